@@ -29,6 +29,7 @@ func newGatewayCommand() *cobra.Command {
 type GatewayArgs struct {
 	HTTPAddr                     string `long:"http_addr" env:"HTTP_ADDR" default:":8080"`
 	grpckit.GrpcClientConnConfig `group:"grpc" namespace:"grpc" env-namespace:"GRPC"`
+	grpckit.TrustedProxyConfig   `group:"user" namespace:"user" env-namespace:"USER"`
 	runkit.GracefulConfig        `group:"graceful" namespace:"graceful" env-namespace:"GRACEFUL"`
 	logkit.LoggerConfig          `group:"logger" namespace:"logger" env-namespace:"LOGGER"`
 }
@@ -66,14 +67,19 @@ func runGateway(_ *cobra.Command, _ []string) error {
 		}
 	}()
 
-	return runkit.GracefulRun(serveHTTP(lis, conn.ClientConn, logger), &args.GracefulConfig)
+	return runkit.GracefulRun(serveHTTP(lis, conn.ClientConn, &args.TrustedProxyConfig, logger), &args.GracefulConfig)
 }
 
-func serveHTTP(lis net.Listener, conn *grpc.ClientConn, logger *logkit.Logger) runkit.GracefulRunFunc {
-	mux := runtime.NewServeMux()
+func serveHTTP(lis net.Listener, conn *grpc.ClientConn, proxyConf *grpckit.TrustedProxyConfig, logger *logkit.Logger) runkit.GracefulRunFunc {
+	mux := runtime.NewServeMux(runtime.WithIncomingHeaderMatcher(grpckit.GatewayHeaderMatcher))
+
+	handler, err := grpckit.NewTrustedProxyHandler(mux, proxyConf)
+	if err != nil {
+		logger.Fatal("failed to create trusted proxy handler", zap.Error(err))
+	}
 
 	httpServer := &http.Server{
-		Handler:           mux,
+		Handler:           handler,
 		ReadHeaderTimeout: 10 * time.Second,
 	}
 
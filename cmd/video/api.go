@@ -5,11 +5,9 @@ import (
 	"log"
 	"net"
 
-	commentpb "github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/comment/pb"
 	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/dao"
 	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/pb"
 	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/service"
-	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/grpckit"
 	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/kafkakit"
 	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/logkit"
 	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/mongokit"
@@ -32,13 +30,17 @@ func newAPICommand() *cobra.Command {
 }
 
 type APIArgs struct {
-	GRPCAddr                             string                       `long:"grpc_addr" env:"GRPC_ADDR" default:":8081"`
-	CommentClientConnConfig              grpckit.GrpcClientConnConfig `group:"comment" namespace:"comment" env-namespace:"COMMENT"`
+	GRPCAddr                             string `long:"grpc_addr" env:"GRPC_ADDR" default:":8081"`
 	runkit.GracefulConfig                `group:"graceful" namespace:"graceful" env-namespace:"GRACEFUL"`
 	logkit.LoggerConfig                  `group:"logger" namespace:"logger" env-namespace:"LOGGER"`
 	mongokit.MongoConfig                 `group:"mongo" namespace:"mongo" env-namespace:"MONGO"`
+	storagekit.StorageConfig             `group:"storage" namespace:"storage" env-namespace:"STORAGE"`
 	storagekit.MinIOConfig               `group:"minio" namespace:"minio" env-namespace:"MINIO"`
+	storagekit.FileSystemConfig          `group:"filesystem" namespace:"filesystem" env-namespace:"FILESYSTEM"`
+	storagekit.MemoryConfig              `group:"memory" namespace:"memory" env-namespace:"MEMORY"`
+	storagekit.URLConfig                 `group:"storage_url" namespace:"storage_url" env-namespace:"STORAGE_URL"`
 	rediskit.RedisConfig                 `group:"redis" namespace:"redis" env-namespace:"REDIS"`
+	dao.RedisVideoDAOConfig              `group:"video_cache" namespace:"video_cache" env-namespace:"VIDEO_CACHE"`
 	otelkit.PrometheusServiceMeterConfig `group:"meter" namespace:"meter" env-namespace:"METER"`
 	kafkakit.KafkaProducerConfig         `group:"kafka_producer" namespace:"kafka_producer" env-namespace:"KAFKA_PRODUCER"`
 }
@@ -72,26 +74,27 @@ func runAPI(_ *cobra.Command, _ []string) error {
 		}
 	}()
 
-	commentClientConn := grpckit.NewGrpcClientConn(ctx, &args.CommentClientConnConfig)
+	producer := kafkakit.NewKafkaProducer(ctx, &args.KafkaProducerConfig)
 	defer func() {
-		if err := commentClientConn.Close(); err != nil {
-			logger.Fatal("failed to close comment gRPC client", zap.Error(err))
+		if err := producer.Close(); err != nil {
+			logger.Fatal("failed to close Kafka producer", zap.Error(err))
 		}
 	}()
 
-	producer := kafkakit.NewKafkaProducer(ctx, &args.KafkaProducerConfig)
+	meter := otelkit.NewPrometheusServiceMeter(ctx, &args.PrometheusServiceMeterConfig)
 	defer func() {
-		if err := producer.Close(); err != nil {
-			logger.Fatal("failed to close Kafka producer", zap.Error(err))
+		if err := meter.Close(); err != nil {
+			logger.Fatal("failed to close meter", zap.Error(err))
 		}
 	}()
 
 	mongoVideoDAO := dao.NewMongoVideoDAO(mongoClient.Database().Collection("videos"))
-	videoDAO := dao.NewRedisVideoDAO(redisClient, mongoVideoDAO)
-	storage := storagekit.NewMinIOClient(ctx, &args.MinIOConfig)
-	commentClient := commentpb.NewCommentClient(commentClientConn)
+	videoDAO := dao.NewRedisVideoDAO(redisClient, mongoVideoDAO, otelkit.NewCacheMeter(ctx, meter, "video"), &args.RedisVideoDAOConfig)
+	uploadSessionDAO := dao.NewMongoUploadSessionDAO(mongoClient.Database().Collection("upload_sessions"))
+	storage := storagekit.NewStorage(ctx, &args.StorageConfig, &args.MinIOConfig, &args.FileSystemConfig, &args.MemoryConfig)
+	urlBuilder := storagekit.NewURLBuilder(ctx, &args.URLConfig, storage)
 
-	svc := service.NewService(videoDAO, storage, commentClient, producer)
+	svc := service.NewService(videoDAO, uploadSessionDAO, storage, urlBuilder, producer)
 
 	logger.Info("listen to gRPC addr", zap.String("grpc_addr", args.GRPCAddr))
 	lis, err := net.Listen("tcp", args.GRPCAddr)
@@ -104,13 +107,6 @@ func runAPI(_ *cobra.Command, _ []string) error {
 		}
 	}()
 
-	meter := otelkit.NewPrometheusServiceMeter(ctx, &args.PrometheusServiceMeterConfig)
-	defer func() {
-		if err := meter.Close(); err != nil {
-			logger.Fatal("failed to close meter", zap.Error(err))
-		}
-	}()
-
 	return runkit.GracefulRun(serveGRPC(lis, svc, logger, grpc.UnaryInterceptor(meter.UnaryServerInterceptor())), &args.GracefulConfig)
 }
 
@@ -31,6 +31,7 @@ type GatewayArgs struct {
 	HTTPAddr                     string `long:"http_addr" env:"HTTP_ADDR" default:":8080"`
 	GRPCAddr                     string `long:"grpc_addr" env:"GRPC_ADDR" default:":8081"`
 	grpckit.GrpcClientConnConfig `group:"grpc" namespace:"grpc" env-namespace:"GRPC"`
+	grpckit.TrustedProxyConfig   `group:"user" namespace:"user" env-namespace:"USER"`
 	runkit.GracefulConfig        `group:"graceful" namespace:"graceful" env-namespace:"GRACEFUL"`
 	logkit.LoggerConfig          `group:"logger" namespace:"logger" env-namespace:"LOGGER"`
 }
@@ -68,11 +69,11 @@ func runGateway(_ *cobra.Command, _ []string) error {
 		}
 	}()
 
-	return runkit.GracefulRun(serveHTTP(lis, conn.ClientConn, logger), &args.GracefulConfig)
+	return runkit.GracefulRun(serveHTTP(lis, conn.ClientConn, &args.TrustedProxyConfig, logger), &args.GracefulConfig)
 }
 
-func serveHTTP(lis net.Listener, conn *grpc.ClientConn, logger *logkit.Logger) runkit.GracefulRunFunc {
-	mux := runtime.NewServeMux()
+func serveHTTP(lis net.Listener, conn *grpc.ClientConn, proxyConf *grpckit.TrustedProxyConfig, logger *logkit.Logger) runkit.GracefulRunFunc {
+	mux := runtime.NewServeMux(runtime.WithIncomingHeaderMatcher(grpckit.GatewayHeaderMatcher))
 
 	// register additional routes
 	handler := gateway.NewHandler(pb.NewVideoClient(conn), logger)
@@ -80,8 +81,21 @@ func serveHTTP(lis net.Listener, conn *grpc.ClientConn, logger *logkit.Logger) r
 		logger.Fatal("failed to register additional routes", zap.Error(err))
 	}
 
+	if err := mux.HandlePath("PATCH", "/v1/uploads/{id}", handler.HandleUploadPart); err != nil {
+		logger.Fatal("failed to register additional routes", zap.Error(err))
+	}
+
+	if err := mux.HandlePath("HEAD", "/v1/uploads/{id}", handler.HandleGetUploadOffset); err != nil {
+		logger.Fatal("failed to register additional routes", zap.Error(err))
+	}
+
+	trustedHandler, err := grpckit.NewTrustedProxyHandler(mux, proxyConf)
+	if err != nil {
+		logger.Fatal("failed to create trusted proxy handler", zap.Error(err))
+	}
+
 	httpServer := &http.Server{
-		Handler:           mux,
+		Handler:           trustedHandler,
 		ReadHeaderTimeout: 10 * time.Second,
 	}
 
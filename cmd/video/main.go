@@ -11,6 +11,9 @@ func NewVideoCommand() *cobra.Command {
 	cmd.AddCommand(newAPICommand())
 	cmd.AddCommand(newGatewayCommand())
 	cmd.AddCommand(newStreamCommand())
+	cmd.AddCommand(newMigrationCommand())
+	cmd.AddCommand(newPurgeCommand())
+	cmd.AddCommand(newSchedulerCommand())
 
 	return cmd
 }
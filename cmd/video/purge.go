@@ -0,0 +1,94 @@
+package video
+
+import (
+	"context"
+	"log"
+
+	commentpb "github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/comment/pb"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/dao"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/service"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/grpckit"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/kafkakit"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/logkit"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/mongokit"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/rediskit"
+	flags "github.com/jessevdk/go-flags"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+func newPurgeCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "purge",
+		Short: "runs the video purge job, which purges the videos in the trash after the retention",
+		RunE:  runPurge,
+	}
+}
+
+type PurgeArgs struct {
+	CommentClientConnConfig    grpckit.GrpcClientConnConfig `group:"comment" namespace:"comment" env-namespace:"COMMENT"`
+	VideoDeletedProducerConfig kafkakit.KafkaProducerConfig `group:"kafka_video_deleted_producer" namespace:"kafka_video_deleted_producer" env-namespace:"KAFKA_VIDEO_DELETED_PRODUCER"`
+	logkit.LoggerConfig        `group:"logger" namespace:"logger" env-namespace:"LOGGER"`
+	mongokit.MongoConfig       `group:"mongo" namespace:"mongo" env-namespace:"MONGO"`
+	rediskit.RedisConfig       `group:"redis" namespace:"redis" env-namespace:"REDIS"`
+	service.PurgeConfig        `group:"purge" namespace:"purge" env-namespace:"PURGE"`
+}
+
+func runPurge(_ *cobra.Command, _ []string) error {
+	ctx := context.Background()
+
+	var args PurgeArgs
+	if _, err := flags.NewParser(&args, flags.Default).Parse(); err != nil {
+		log.Fatal("failed to parse flag", err.Error())
+	}
+
+	logger := logkit.NewLogger(&args.LoggerConfig)
+	defer func() {
+		_ = logger.Sync()
+	}()
+
+	ctx = logger.WithContext(ctx)
+
+	mongoClient := mongokit.NewMongoClient(ctx, &args.MongoConfig)
+	defer func() {
+		if err := mongoClient.Close(); err != nil {
+			logger.Fatal("failed to close mongo client", zap.Error(err))
+		}
+	}()
+
+	redisClient := rediskit.NewRedisClient(ctx, &args.RedisConfig)
+	defer func() {
+		if err := redisClient.Close(); err != nil {
+			logger.Fatal("failed to close redis client", zap.Error(err))
+		}
+	}()
+
+	commentClientConn := grpckit.NewGrpcClientConn(ctx, &args.CommentClientConnConfig)
+	defer func() {
+		if err := commentClientConn.Close(); err != nil {
+			logger.Fatal("failed to close comment gRPC client", zap.Error(err))
+		}
+	}()
+
+	videoDeletedProducer := kafkakit.NewKafkaProducer(ctx, &args.VideoDeletedProducerConfig)
+	defer func() {
+		if err := videoDeletedProducer.Close(); err != nil {
+			logger.Fatal("failed to close Kafka video deleted producer", zap.Error(err))
+		}
+	}()
+
+	// the trash is read from MongoDB directly, the cached pages may miss the videos just deleted
+	videoDAO := dao.NewUncachedVideoDAO(redisClient, dao.NewMongoVideoDAO(mongoClient.Database().Collection("videos")))
+	commentClient := commentpb.NewCommentClient(commentClientConn)
+
+	purger := service.NewPurger(videoDAO, commentClient, videoDeletedProducer, &args.PurgeConfig)
+
+	purged, err := purger.Purge(ctx)
+	if err != nil {
+		logger.Fatal("failed to purge videos", zap.Int("purged", purged), zap.Error(err))
+	}
+
+	logger.Info("run purge job successfully, terminating ...", zap.Int("purged", purged))
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package video
+
+import (
+	"context"
+	"log"
+
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/dao"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/service"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/kafkakit"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/logkit"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/mongokit"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/rediskit"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/runkit"
+	flags "github.com/jessevdk/go-flags"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// schedulerLeaderKey is the Redis key of the lease held by the leading scheduler
+const schedulerLeaderKey = "video:scheduler:leader"
+
+func newSchedulerCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "scheduler",
+		Short: "starts video scheduler, which publishes the scheduled videos on the leading replica",
+		RunE:  runScheduler,
+	}
+}
+
+type SchedulerArgs struct {
+	VideoPublishedProducerConfig  kafkakit.KafkaProducerConfig `group:"kafka_video_published_producer" namespace:"kafka_video_published_producer" env-namespace:"KAFKA_VIDEO_PUBLISHED_PRODUCER"`
+	runkit.GracefulConfig         `group:"graceful" namespace:"graceful" env-namespace:"GRACEFUL"`
+	logkit.LoggerConfig           `group:"logger" namespace:"logger" env-namespace:"LOGGER"`
+	mongokit.MongoConfig          `group:"mongo" namespace:"mongo" env-namespace:"MONGO"`
+	rediskit.RedisConfig          `group:"redis" namespace:"redis" env-namespace:"REDIS"`
+	rediskit.LeaderElectionConfig `group:"leader_election" namespace:"leader_election" env-namespace:"LEADER_ELECTION"`
+	service.SchedulerConfig       `group:"scheduler" namespace:"scheduler" env-namespace:"SCHEDULER"`
+}
+
+func runScheduler(_ *cobra.Command, _ []string) error {
+	ctx := context.Background()
+
+	var args SchedulerArgs
+	if _, err := flags.NewParser(&args, flags.Default).Parse(); err != nil {
+		log.Fatal("failed to parse flag", err.Error())
+	}
+
+	logger := logkit.NewLogger(&args.LoggerConfig)
+	defer func() {
+		_ = logger.Sync()
+	}()
+
+	ctx = logger.WithContext(ctx)
+
+	mongoClient := mongokit.NewMongoClient(ctx, &args.MongoConfig)
+	defer func() {
+		if err := mongoClient.Close(); err != nil {
+			logger.Fatal("failed to close mongo client", zap.Error(err))
+		}
+	}()
+
+	redisClient := rediskit.NewRedisClient(ctx, &args.RedisConfig)
+	defer func() {
+		if err := redisClient.Close(); err != nil {
+			logger.Fatal("failed to close redis client", zap.Error(err))
+		}
+	}()
+
+	videoPublishedProducer := kafkakit.NewKafkaProducer(ctx, &args.VideoPublishedProducerConfig)
+	defer func() {
+		if err := videoPublishedProducer.Close(); err != nil {
+			logger.Fatal("failed to close Kafka video published producer", zap.Error(err))
+		}
+	}()
+
+	// the scheduled videos are read from MongoDB directly, the cached pages may miss the videos just scheduled
+	videoDAO := dao.NewUncachedVideoDAO(redisClient, dao.NewMongoVideoDAO(mongoClient.Database().Collection("videos")))
+
+	scheduler := service.NewScheduler(videoDAO, videoPublishedProducer, &args.SchedulerConfig)
+	elector := rediskit.NewLeaderElector(redisClient, schedulerLeaderKey, &args.LeaderElectionConfig)
+
+	logger.Info("starting video scheduler")
+
+	return runkit.GracefulRun(func(ctx context.Context) error {
+		return elector.Run(logger.WithContext(ctx), func(ctx context.Context) {
+			// the scheduler only returns once the leadership is lost
+			_ = scheduler.Run(ctx)
+		})
+	}, &args.GracefulConfig)
+}
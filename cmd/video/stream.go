@@ -10,10 +10,13 @@ import (
 	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/kafkakit"
 	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/logkit"
 	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/mongokit"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/rediskit"
 	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/runkit"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/storagekit"
 	flags "github.com/jessevdk/go-flags"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
 )
 
 func newStreamCommand() *cobra.Command {
@@ -25,9 +28,19 @@ func newStreamCommand() *cobra.Command {
 }
 
 type StreamArgs struct {
+	VideoDeletedConsumerConfig   kafkakit.KafkaConsumerConfig `group:"kafka_video_deleted_consumer" namespace:"kafka_video_deleted_consumer" env-namespace:"KAFKA_VIDEO_DELETED_CONSUMER"`
 	runkit.GracefulConfig        `group:"graceful" namespace:"graceful" env-namespace:"GRACEFUL"`
 	logkit.LoggerConfig          `group:"logger" namespace:"logger" env-namespace:"LOGGER"`
 	mongokit.MongoConfig         `group:"mongo" namespace:"mongo" env-namespace:"MONGO"`
+	rediskit.RedisConfig         `group:"redis" namespace:"redis" env-namespace:"REDIS"`
+	storagekit.StorageConfig     `group:"storage" namespace:"storage" env-namespace:"STORAGE"`
+	storagekit.MinIOConfig       `group:"minio" namespace:"minio" env-namespace:"MINIO"`
+	storagekit.FileSystemConfig  `group:"filesystem" namespace:"filesystem" env-namespace:"FILESYSTEM"`
+	storagekit.MemoryConfig      `group:"memory" namespace:"memory" env-namespace:"MEMORY"`
+	stream.TranscoderConfig      `group:"transcoder" namespace:"transcoder" env-namespace:"TRANSCODER"`
+	stream.FFmpegConfig          `group:"ffmpeg" namespace:"ffmpeg" env-namespace:"FFMPEG"`
+	stream.RetryConfig           `group:"retry" namespace:"retry" env-namespace:"RETRY"`
+	stream.ProfileConfig         `group:"profile" namespace:"profile" env-namespace:"PROFILE"`
 	kafkakit.KafkaProducerConfig `group:"kafka_producer" namespace:"kafka_producer" env-namespace:"KAFKA_PRODUCER"`
 	kafkakit.KafkaConsumerConfig `group:"kafka_consumer" namespace:"kafka_consumer" env-namespace:"KAFKA_CONSUMER"`
 }
@@ -54,6 +67,13 @@ func runStream(_ *cobra.Command, _ []string) error {
 		}
 	}()
 
+	redisClient := rediskit.NewRedisClient(ctx, &args.RedisConfig)
+	defer func() {
+		if err := redisClient.Close(); err != nil {
+			logger.Fatal("failed to close redis client", zap.Error(err))
+		}
+	}()
+
 	producer := kafkakit.NewKafkaProducer(ctx, &args.KafkaProducerConfig)
 	defer func() {
 		if err := producer.Close(); err != nil {
@@ -68,18 +88,41 @@ func runStream(_ *cobra.Command, _ []string) error {
 		}
 	}()
 
-	videoDAO := dao.NewMongoVideoDAO(mongoClient.Database().Collection("videos"))
+	videoDeletedConsumer := kafkakit.NewKafkaConsumer(ctx, &args.VideoDeletedConsumerConfig)
+	defer func() {
+		if err := videoDeletedConsumer.Close(); err != nil {
+			logger.Fatal("failed to close Kafka video deleted consumer", zap.Error(err))
+		}
+	}()
+
+	// the manifest is written from the latest video, so the reads skip the cache of the API
+	videoDAO := dao.NewUncachedVideoDAO(redisClient, dao.NewMongoVideoDAO(mongoClient.Database().Collection("videos")))
+	profileDAO := stream.NewProfileDAO(ctx, &args.ProfileConfig, mongoClient.Database().Collection("profiles"))
+	storage := storagekit.NewStorage(ctx, &args.StorageConfig, &args.MinIOConfig, &args.FileSystemConfig, &args.MemoryConfig)
+
+	transcoder := stream.NewTranscoder(storage, &args.TranscoderConfig, &args.FFmpegConfig)
 
-	svc := stream.NewStream(videoDAO, producer)
+	svc := stream.NewStream(videoDAO, profileDAO, storage, transcoder, producer, &args.RetryConfig)
 
-	return runkit.GracefulRun(serveConsumer(consumer, svc, logger), &args.GracefulConfig)
+	return runkit.GracefulRun(serveConsumer(consumer, videoDeletedConsumer, svc, logger), &args.GracefulConfig)
 }
 
-func serveConsumer(consumer *kafkakit.KafkaConsumer, svc pb.VideoStreamServer, logger *logkit.Logger) runkit.GracefulRunFunc {
+func serveConsumer(consumer, videoDeletedConsumer *kafkakit.KafkaConsumer, svc pb.VideoStreamServer, logger *logkit.Logger) runkit.GracefulRunFunc {
 	handlers := pb.NewVideoStreamHandlers(svc, logkit.NewSaramaLogger(logger))
 
 	return func(ctx context.Context) error {
-		if err := consumer.Consume(ctx, handlers.HandleVideoCreatedHandler); err != nil {
+		// each topic carries a single message type, so every handler has its own consumer
+		eg, ctx := errgroup.WithContext(ctx)
+
+		eg.Go(func() error {
+			return consumer.Consume(ctx, handlers.HandleVideoCreatedHandler)
+		})
+
+		eg.Go(func() error {
+			return videoDeletedConsumer.Consume(ctx, handlers.HandleVideoDeletedHandler)
+		})
+
+		if err := eg.Wait(); err != nil {
 			return err
 		}
 
@@ -31,8 +31,10 @@ func (c *Comment) ToProto() *pb.CommentInfo {
 }
 
 type CommentDAO interface {
+	Get(ctx context.Context, id uuid.UUID) (*Comment, error)
 	ListByVideoID(ctx context.Context, videoID string, limit, offset int) ([]*Comment, error)
 	Create(ctx context.Context, comment *Comment) (uuid.UUID, error)
+	// Update sets the content of the comment, and fills the other fields of the comment from the updated row
 	Update(ctx context.Context, comment *Comment) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	DeleteByVideoID(ctx context.Context, videoID string) error
@@ -42,8 +44,13 @@ var (
 	ErrCommentNotFound = errors.New("comment not found")
 )
 
-func listCommentKey(videoID string, limit, offset int) string {
-	return fmt.Sprintf("listComment:%s:%d:%d", videoID, limit, offset)
+// listCommentKey is versioned by the comments of the video, so a write invalidates all the cached pages of the video at once
+func listCommentKey(videoID string, version int64, limit, offset int) string {
+	return fmt.Sprintf("listComment:%s:%d:%d:%d", videoID, version, limit, offset)
+}
+
+func listCommentVersionKey(videoID string) string {
+	return fmt.Sprintf("listComment:%s:version", videoID)
 }
 
 func NewFakeComment(videoID string) *Comment {
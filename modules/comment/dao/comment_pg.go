@@ -21,6 +21,19 @@ func NewPGCommentDAO(pgClient *pgkit.PGClient) *pgCommentDAO {
 	}
 }
 
+func (dao *pgCommentDAO) Get(ctx context.Context, id uuid.UUID) (*Comment, error) {
+	comment := &Comment{ID: id}
+	if err := dao.client.ModelContext(ctx, comment).WherePK().Select(); err != nil {
+		if errors.Is(err, pg.ErrNoRows) {
+			return nil, ErrCommentNotFound
+		}
+
+		return nil, err
+	}
+
+	return comment, nil
+}
+
 func (dao *pgCommentDAO) ListByVideoID(ctx context.Context, videoID string, limit, offset int) ([]*Comment, error) {
 	var comments []*Comment
 	query := dao.client.ModelContext(ctx, &comments).
@@ -21,6 +21,48 @@ var _ = Describe("PGCommentDAO", func() {
 		ctx = context.Background()
 	})
 
+	Describe("Get", func() {
+		var (
+			comment *Comment
+			id      uuid.UUID
+
+			resp *Comment
+			err  error
+		)
+
+		BeforeEach(func() {
+			comment = NewFakeComment("")
+
+			insertComment(comment)
+		})
+
+		AfterEach(func() {
+			deleteComment(comment.ID)
+		})
+
+		JustBeforeEach(func() {
+			resp, err = commentDAO.Get(ctx, id)
+		})
+
+		When("comment not found", func() {
+			BeforeEach(func() { id = uuid.New() })
+
+			It("returns comment not found error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrCommentNotFound))
+			})
+		})
+
+		When("success", func() {
+			BeforeEach(func() { id = comment.ID })
+
+			It("returns the comment with no error", func() {
+				Expect(resp).To(matchComment(comment))
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+	})
+
 	Describe("ListByVideoID", func() {
 		var (
 			comments []*Comment
@@ -2,14 +2,19 @@ package dao
 
 import (
 	"context"
+	"errors"
 	"time"
 
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/logkit"
 	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/rediskit"
 	"github.com/go-redis/cache/v8"
+	"github.com/go-redis/redis/v8"
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 )
 
 type redisCommentDAO struct {
+	client  *rediskit.RedisClient
 	cache   *cache.Cache
 	baseDAO CommentDAO
 }
@@ -20,23 +25,40 @@ const (
 	commentDAOLocalCacheSize     = 1024
 	commentDAOLocalCacheDuration = 1 * time.Minute
 	commentDAORedisCacheDuration = 3 * time.Minute
+
+	// commentDAOVersionDuration outlives the cached pages and is refreshed on every read and write,
+	// so a version only expires after its pages, and an expired version never serves a stale page
+	commentDAOVersionDuration = 24 * time.Hour
+	// the invalidation outlives the write, so a canceled request does not leave the pages stale
+	commentDAOInvalidateTimeout = 5 * time.Second
 )
 
 func NewRedisCommentDAO(client *rediskit.RedisClient, baseDAO CommentDAO) *redisCommentDAO {
 	return &redisCommentDAO{
+		client: client,
 		cache: cache.New(&cache.Options{
-			Redis:      client,
-			LocalCache: cache.NewTinyLFU(commentDAOLocalCacheSize, commentDAOLocalCacheDuration),
+			Redis: client,
+			// the local cache drops the keys evicted by any replica, while the pages are versioned
+			// and need no eviction on writes
+			LocalCache: client.InvalidationBus().NewLocalCache(commentDAOLocalCacheSize, commentDAOLocalCacheDuration),
 		}),
 		baseDAO: baseDAO,
 	}
 }
 
 func (dao *redisCommentDAO) ListByVideoID(ctx context.Context, videoID string, limit, offset int) ([]*Comment, error) {
+	// the comments are read from baseDAO directly when Redis is unavailable
+	version, err := dao.listVersion(ctx, videoID)
+	if err != nil {
+		logkit.FromContext(ctx).Error("failed to get the version of the cached comments", zap.String("videoID", videoID), zap.Error(err))
+
+		return dao.baseDAO.ListByVideoID(ctx, videoID, limit, offset)
+	}
+
 	var comment []*Comment
 
 	if err := dao.cache.Once(&cache.Item{
-		Key:   listCommentKey(videoID, limit, offset),
+		Key:   listCommentKey(videoID, version, limit, offset),
 		Value: &comment,
 		TTL:   commentDAORedisCacheDuration,
 		Do: func(*cache.Item) (interface{}, error) {
@@ -48,20 +70,74 @@ func (dao *redisCommentDAO) ListByVideoID(ctx context.Context, videoID string, l
 	return comment, nil
 }
 
-// The following operations are not cachable, just pass down to baseDAO
+// Get is not cached since it is only used to resolve a comment before writing it
+func (dao *redisCommentDAO) Get(ctx context.Context, id uuid.UUID) (*Comment, error) {
+	return dao.baseDAO.Get(ctx, id)
+}
+
+// The following operations write to baseDAO and then invalidate the cached pages of the video.
+// A write that fails may still have been applied, so the pages are invalidated regardless
+// whenever the video is known.
 
 func (dao *redisCommentDAO) Create(ctx context.Context, comment *Comment) (uuid.UUID, error) {
+	defer dao.invalidate(ctx, comment.VideoID)
+
 	return dao.baseDAO.Create(ctx, comment)
 }
 
 func (dao *redisCommentDAO) Update(ctx context.Context, comment *Comment) error {
-	return dao.baseDAO.Update(ctx, comment)
+	if err := dao.baseDAO.Update(ctx, comment); err != nil {
+		return err
+	}
+
+	// the video of the comment is filled by the update
+	dao.invalidate(ctx, comment.VideoID)
+
+	return nil
 }
 
 func (dao *redisCommentDAO) Delete(ctx context.Context, id uuid.UUID) error {
+	// the comment is resolved before the delete since the video of a deleted comment is unknown
+	comment, err := dao.baseDAO.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	defer dao.invalidate(ctx, comment.VideoID)
+
 	return dao.baseDAO.Delete(ctx, id)
 }
 
 func (dao *redisCommentDAO) DeleteByVideoID(ctx context.Context, videoID string) error {
+	defer dao.invalidate(ctx, videoID)
+
 	return dao.baseDAO.DeleteByVideoID(ctx, videoID)
 }
+
+// listVersion returns the current version of the cached pages of the video, which is zero before the first write
+func (dao *redisCommentDAO) listVersion(ctx context.Context, videoID string) (int64, error) {
+	version, err := dao.client.GetEx(ctx, listCommentVersionKey(videoID), commentDAOVersionDuration).Int64()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return 0, err
+	}
+
+	return version, nil
+}
+
+// invalidate moves the cached pages of the video to the next version, the pages of the previous versions
+// are never read again and expire by their TTL. A failure is logged instead of failing the write which
+// has been applied, and the stale pages expire by their TTL.
+func (dao *redisCommentDAO) invalidate(ctx context.Context, videoID string) {
+	ctx, cancel := context.WithTimeout(logkit.FromContext(ctx).WithContext(context.Background()), commentDAOInvalidateTimeout)
+	defer cancel()
+
+	key := listCommentVersionKey(videoID)
+
+	if _, err := dao.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Incr(ctx, key)
+		pipe.Expire(ctx, key, commentDAOVersionDuration)
+		return nil
+	}); err != nil {
+		logkit.FromContext(ctx).Error("failed to invalidate the cached comments", zap.String("videoID", videoID), zap.Error(err))
+	}
+}
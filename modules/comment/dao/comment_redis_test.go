@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/go-redis/cache/v8"
+	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -114,12 +115,152 @@ var _ = Describe("CommentRedisDAO", func() {
 
 				It("insert the comments to cache", func() {
 					var getComments []*Comment
-					Expect(redisCommentDAO.cache.Get(ctx, listCommentKey(videoID, limit, offset), &getComments)).NotTo(HaveOccurred())
+					Expect(redisCommentDAO.cache.Get(ctx, listCommentKey(videoID, 0, limit, offset), &getComments)).NotTo(HaveOccurred())
 					for i := range getComments {
 						Expect(getComments[i]).To(matchComment(comments[i]))
 					}
 				})
 			})
+
+			When("version is unreadable", func() {
+				BeforeEach(func() {
+					Expect(redisClient.Set(ctx, listCommentVersionKey(videoID), "not a version", 0).Err()).NotTo(HaveOccurred())
+				})
+
+				AfterEach(func() {
+					Expect(redisClient.Del(ctx, listCommentVersionKey(videoID)).Err()).NotTo(HaveOccurred())
+				})
+
+				It("returns the comments read from the database", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(resp).To(HaveLen(limit))
+					for i := range resp {
+						Expect(resp[i]).To(matchComment(comments[i]))
+					}
+				})
+			})
+		})
+	})
+
+	Describe("invalidation", func() {
+		var (
+			comments []*Comment
+			videoID  string
+		)
+
+		BeforeEach(func() {
+			videoID = primitive.NewObjectID().Hex()
+			comments = []*Comment{NewFakeComment(videoID), NewFakeComment(videoID)}
+			for _, comment := range comments {
+				insertComment(comment)
+			}
+
+			// caches the pages of the video before the write
+			for _, offset := range []int{0, 1} {
+				resp, err := redisCommentDAO.ListByVideoID(ctx, videoID, 1, offset)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp).To(HaveLen(1))
+			}
+		})
+
+		AfterEach(func() {
+			_, err := pgClient.Exec("DELETE FROM comments WHERE video_id = ?", videoID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(redisClient.Del(ctx, listCommentVersionKey(videoID)).Err()).NotTo(HaveOccurred())
+		})
+
+		listAll := func() []*Comment {
+			resp, err := redisCommentDAO.ListByVideoID(ctx, videoID, 10, 0)
+			Expect(err).NotTo(HaveOccurred())
+			return resp
+		}
+
+		listPage := func(offset int) []*Comment {
+			resp, err := redisCommentDAO.ListByVideoID(ctx, videoID, 1, offset)
+			Expect(err).NotTo(HaveOccurred())
+			return resp
+		}
+
+		It("moves the pages of the video to the next version on writes", func() {
+			Expect(redisCommentDAO.listVersion(ctx, videoID)).To(BeZero())
+
+			_, err := redisCommentDAO.Create(ctx, NewFakeComment(videoID))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(redisCommentDAO.listVersion(ctx, videoID)).To(BeEquivalentTo(1))
+			Expect(redisClient.TTL(ctx, listCommentVersionKey(videoID)).Val()).To(BeNumerically(">", commentDAORedisCacheDuration))
+		})
+
+		It("does not invalidate the pages of the other videos", func() {
+			otherVideoID := primitive.NewObjectID().Hex()
+
+			_, err := redisCommentDAO.Create(ctx, NewFakeComment(otherVideoID))
+			Expect(err).NotTo(HaveOccurred())
+			defer func() {
+				_, err := pgClient.Exec("DELETE FROM comments WHERE video_id = ?", otherVideoID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(redisClient.Del(ctx, listCommentVersionKey(otherVideoID)).Err()).NotTo(HaveOccurred())
+			}()
+
+			Expect(redisCommentDAO.listVersion(ctx, videoID)).To(BeZero())
+		})
+
+		When("a comment is created", func() {
+			It("lists the created comment", func() {
+				comment := NewFakeComment(videoID)
+
+				_, err := redisCommentDAO.Create(ctx, comment)
+				Expect(err).NotTo(HaveOccurred())
+
+				resp := listAll()
+				Expect(resp).To(HaveLen(3))
+				Expect(resp[2]).To(matchComment(comment))
+			})
+		})
+
+		When("a comment is updated", func() {
+			It("lists the updated comment", func() {
+				comment := &Comment{ID: comments[0].ID, Content: "updated"}
+
+				Expect(redisCommentDAO.Update(ctx, comment)).To(Succeed())
+
+				Expect(listPage(0)[0].Content).To(Equal("updated"))
+			})
+		})
+
+		When("a comment is deleted", func() {
+			It("shifts the following pages", func() {
+				Expect(redisCommentDAO.Delete(ctx, comments[0].ID)).To(Succeed())
+
+				Expect(listPage(0)[0]).To(matchComment(comments[1]))
+				Expect(listPage(1)).To(BeEmpty())
+			})
+
+			It("returns comment not found error for an unknown comment", func() {
+				Expect(redisCommentDAO.Delete(ctx, uuid.New())).To(MatchError(ErrCommentNotFound))
+				Expect(redisCommentDAO.listVersion(ctx, videoID)).To(BeZero())
+			})
+		})
+
+		When("the writing request is canceled", func() {
+			It("moves the pages of the video to the next version", func() {
+				canceledCtx, cancel := context.WithCancel(ctx)
+				cancel()
+
+				// the delete may fail on the canceled context, the pages are invalidated regardless
+				_ = redisCommentDAO.DeleteByVideoID(canceledCtx, videoID)
+
+				Expect(redisCommentDAO.listVersion(ctx, videoID)).To(BeEquivalentTo(1))
+			})
+		})
+
+		When("the comments of the video are deleted", func() {
+			It("lists no comment", func() {
+				Expect(redisCommentDAO.DeleteByVideoID(ctx, videoID)).To(Succeed())
+
+				Expect(listPage(0)).To(BeEmpty())
+				Expect(listPage(1)).To(BeEmpty())
+			})
 		})
 	})
 })
@@ -127,12 +268,12 @@ var _ = Describe("CommentRedisDAO", func() {
 func insertCommentsInRedis(ctx context.Context, commentDAO *redisCommentDAO, comments []*Comment, videoID string, limit, offset int) {
 	Expect(commentDAO.cache.Set(&cache.Item{
 		Ctx:   ctx,
-		Key:   listCommentKey(videoID, limit, offset),
+		Key:   listCommentKey(videoID, 0, limit, offset),
 		Value: comments,
 		TTL:   commentDAORedisCacheDuration,
 	})).NotTo(HaveOccurred())
 }
 
 func deleteCommentsInRedis(ctx context.Context, commentDAO *redisCommentDAO, videoID string, limit, offset int) {
-	Expect(commentDAO.cache.Delete(ctx, listCommentKey(videoID, limit, offset))).NotTo(HaveOccurred())
+	Expect(commentDAO.cache.Delete(ctx, listCommentKey(videoID, 0, limit, offset))).NotTo(HaveOccurred())
 }
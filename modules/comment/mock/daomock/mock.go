@@ -79,6 +79,21 @@ func (mr *MockCommentDAOMockRecorder) DeleteByVideoID(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByVideoID", reflect.TypeOf((*MockCommentDAO)(nil).DeleteByVideoID), arg0, arg1)
 }
 
+// Get mocks base method.
+func (m *MockCommentDAO) Get(arg0 context.Context, arg1 uuid.UUID) (*dao.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(*dao.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCommentDAOMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCommentDAO)(nil).Get), arg0, arg1)
+}
+
 // ListByVideoID mocks base method.
 func (m *MockCommentDAO) ListByVideoID(arg0 context.Context, arg1 string, arg2, arg3 int) ([]*dao.Comment, error) {
 	m.ctrl.T.Helper()
@@ -7,6 +7,7 @@ import (
 	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/comment/dao"
 	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/comment/pb"
 	videopb "github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/pb"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/grpckit"
 	"github.com/google/uuid"
 )
 
@@ -29,6 +30,13 @@ func (s *service) Healthz(ctx context.Context, req *pb.HealthzRequest) (*pb.Heal
 }
 
 func (s *service) ListComment(ctx context.Context, req *pb.ListCommentRequest) (*pb.ListCommentResponse, error) {
+	// the comments of a video are only listed to the users who can view the video
+	if _, err := s.videoClient.GetVideo(grpckit.ForwardUserID(ctx), &videopb.GetVideoRequest{
+		Id: req.GetVideoId(),
+	}); err != nil {
+		return nil, err
+	}
+
 	comments, err := s.commentDAO.ListByVideoID(ctx, req.GetVideoId(), int(req.GetLimit()), int(req.GetOffset()))
 	if err != nil {
 		return nil, err
@@ -43,7 +51,8 @@ func (s *service) ListComment(ctx context.Context, req *pb.ListCommentRequest) (
 }
 
 func (s *service) CreateComment(ctx context.Context, req *pb.CreateCommentRequest) (*pb.CreateCommentResponse, error) {
-	if _, err := s.videoClient.GetVideo(ctx, &videopb.GetVideoRequest{
+	// the video service hides the private videos the user cannot view
+	if _, err := s.videoClient.GetVideo(grpckit.ForwardUserID(ctx), &videopb.GetVideoRequest{
 		Id: req.GetVideoId(),
 	}); err != nil {
 		return nil, err
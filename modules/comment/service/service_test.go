@@ -14,6 +14,10 @@ import (
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 func TestService(t *testing.T) {
@@ -24,6 +28,7 @@ func TestService(t *testing.T) {
 var (
 	errDAOUnknown          = errors.New("unknown DAO error")
 	errVideoServiceUnknown = errors.New("unknown video service error")
+	errVideoNotFound       = status.Errorf(codes.NotFound, "video not found")
 )
 
 var _ = Describe("Service", func() {
@@ -62,33 +67,99 @@ var _ = Describe("Service", func() {
 			resp, err = svc.ListComment(ctx, req)
 		})
 
-		When("DAO error", func() {
+		When("get video error", func() {
 			BeforeEach(func() {
-				commentDAO.EXPECT().ListByVideoID(ctx, req.GetVideoId(), int(req.GetLimit()), int(req.GetOffset())).Return(nil, errDAOUnknown)
+				videoClient.EXPECT().GetVideo(ctx, &videopb.GetVideoRequest{
+					Id: req.GetVideoId(),
+				}).Return(nil, errVideoServiceUnknown)
 			})
 
 			It("returns the error", func() {
 				Expect(resp).To(BeNil())
-				Expect(err).To(MatchError(errDAOUnknown))
+				Expect(err).To(MatchError(errVideoServiceUnknown))
 			})
 		})
 
-		When("success", func() {
-			var comments []*dao.Comment
+		Context("get video no error", func() {
+			BeforeEach(func() {
+				videoClient.EXPECT().GetVideo(ctx, &videopb.GetVideoRequest{
+					Id: req.GetVideoId(),
+				}).Return(&videopb.GetVideoResponse{}, nil)
+			})
+
+			When("DAO error", func() {
+				BeforeEach(func() {
+					commentDAO.EXPECT().ListByVideoID(ctx, req.GetVideoId(), int(req.GetLimit()), int(req.GetOffset())).Return(nil, errDAOUnknown)
+				})
+
+				It("returns the error", func() {
+					Expect(resp).To(BeNil())
+					Expect(err).To(MatchError(errDAOUnknown))
+				})
+			})
+
+			When("success", func() {
+				var comments []*dao.Comment
+
+				BeforeEach(func() {
+					comments = []*dao.Comment{dao.NewFakeComment(""), dao.NewFakeComment("")}
+					commentDAO.EXPECT().ListByVideoID(ctx, req.GetVideoId(), int(req.GetLimit()), int(req.GetOffset())).Return(comments, nil)
+				})
+
+				It("returns comments with no error", func() {
+					Expect(resp).To(Equal(&pb.ListCommentResponse{
+						Comments: []*pb.CommentInfo{
+							comments[0].ToProto(),
+							comments[1].ToProto(),
+						},
+					}))
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+		})
+		Context("video is private", func() {
+			var forwardedUserID string
 
 			BeforeEach(func() {
-				comments = []*dao.Comment{dao.NewFakeComment(""), dao.NewFakeComment("")}
-				commentDAO.EXPECT().ListByVideoID(ctx, req.GetVideoId(), int(req.GetLimit()), int(req.GetOffset())).Return(comments, nil)
+				videoClient.EXPECT().GetVideo(gomock.Any(), &videopb.GetVideoRequest{
+					Id: req.GetVideoId(),
+				}).DoAndReturn(getPrivateVideo("owner", &forwardedUserID))
 			})
 
-			It("returns comments with no error", func() {
-				Expect(resp).To(Equal(&pb.ListCommentResponse{
-					Comments: []*pb.CommentInfo{
-						comments[0].ToProto(),
-						comments[1].ToProto(),
-					},
-				}))
-				Expect(err).NotTo(HaveOccurred())
+			When("user is the owner", func() {
+				var comments []*dao.Comment
+
+				BeforeEach(func() {
+					ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-user-id", "owner"))
+					comments = []*dao.Comment{dao.NewFakeComment("")}
+					commentDAO.EXPECT().ListByVideoID(ctx, req.GetVideoId(), int(req.GetLimit()), int(req.GetOffset())).Return(comments, nil)
+				})
+
+				It("forwards the user to the video service", func() {
+					Expect(forwardedUserID).To(Equal("owner"))
+				})
+
+				It("returns comments with no error", func() {
+					Expect(resp).To(Equal(&pb.ListCommentResponse{
+						Comments: []*pb.CommentInfo{comments[0].ToProto()},
+					}))
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+
+			When("user is not the owner", func() {
+				BeforeEach(func() {
+					ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-user-id", "other"))
+				})
+
+				It("forwards the user to the video service", func() {
+					Expect(forwardedUserID).To(Equal("other"))
+				})
+
+				It("returns video not found error", func() {
+					Expect(resp).To(BeNil())
+					Expect(err).To(MatchError(errVideoNotFound))
+				})
 			})
 		})
 	})
@@ -163,6 +234,51 @@ var _ = Describe("Service", func() {
 				})
 			})
 		})
+		Context("video is private", func() {
+			var forwardedUserID string
+
+			BeforeEach(func() {
+				videoClient.EXPECT().GetVideo(gomock.Any(), &videopb.GetVideoRequest{
+					Id: req.GetVideoId(),
+				}).DoAndReturn(getPrivateVideo("owner", &forwardedUserID))
+			})
+
+			When("user is the owner", func() {
+				var id uuid.UUID
+
+				BeforeEach(func() {
+					ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-user-id", "owner"))
+					id = uuid.New()
+					commentDAO.EXPECT().Create(ctx, comment).Return(id, nil)
+				})
+
+				It("forwards the user to the video service", func() {
+					Expect(forwardedUserID).To(Equal("owner"))
+				})
+
+				It("returns no error", func() {
+					Expect(resp).To(Equal(&pb.CreateCommentResponse{
+						Id: id.String(),
+					}))
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+
+			When("user is not the owner", func() {
+				BeforeEach(func() {
+					ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-user-id", "other"))
+				})
+
+				It("forwards the user to the video service", func() {
+					Expect(forwardedUserID).To(Equal("other"))
+				})
+
+				It("returns video not found error", func() {
+					Expect(resp).To(BeNil())
+					Expect(err).To(MatchError(errVideoNotFound))
+				})
+			})
+		})
 	})
 
 	Describe("UpdateComment", func() {
@@ -316,3 +432,24 @@ var _ = Describe("Service", func() {
 		})
 	})
 })
+
+// getPrivateVideo returns the private video of the owner like the video service, which is not found for any other user,
+// and records the user forwarded by the outgoing metadata
+func getPrivateVideo(ownerID string, forwardedUserID *string) func(context.Context, *videopb.GetVideoRequest, ...grpc.CallOption) (*videopb.GetVideoResponse, error) {
+	return func(ctx context.Context, req *videopb.GetVideoRequest, opts ...grpc.CallOption) (*videopb.GetVideoResponse, error) {
+		*forwardedUserID = ""
+		if md, ok := metadata.FromOutgoingContext(ctx); ok {
+			if values := md.Get("x-user-id"); len(values) > 0 {
+				*forwardedUserID = values[0]
+			}
+		}
+
+		if *forwardedUserID != ownerID {
+			return nil, errVideoNotFound
+		}
+
+		return &videopb.GetVideoResponse{
+			Video: &videopb.VideoInfo{Id: req.GetId(), Visibility: "private", OwnerId: ownerID},
+		}, nil
+	}
+}
@@ -3,9 +3,11 @@ package dao
 import (
 	"context"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/logkit"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/migrationkit"
 	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/mongokit"
 	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/rediskit"
 	. "github.com/onsi/ginkgo/v2"
@@ -46,6 +48,14 @@ var _ = BeforeSuite(func() {
 
 	mongoClient = mongokit.NewMongoClient(ctx, mongoConf)
 	redisClient = rediskit.NewRedisClient(ctx, redisConf)
+
+	// the indexes are created by the migrations as in the deployment
+	migration := migrationkit.NewMigration(ctx, &migrationkit.MigrationConfig{
+		Source: "file://../migration",
+		URL:    strings.TrimSuffix(mongoConf.URL, "/") + "/" + mongoConf.Database,
+	})
+	Expect(migration.Up()).NotTo(HaveOccurred())
+	Expect(migration.Close()).NotTo(HaveOccurred())
 })
 
 var _ = AfterSuite(func() {
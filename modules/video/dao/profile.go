@@ -0,0 +1,83 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+const (
+	ProfileCodecH264 = "h264"
+	ProfileCodecH265 = "h265"
+	ProfileCodecVP9  = "vp9"
+
+	ProfileContainerMP4  = "mp4"
+	ProfileContainerWebM = "webm"
+)
+
+// Profile describes a variant transcoded from the source video, the ID of the
+// profile is also the name of the variant in `Video.Variants`
+type Profile struct {
+	ID string `bson:"_id"`
+	// Height is the height of the variant, the width is scaled to keep the aspect ratio
+	Height uint32 `bson:"height"`
+	// Bitrate is the video bitrate in kbps, the encoder decides the bitrate if it is zero
+	Bitrate   uint32 `bson:"bitrate,omitempty"`
+	Codec     string `bson:"codec"`
+	Container string `bson:"container"`
+	// SkipIfSourceSmaller skips the profile if the source is shorter than the height, so videos are never upscaled
+	SkipIfSourceSmaller bool `bson:"skip_if_source_smaller,omitempty"`
+}
+
+// Validate checks that the profile can be transcoded
+func (p *Profile) Validate() error {
+	if p.ID == "" {
+		return errors.New("profile ID is required")
+	}
+
+	if p.Height == 0 {
+		return fmt.Errorf("profile %s: height is required", p.ID)
+	}
+
+	switch p.Codec {
+	case ProfileCodecH264, ProfileCodecH265, ProfileCodecVP9:
+	default:
+		return fmt.Errorf("profile %s: unknown codec %q", p.ID, p.Codec)
+	}
+
+	switch p.Container {
+	case ProfileContainerMP4:
+	case ProfileContainerWebM:
+		if p.Codec != ProfileCodecVP9 {
+			return fmt.Errorf("profile %s: codec %s cannot be muxed into webm", p.ID, p.Codec)
+		}
+	default:
+		return fmt.Errorf("profile %s: unknown container %q", p.ID, p.Container)
+	}
+
+	return nil
+}
+
+type ProfileDAO interface {
+	// List returns the profiles ordered by the height from the highest,
+	// ErrInvalidProfile is returned if any of the profiles cannot be transcoded
+	List(ctx context.Context) ([]*Profile, error)
+	Get(ctx context.Context, id string) (*Profile, error)
+}
+
+var (
+	ErrProfileNotFound = errors.New("profile not found")
+	ErrInvalidProfile  = errors.New("invalid profile")
+)
+
+// NewFakeProfile returns a fake profile instance that is useful for testing
+func NewFakeProfile(id string, height uint32) *Profile {
+	return &Profile{
+		ID:                  id,
+		Height:              height,
+		Bitrate:             height * 4,
+		Codec:               ProfileCodecH264,
+		Container:           ProfileContainerMP4,
+		SkipIfSourceSmaller: true,
+	}
+}
@@ -0,0 +1,65 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoProfileDAO struct {
+	collection *mongo.Collection
+}
+
+var _ ProfileDAO = (*mongoProfileDAO)(nil)
+
+func NewMongoProfileDAO(collection *mongo.Collection) *mongoProfileDAO {
+	return &mongoProfileDAO{
+		collection: collection,
+	}
+}
+
+func (dao *mongoProfileDAO) List(ctx context.Context) ([]*Profile, error) {
+	o := options.Find().SetSort(bson.D{{Key: "height", Value: -1}, {Key: "_id", Value: 1}})
+
+	cursor, err := dao.collection.Find(ctx, bson.M{}, o)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	profiles := make([]*Profile, 0)
+	for cursor.Next(ctx) {
+		var profile Profile
+		if err := cursor.Decode(&profile); err != nil {
+			return nil, err
+		}
+
+		if err := profile.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidProfile, err)
+		}
+
+		profiles = append(profiles, &profile)
+	}
+
+	return profiles, nil
+}
+
+func (dao *mongoProfileDAO) Get(ctx context.Context, id string) (*Profile, error) {
+	var profile Profile
+	if err := dao.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&profile); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrProfileNotFound
+		}
+		return nil, err
+	}
+
+	if err := profile.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidProfile, err)
+	}
+
+	return &profile, nil
+}
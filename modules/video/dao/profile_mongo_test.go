@@ -0,0 +1,122 @@
+package dao
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var _ = Describe("mongoProfileDAO", func() {
+	var profileDAO *mongoProfileDAO
+	var ctx context.Context
+
+	BeforeEach(func() {
+		profileDAO = NewMongoProfileDAO(mongoClient.Database().Collection("profiles"))
+		ctx = context.Background()
+	})
+
+	Describe("List", func() {
+		var (
+			profiles []*Profile
+
+			resp []*Profile
+			err  error
+		)
+
+		BeforeEach(func() {
+			profiles = []*Profile{
+				NewFakeProfile("480p", 480),
+				NewFakeProfile("1080p", 1080),
+				NewFakeProfile("720p", 720),
+			}
+
+			for _, profile := range profiles {
+				insertProfile(ctx, profileDAO, profile)
+			}
+		})
+
+		AfterEach(func() {
+			for _, profile := range profiles {
+				deleteProfile(ctx, profileDAO, profile.ID)
+			}
+		})
+
+		JustBeforeEach(func() {
+			resp, err = profileDAO.List(ctx)
+		})
+
+		It("returns the profiles from the highest with no error", func() {
+			Expect(resp).To(Equal([]*Profile{profiles[1], profiles[2], profiles[0]}))
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("Get", func() {
+		var (
+			profile *Profile
+			id      string
+
+			resp *Profile
+			err  error
+		)
+
+		BeforeEach(func() {
+			profile = NewFakeProfile("720p", 720)
+
+			insertProfile(ctx, profileDAO, profile)
+		})
+
+		AfterEach(func() {
+			deleteProfile(ctx, profileDAO, profile.ID)
+		})
+
+		JustBeforeEach(func() {
+			resp, err = profileDAO.Get(ctx, id)
+		})
+
+		When("profile not found", func() {
+			BeforeEach(func() { id = "not-found" })
+
+			It("returns profile not found error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrProfileNotFound))
+			})
+		})
+
+		When("profile is invalid", func() {
+			BeforeEach(func() {
+				id = profile.ID
+				Expect(profileDAO.collection.UpdateByID(ctx, id, bson.M{"$set": bson.M{"codec": "mpeg2"}})).NotTo(BeNil())
+			})
+
+			It("returns invalid profile error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrInvalidProfile))
+			})
+		})
+
+		When("success", func() {
+			BeforeEach(func() { id = profile.ID })
+
+			It("returns the profile with no error", func() {
+				Expect(resp).To(Equal(profile))
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+	})
+})
+
+// useful methods for testing
+
+func insertProfile(ctx context.Context, profileDAO *mongoProfileDAO, profile *Profile) {
+	Expect(profileDAO.collection.InsertOne(ctx, profile)).
+		To(Equal(&mongo.InsertOneResult{InsertedID: profile.ID}))
+}
+
+func deleteProfile(ctx context.Context, profileDAO *mongoProfileDAO, id string) {
+	Expect(profileDAO.collection.DeleteOne(ctx, bson.M{"_id": id})).
+		To(Equal(&mongo.DeleteResult{DeletedCount: 1}))
+}
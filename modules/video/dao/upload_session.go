@@ -0,0 +1,106 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/pb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type UploadSessionStatus string
+
+const (
+	UploadSessionStatusActive    UploadSessionStatus = "active"
+	UploadSessionStatusCompleted UploadSessionStatus = "completed"
+	UploadSessionStatusAborted   UploadSessionStatus = "aborted"
+)
+
+func (s UploadSessionStatus) String() string {
+	return string(s)
+}
+
+// UploadPart is a part that has been uploaded to the storage multipart upload
+type UploadPart struct {
+	Number int    `bson:"number"`
+	ETag   string `bson:"etag"`
+	Size   uint64 `bson:"size"`
+}
+
+// UploadSession tracks a resumable upload, the video is uploaded part by part
+// into a storage multipart upload identified by `UploadID`, or uploaded by the client
+// directly to the storage with a presigned URL if `Presigned` is set
+type UploadSession struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty"`
+	VideoID    primitive.ObjectID `bson:"video_id,omitempty"`
+	Filename   string             `bson:"filename,omitempty"`
+	ObjectName string             `bson:"object_name,omitempty"`
+	UploadID   string             `bson:"upload_id,omitempty"`
+	Size       uint64             `bson:"size,omitempty"`
+	Offset     uint64             `bson:"offset"`
+	Parts      []*UploadPart      `bson:"parts"`
+	// LastPartNumber is the last part number reserved by an upload of a part
+	LastPartNumber int                 `bson:"last_part_number"`
+	Status         UploadSessionStatus `bson:"status,omitempty"`
+	Presigned      bool                `bson:"presigned,omitempty"`
+	// OwnerID, the metadata and PublishAt are applied to the video created once the session is completed
+	OwnerID       string        `bson:"owner_id,omitempty"`
+	VideoMetadata VideoMetadata `bson:"metadata"`
+	PublishAt     time.Time     `bson:"publish_at,omitempty"`
+	CreatedAt     time.Time     `bson:"created_at,omitempty"`
+	UpdatedAt     time.Time     `bson:"updated_at,omitempty"`
+}
+
+func (s *UploadSession) ToProto() *pb.UploadSessionInfo {
+	return &pb.UploadSessionInfo{
+		Id:        s.ID.Hex(),
+		Filename:  s.Filename,
+		Size:      s.Size,
+		Offset:    s.Offset,
+		Status:    s.Status.String(),
+		Presigned: s.Presigned,
+		CreatedAt: timestamppb.New(s.CreatedAt),
+		UpdatedAt: timestamppb.New(s.UpdatedAt),
+	}
+}
+
+type UploadSessionDAO interface {
+	Get(ctx context.Context, id primitive.ObjectID) (*UploadSession, error)
+	Create(ctx context.Context, session *UploadSession) error
+	// ReservePartNumber reserves a part number of an active session at the offset, the part numbers are increasing
+	// and never reused, so concurrent uploads of a part do not overwrite each other in the storage
+	ReservePartNumber(ctx context.Context, id primitive.ObjectID, offset uint64) (int, error)
+	// AppendPart appends the part to an active session only if the session is still at the offset
+	AppendPart(ctx context.Context, id primitive.ObjectID, offset uint64, part *UploadPart) error
+	// UpdateStatus changes the status of the session only if the session is still in the `from` status
+	UpdateStatus(ctx context.Context, id primitive.ObjectID, from, to UploadSessionStatus) error
+}
+
+var (
+	ErrUploadSessionNotFound = errors.New("upload session not found")
+	ErrUploadSessionConflict = errors.New("upload session conflict")
+)
+
+// NewFakeUploadSession returns a fake active upload session instance
+// with random id that is useful for testing
+func NewFakeUploadSession() *UploadSession {
+	videoID := primitive.NewObjectID()
+
+	return &UploadSession{
+		ID:         primitive.NewObjectID(),
+		VideoID:    videoID,
+		Filename:   "video.mp4",
+		ObjectName: videoID.Hex() + "-video.mp4",
+		UploadID:   "fake-upload-id",
+		Size:       12 << 20,
+		Offset:     0,
+		Parts:      []*UploadPart{},
+		Status:     UploadSessionStatusActive,
+		VideoMetadata: VideoMetadata{
+			Title:      "video",
+			Visibility: VideoVisibilityPublic,
+		},
+	}
+}
@@ -0,0 +1,121 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoUploadSessionDAO struct {
+	collection *mongo.Collection
+}
+
+var _ UploadSessionDAO = (*mongoUploadSessionDAO)(nil)
+
+func NewMongoUploadSessionDAO(collection *mongo.Collection) *mongoUploadSessionDAO {
+	return &mongoUploadSessionDAO{
+		collection: collection,
+	}
+}
+
+func (dao *mongoUploadSessionDAO) Get(ctx context.Context, id primitive.ObjectID) (*UploadSession, error) {
+	var session UploadSession
+	if err := dao.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&session); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrUploadSessionNotFound
+		}
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+func (dao *mongoUploadSessionDAO) Create(ctx context.Context, session *UploadSession) error {
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	session.CreatedAt = now
+	session.UpdatedAt = now
+
+	if session.Parts == nil {
+		session.Parts = []*UploadPart{}
+	}
+
+	result, err := dao.collection.InsertOne(ctx, session)
+	if err != nil {
+		return err
+	}
+
+	session.ID = result.InsertedID.(primitive.ObjectID)
+
+	return nil
+}
+
+func (dao *mongoUploadSessionDAO) ReservePartNumber(ctx context.Context, id primitive.ObjectID, offset uint64) (int, error) {
+	filter := bson.M{
+		"_id":    id,
+		"status": UploadSessionStatusActive,
+		"offset": offset,
+	}
+	update := bson.M{
+		"$inc": bson.M{"last_part_number": 1},
+	}
+	opts := options.FindOneAndUpdate().
+		SetReturnDocument(options.After).
+		SetProjection(bson.M{"last_part_number": 1})
+
+	var session UploadSession
+	if err := dao.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&session); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return 0, ErrUploadSessionConflict
+		}
+		return 0, err
+	}
+
+	return session.LastPartNumber, nil
+}
+
+func (dao *mongoUploadSessionDAO) AppendPart(ctx context.Context, id primitive.ObjectID, offset uint64, part *UploadPart) error {
+	filter := bson.M{
+		"_id":    id,
+		"status": UploadSessionStatusActive,
+		"offset": offset,
+	}
+	update := bson.M{
+		"$push": bson.M{"parts": part},
+		"$inc":  bson.M{"offset": part.Size},
+		"$set":  bson.M{"updated_at": time.Now().UTC().Truncate(time.Millisecond)},
+	}
+
+	if result, err := dao.collection.UpdateOne(ctx, filter, update); err != nil {
+		return err
+	} else if result.MatchedCount == 0 {
+		return ErrUploadSessionConflict
+	}
+
+	return nil
+}
+
+func (dao *mongoUploadSessionDAO) UpdateStatus(ctx context.Context, id primitive.ObjectID, from, to UploadSessionStatus) error {
+	filter := bson.M{
+		"_id":    id,
+		"status": from,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"status":     to,
+			"updated_at": time.Now().UTC().Truncate(time.Millisecond),
+		},
+	}
+
+	if result, err := dao.collection.UpdateOne(ctx, filter, update); err != nil {
+		return err
+	} else if result.MatchedCount == 0 {
+		return ErrUploadSessionConflict
+	}
+
+	return nil
+}
@@ -0,0 +1,259 @@
+package dao
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var _ = Describe("mongoUploadSessionDAO", func() {
+	var uploadSessionDAO *mongoUploadSessionDAO
+	var ctx context.Context
+
+	BeforeEach(func() {
+		uploadSessionDAO = NewMongoUploadSessionDAO(mongoClient.Database().Collection("upload_sessions"))
+		ctx = context.Background()
+	})
+
+	Describe("Get", func() {
+		var (
+			session *UploadSession
+			id      primitive.ObjectID
+
+			resp *UploadSession
+			err  error
+		)
+
+		BeforeEach(func() {
+			session = NewFakeUploadSession()
+
+			insertUploadSession(ctx, uploadSessionDAO, session)
+		})
+
+		AfterEach(func() {
+			deleteUploadSession(ctx, uploadSessionDAO, session.ID)
+		})
+
+		JustBeforeEach(func() {
+			resp, err = uploadSessionDAO.Get(ctx, id)
+		})
+
+		When("session not found", func() {
+			BeforeEach(func() { id = primitive.NewObjectID() })
+
+			It("returns upload session not found error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrUploadSessionNotFound))
+			})
+		})
+
+		When("success", func() {
+			BeforeEach(func() { id = session.ID })
+
+			It("returns the session with no error", func() {
+				Expect(resp).To(Equal(session))
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("Create", func() {
+		var (
+			session *UploadSession
+
+			err error
+		)
+
+		BeforeEach(func() {
+			session = NewFakeUploadSession()
+			session.ID = primitive.NilObjectID
+		})
+
+		AfterEach(func() {
+			deleteUploadSession(ctx, uploadSessionDAO, session.ID)
+		})
+
+		JustBeforeEach(func() {
+			err = uploadSessionDAO.Create(ctx, session)
+		})
+
+		When("success", func() {
+			It("returns the new session ID with no error", func() {
+				Expect(session.ID).NotTo(Equal(primitive.NilObjectID))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("inserts the document", func() {
+				var getSession UploadSession
+
+				Expect(
+					uploadSessionDAO.collection.FindOne(ctx, bson.M{"_id": session.ID}).Decode(&getSession),
+				).NotTo(HaveOccurred())
+
+				Expect(&getSession).To(Equal(session))
+			})
+		})
+	})
+
+	Describe("ReservePartNumber", func() {
+		var (
+			session *UploadSession
+			offset  uint64
+
+			number int
+			err    error
+		)
+
+		BeforeEach(func() {
+			session = NewFakeUploadSession()
+			session.Offset = 5 << 20
+			session.LastPartNumber = 1
+			offset = session.Offset
+
+			insertUploadSession(ctx, uploadSessionDAO, session)
+		})
+
+		AfterEach(func() {
+			deleteUploadSession(ctx, uploadSessionDAO, session.ID)
+		})
+
+		JustBeforeEach(func() {
+			number, err = uploadSessionDAO.ReservePartNumber(ctx, session.ID, offset)
+		})
+
+		When("offset has moved", func() {
+			BeforeEach(func() { offset = 0 })
+
+			It("returns upload session conflict error", func() {
+				Expect(number).To(BeZero())
+				Expect(err).To(MatchError(ErrUploadSessionConflict))
+			})
+		})
+
+		When("success", func() {
+			It("returns the next part number", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(number).To(Equal(2))
+			})
+
+			It("never returns the part number again", func() {
+				next, err := uploadSessionDAO.ReservePartNumber(ctx, session.ID, offset)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(next).To(Equal(3))
+			})
+		})
+	})
+
+	Describe("AppendPart", func() {
+		var (
+			session *UploadSession
+			offset  uint64
+			part    *UploadPart
+
+			err error
+		)
+
+		BeforeEach(func() {
+			session = NewFakeUploadSession()
+			offset = 0
+			part = &UploadPart{Number: 1, ETag: "etag", Size: 5 << 20}
+
+			insertUploadSession(ctx, uploadSessionDAO, session)
+		})
+
+		AfterEach(func() {
+			deleteUploadSession(ctx, uploadSessionDAO, session.ID)
+		})
+
+		JustBeforeEach(func() {
+			err = uploadSessionDAO.AppendPart(ctx, session.ID, offset, part)
+		})
+
+		When("offset has moved", func() {
+			BeforeEach(func() { offset = 1024 })
+
+			It("returns upload session conflict error", func() {
+				Expect(err).To(MatchError(ErrUploadSessionConflict))
+			})
+		})
+
+		When("success", func() {
+			It("returns no error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("appends the part and moves the offset", func() {
+				var getSession UploadSession
+
+				Expect(
+					uploadSessionDAO.collection.FindOne(ctx, bson.M{"_id": session.ID}).Decode(&getSession),
+				).NotTo(HaveOccurred())
+
+				Expect(getSession.Offset).To(Equal(part.Size))
+				Expect(getSession.Parts).To(Equal([]*UploadPart{part}))
+			})
+		})
+	})
+
+	Describe("UpdateStatus", func() {
+		var (
+			session *UploadSession
+			from    UploadSessionStatus
+
+			err error
+		)
+
+		BeforeEach(func() {
+			session = NewFakeUploadSession()
+			from = UploadSessionStatusActive
+
+			insertUploadSession(ctx, uploadSessionDAO, session)
+		})
+
+		AfterEach(func() {
+			deleteUploadSession(ctx, uploadSessionDAO, session.ID)
+		})
+
+		JustBeforeEach(func() {
+			err = uploadSessionDAO.UpdateStatus(ctx, session.ID, from, UploadSessionStatusCompleted)
+		})
+
+		When("status has changed", func() {
+			BeforeEach(func() { from = UploadSessionStatusAborted })
+
+			It("returns upload session conflict error", func() {
+				Expect(err).To(MatchError(ErrUploadSessionConflict))
+			})
+		})
+
+		When("success", func() {
+			It("returns no error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("updates the status", func() {
+				var getSession UploadSession
+
+				Expect(
+					uploadSessionDAO.collection.FindOne(ctx, bson.M{"_id": session.ID}).Decode(&getSession),
+				).NotTo(HaveOccurred())
+
+				Expect(getSession.Status).To(Equal(UploadSessionStatusCompleted))
+			})
+		})
+	})
+})
+
+func insertUploadSession(ctx context.Context, uploadSessionDAO *mongoUploadSessionDAO, session *UploadSession) {
+	Expect(uploadSessionDAO.collection.InsertOne(ctx, session)).
+		To(Equal(&mongo.InsertOneResult{InsertedID: session.ID}))
+}
+
+func deleteUploadSession(ctx context.Context, uploadSessionDAO *mongoUploadSessionDAO, id primitive.ObjectID) {
+	Expect(uploadSessionDAO.collection.DeleteOne(ctx, bson.M{"_id": id})).
+		To(Equal(&mongo.DeleteResult{DeletedCount: 1}))
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/pb"
@@ -24,75 +26,415 @@ func (s VideoStatus) String() string {
 	return string(s)
 }
 
+// VideoPublishStatus is whether the video has gone live, which is apart from the status of the processing
+// since a scheduled video is encoded before it is published
+type VideoPublishStatus string
+
+const (
+	VideoPublishStatusScheduled VideoPublishStatus = "scheduled"
+	VideoPublishStatusPublished VideoPublishStatus = "published"
+)
+
+func (s VideoPublishStatus) String() string {
+	return string(s)
+}
+
+// VideoVisibility is who can view the video, a public video is listed to everyone, an unlisted video
+// is reachable by the ID but not listed, and a private video is reachable by its owner only
+type VideoVisibility string
+
+const (
+	VideoVisibilityPublic   VideoVisibility = "public"
+	VideoVisibilityUnlisted VideoVisibility = "unlisted"
+	VideoVisibilityPrivate  VideoVisibility = "private"
+)
+
+func (v VideoVisibility) String() string {
+	return string(v)
+}
+
+// Playlist is the HLS media playlist of a variant, which is referenced by the master playlist
+type Playlist struct {
+	ObjectName string `bson:"object_name"`
+	Width      uint32 `bson:"width,omitempty"`
+	Height     uint32 `bson:"height,omitempty"`
+	// Bandwidth is the bitrate of the variant in bits per second
+	Bandwidth uint64 `bson:"bandwidth,omitempty"`
+}
+
+// Thumbnail is the poster frame and the preview sprite sheet of a video,
+// the preview is a WebVTT index mapping the time ranges to the tiles of the sprite sheet.
+type Thumbnail struct {
+	PosterObjectName  string `bson:"poster_object_name"`
+	SpriteObjectName  string `bson:"sprite_object_name"`
+	PreviewObjectName string `bson:"preview_object_name"`
+}
+
+// VideoMetadata is the human-facing metadata of a video, which is editable after the upload
+type VideoMetadata struct {
+	Title       string   `bson:"title,omitempty"`
+	Description string   `bson:"description,omitempty"`
+	Tags        []string `bson:"tags,omitempty"`
+	Language    string   `bson:"language,omitempty"`
+	// Visibility defaults to public
+	Visibility VideoVisibility `bson:"visibility,omitempty"`
+}
+
+// The fields of the video metadata to update
+const (
+	VideoFieldTitle       = "title"
+	VideoFieldDescription = "description"
+	VideoFieldTags        = "tags"
+	VideoFieldLanguage    = "language"
+	VideoFieldVisibility  = "visibility"
+)
+
+// Video keeps the object names in the storage, the URLs are derived from them when the video is read
 type Video struct {
-	ID        primitive.ObjectID `bson:"_id,omitempty"`
-	Width     uint32             `bson:"width,omitempty"`
-	Height    uint32             `bson:"height,omitempty"`
-	Size      uint64             `bson:"size,omitempty"`
-	Duration  float64            `bson:"duration,omitempty"`
-	URL       string             `bson:"url,omitempty"`
-	Status    VideoStatus        `bson:"status,omitempty"`
-	Variants  map[string]string  `bson:"variants,omitempty"`
-	CreatedAt time.Time          `bson:"created_at,omitempty"`
-	UpdatedAt time.Time          `bson:"updated_at,omitempty"`
+	ID         primitive.ObjectID   `bson:"_id,omitempty"`
+	Width      uint32               `bson:"width,omitempty"`
+	Height     uint32               `bson:"height,omitempty"`
+	Size       uint64               `bson:"size,omitempty"`
+	Duration   float64              `bson:"duration,omitempty"`
+	Codec      string               `bson:"codec,omitempty"`
+	Bitrate    uint64               `bson:"bitrate,omitempty"`
+	ObjectName string               `bson:"object_name,omitempty"`
+	Status     VideoStatus          `bson:"status,omitempty"`
+	Variants   map[string]string    `bson:"variants,omitempty"`
+	Playlists  map[string]*Playlist `bson:"playlists,omitempty"`
+	CreatedAt  time.Time            `bson:"created_at,omitempty"`
+	UpdatedAt  time.Time            `bson:"updated_at,omitempty"`
+
+	ExpectedVariants   []string   `bson:"expected_variants,omitempty"`
+	ManifestObjectName string     `bson:"manifest_object_name,omitempty"`
+	Thumbnail          *Thumbnail `bson:"thumbnail,omitempty"`
+	DeletedAt          time.Time  `bson:"deleted_at,omitempty"`
+	PurgingAt          time.Time  `bson:"purging_at,omitempty"`
+	// OwnerID is the user who uploaded the video, which is empty if the video is uploaded anonymously
+	OwnerID string `bson:"owner_id,omitempty"`
+	// PublishStatus defaults to published, a scheduled video is hidden until PublishAt
+	PublishStatus VideoPublishStatus `bson:"publish_status,omitempty"`
+	// PublishAt is the time the video goes live, which defaults to the creation time
+	PublishAt time.Time `bson:"publish_at,omitempty"`
+	// MetadataUpdatedAt is the time the metadata is last updated, which is not changed by the stream worker
+	MetadataUpdatedAt time.Time `bson:"metadata_updated_at,omitempty"`
+
+	VideoMetadata `bson:",inline"`
+}
+
+// sortValue returns the value of the sort field, which is nil if the field is not stored
+func (v *Video) sortValue(sortBy VideoSortField) interface{} {
+	switch sortBy {
+	case VideoSortUpdatedAt:
+		return v.UpdatedAt
+	case VideoSortSize:
+		if v.Size == 0 {
+			return nil
+		}
+		return v.Size
+	case VideoSortDuration:
+		if v.Duration == 0 {
+			return nil
+		}
+		return v.Duration
+	case VideoSortDeletedAt:
+		return v.DeletedAt
+	case VideoSortPublishAt:
+		return v.PublishAt
+	default:
+		return v.CreatedAt
+	}
 }
 
+// ToProto converts the video to the protobuf message without the URLs,
+// which are filled by the caller from the object names.
 func (v *Video) ToProto() *pb.VideoInfo {
+	var deletedAt *timestamppb.Timestamp
+	if !v.DeletedAt.IsZero() {
+		deletedAt = timestamppb.New(v.DeletedAt)
+	}
+
+	var publishAt *timestamppb.Timestamp
+	if !v.PublishAt.IsZero() {
+		publishAt = timestamppb.New(v.PublishAt)
+	}
+
 	return &pb.VideoInfo{
 		Id:        v.ID.Hex(),
 		Width:     v.Width,
 		Height:    v.Height,
 		Size:      v.Size,
 		Duration:  v.Duration,
-		Url:       v.URL,
+		Codec:     v.Codec,
+		Bitrate:   v.Bitrate,
 		Status:    v.Status.String(),
-		Variants:  v.Variants,
 		CreatedAt: timestamppb.New(v.CreatedAt),
 		UpdatedAt: timestamppb.New(v.UpdatedAt),
+		DeletedAt: deletedAt,
+
+		PublishStatus: v.PublishStatus.String(),
+		PublishAt:     publishAt,
+
+		Title:       v.Title,
+		Description: v.Description,
+		Tags:        v.Tags,
+		Language:    v.Language,
+		Visibility:  v.Visibility.String(),
+		OwnerId:     v.OwnerID,
+	}
+}
+
+// Viewable tells whether the user can view the video, an anonymous user has an empty ID.
+// The owner views the video at any time, the other users view it once it is published.
+func (v *Video) Viewable(userID string) bool {
+	if userID != "" && userID == v.OwnerID {
+		return true
+	}
+
+	if v.Visibility == VideoVisibilityPrivate {
+		return false
 	}
+
+	return v.Published(time.Now())
+}
+
+// Published tells whether the video is live at the time, a scheduled video is live once its publish time
+// has come even if the scheduler has not published it yet
+func (v *Video) Published(now time.Time) bool {
+	return v.PublishStatus != VideoPublishStatusScheduled || !now.Before(v.PublishAt)
+}
+
+// VideoSortField is the field to sort the videos by, the ID breaks the ties
+type VideoSortField string
+
+const (
+	VideoSortCreatedAt VideoSortField = "created_at"
+	VideoSortUpdatedAt VideoSortField = "updated_at"
+	VideoSortSize      VideoSortField = "size"
+	VideoSortDuration  VideoSortField = "duration"
+	VideoSortDeletedAt VideoSortField = "deleted_at"
+	VideoSortPublishAt VideoSortField = "publish_at"
+)
+
+// ListVideoOptions filters and sorts the videos, the pages are continued by the page token
+// which is only valid for the same sorting and filters.
+type ListVideoOptions struct {
+	// Limit is the maximum number of videos in a page, all the videos are returned if it is zero
+	Limit int64
+	// Skip is kept for the clients paging by offset, which scans the skipped videos,
+	// it is ignored with a page token which continues after the skipped videos already
+	Skip int64
+	// SortBy defaults to the creation time
+	SortBy    VideoSortField
+	Ascending bool
+	// Statuses lists the videos in any of the statuses
+	Statuses []VideoStatus
+	// Tags lists the videos having all the tags
+	Tags []string
+	// Deleted lists the videos in the trash instead
+	Deleted bool
+	// DeletedBefore lists the videos in the trash deleted at or before the time, it is ignored if it is zero
+	DeletedBefore time.Time
+	// OwnerID lists the videos of the owner only, it is ignored if it is empty
+	OwnerID string
+	// Scheduled lists the scheduled videos out of the trash instead
+	Scheduled bool
+	// PublishBefore lists the scheduled videos to publish at or before the time, it is ignored if it is zero
+	PublishBefore time.Time
+	// PageToken is the next page token of the previous page
+	PageToken string
+}
+
+// VideoPage is a page of the listed videos, NextPageToken is empty on the last page
+type VideoPage struct {
+	Videos        []*Video
+	NextPageToken string
+}
+
+// SearchVideoOptions searches the videos by the words in the title, the description and the tags,
+// the pages are continued by the page token which is only valid for the same query and filters.
+type SearchVideoOptions struct {
+	// Query is the words to search, see the text search of MongoDB for the phrases and the negations
+	Query string
+	// Limit is the maximum number of videos in a page, all the matched videos are returned if it is zero
+	Limit int64
+	// Statuses searches the videos in any of the statuses
+	Statuses []VideoStatus
+	// PageToken is the next page token of the previous page
+	PageToken string
+}
+
+// VideoSearchResult is a matched video and its relevance score, a higher score is more relevant
+type VideoSearchResult struct {
+	Video *Video
+	Score float64
+}
+
+// VideoSearchPage is a page of the matched videos in the order of relevance, NextPageToken is empty on the last page
+type VideoSearchPage struct {
+	Results       []*VideoSearchResult
+	NextPageToken string
 }
 
 type VideoDAO interface {
+	// Get returns the video unless it is in the trash
 	Get(ctx context.Context, id primitive.ObjectID) (*Video, error)
-	List(ctx context.Context, limit, skip int64) ([]*Video, error)
+	// GetIncludingDeleted returns the video in or out of the trash unless it is being purged
+	GetIncludingDeleted(ctx context.Context, id primitive.ObjectID) (*Video, error)
+	// List returns the published public videos out of the trash, all the videos in the trash if opts.Deleted is set,
+	// or all the scheduled videos if opts.Scheduled is set
+	List(ctx context.Context, opts *ListVideoOptions) (*VideoPage, error)
+	// Search returns the matched published public videos out of the trash
+	Search(ctx context.Context, opts *SearchVideoOptions) (*VideoSearchPage, error)
+	// Create inserts the video, the DAO owns the timestamps so CreatedAt and UpdatedAt are set to now,
+	// the visibility defaults to public and the video is published now unless it is scheduled
 	Create(ctx context.Context, video *Video) error
+	// Update sets the non-empty fields of the video and bumps UpdatedAt, CreatedAt is preserved from the document
 	Update(ctx context.Context, video *Video) error
-	UpdateVariant(ctx context.Context, id primitive.ObjectID, variant string, url string) error
+	// StartEncoding moves the video from `uploaded` to `encoding` and records the expected variants,
+	// a video that is already `encoding` is accepted again so a redelivered fan-out can complete
+	StartEncoding(ctx context.Context, id primitive.ObjectID, variants []string) error
+	// UpdateVariant sets the variant and its playlist of an `encoding` video, and moves the video to `success`
+	// in the same update once all the expected variants are set. A `success` video is accepted again so a
+	// redelivered variant can complete, and the updated video is returned to regenerate the master playlist.
+	UpdateVariant(ctx context.Context, id primitive.ObjectID, variant string, objectName string, playlist *Playlist) (*Video, error)
+	// UpdateManifest sets the master playlist of the video
+	UpdateManifest(ctx context.Context, id primitive.ObjectID, objectName string) error
+	// UpdateThumbnail sets the thumbnail and the preview of the video
+	UpdateThumbnail(ctx context.Context, id primitive.ObjectID, thumbnail *Thumbnail) error
+	// UpdateMetadata sets the fields of the metadata only if the metadata has not been updated since updatedAt,
+	// ErrVideoUpdateConflict is returned otherwise so concurrent editors cannot overwrite each other
+	UpdateMetadata(ctx context.Context, id primitive.ObjectID, metadata *VideoMetadata, fields []string, updatedAt time.Time) (*Video, error)
+	// UpdateStatus changes the status of the video only if the video is still in the `from` status
+	UpdateStatus(ctx context.Context, id primitive.ObjectID, from, to VideoStatus) error
+	// SoftDelete moves the video to the trash by setting DeletedAt, a video already in the trash is not found
+	SoftDelete(ctx context.Context, id primitive.ObjectID) error
+	// Restore moves the video of the owner out of the trash, a video not in the trash, being purged
+	// or of another owner is not found
+	Restore(ctx context.Context, id primitive.ObjectID, ownerID string) (*Video, error)
+	// StartPurge marks the video as purging only if it has been in the trash since deletedBefore, so a video
+	// restored after it is listed for the purge is kept. A purging video is accepted again so an interrupted
+	// purge can be retried.
+	StartPurge(ctx context.Context, id primitive.ObjectID, deletedBefore time.Time) error
+	// Purge deletes the video only if it is purging and has been in the trash since deletedBefore
+	Purge(ctx context.Context, id primitive.ObjectID, deletedBefore time.Time) error
+	// Publish publishes the video only if it is scheduled to publish at or before publishBefore,
+	// so a video rescheduled or deleted after it is listed for publishing is not found
+	Publish(ctx context.Context, id primitive.ObjectID, publishBefore time.Time) (*Video, error)
 	Delete(ctx context.Context, id primitive.ObjectID) error
 }
 
 var (
-	ErrVideoNotFound = errors.New("video not found")
+	ErrVideoNotFound       = errors.New("video not found")
+	ErrVideoStatusConflict = errors.New("video status conflict")
+	ErrVideoUpdateConflict = errors.New("video has been updated")
+	ErrInvalidPageToken    = errors.New("invalid page token")
 )
 
 func getVideoKey(id primitive.ObjectID) string {
 	return "getVideo:" + id.Hex()
 }
 
-func listVideoKey(limit, skip int64) string {
-	return fmt.Sprintf("listVideo:%d:%d", limit, skip)
+// listVideoKey is versioned by the generation of the lists, so a write invalidates all the cached pages at once
+func listVideoKey(generation int64, opts *ListVideoOptions) string {
+	return fmt.Sprintf("listVideo:%d:%s:%d:%d:%s", generation, opts.queryKey(), opts.Limit, opts.Skip, opts.PageToken)
+}
+
+func searchVideoKey(generation int64, opts *SearchVideoOptions) string {
+	return fmt.Sprintf("searchVideo:%d:%s:%d:%s", generation, opts.queryKey(), opts.Limit, opts.PageToken)
+}
+
+// sortBy returns the sort field, which defaults to the creation time
+func (o *ListVideoOptions) sortBy() VideoSortField {
+	if o.SortBy == "" {
+		return VideoSortCreatedAt
+	}
+
+	return o.SortBy
+}
+
+// queryKey identifies the sorting and the filters regardless of the order of the statuses and the tags,
+// a page token is only valid for the query key of the page it is issued with.
+func (o *ListVideoOptions) queryKey() string {
+	order := "desc"
+	if o.Ascending {
+		order = "asc"
+	}
+
+	statuses := make([]string, 0, len(o.Statuses))
+	for _, status := range o.Statuses {
+		statuses = append(statuses, status.String())
+	}
+	sort.Strings(statuses)
+
+	tags := append([]string(nil), o.Tags...)
+	sort.Strings(tags)
+
+	key := fmt.Sprintf("%s:%s:%s:%s", o.sortBy(), order, strings.Join(statuses, ","), strings.Join(tags, ","))
+	if o.Deleted {
+		key += fmt.Sprintf(":deleted:%d", o.DeletedBefore.UnixMilli())
+	}
+	if o.OwnerID != "" {
+		key += fmt.Sprintf(":owner:%q", o.OwnerID)
+	}
+	if o.Scheduled {
+		key += fmt.Sprintf(":scheduled:%d", o.PublishBefore.UnixMilli())
+	}
+
+	return key
+}
+
+// queryKey identifies the query and the filters, the query is quoted since it may contain any character
+func (o *SearchVideoOptions) queryKey() string {
+	statuses := make([]string, 0, len(o.Statuses))
+	for _, status := range o.Statuses {
+		statuses = append(statuses, status.String())
+	}
+	sort.Strings(statuses)
+
+	return fmt.Sprintf("%q:%s", o.Query, strings.Join(statuses, ","))
 }
 
 // NewFakeVideo returns a fake video instance with random
 // id that is useful for testing
 func NewFakeVideo() *Video {
 	id := primitive.NewObjectID()
-	baseURL := "https://storage.example.com/videos/" + id.Hex()
 
 	// Note that timestamp is hard to test equally,
 	// so ignore the `createdAt` and `updatedAt` field
 
 	return &Video{
-		ID:       id,
-		Width:    800,
-		Height:   600,
-		Size:     144000,
-		Duration: 10.234,
-		URL:      baseURL + ".mp4",
-		Status:   VideoStatusSuccess,
+		ID:         id,
+		Width:      800,
+		Height:     600,
+		Size:       144000,
+		Duration:   10.234,
+		Codec:      "avc1",
+		Bitrate:    112560,
+		ObjectName: id.Hex() + ".mp4",
+		Status:     VideoStatusSuccess,
+		// the publish time is left empty like the timestamps
+		PublishStatus: VideoPublishStatusPublished,
+		VideoMetadata: VideoMetadata{
+			Title:       "Big Buck Bunny",
+			Description: "A short computer-animated comedy film",
+			Tags:        []string{"animation", "comedy"},
+			Language:    "en",
+			Visibility:  VideoVisibilityPublic,
+		},
 		Variants: map[string]string{
-			"1080p": baseURL + "-1080p.mp4",
-			"720p":  baseURL + "-720p.mp4",
+			"1080p": id.Hex() + "-1080p.mp4",
+			"720p":  id.Hex() + "-720p.mp4",
+		},
+		Playlists: map[string]*Playlist{
+			"1080p": {ObjectName: id.Hex() + "-hls/1080p/index.m3u8", Width: 1920, Height: 1080, Bandwidth: 5000000},
+			"720p":  {ObjectName: id.Hex() + "-hls/720p/index.m3u8", Width: 1280, Height: 720, Bandwidth: 2800000},
+		},
+		ManifestObjectName: id.Hex() + "-hls/master.m3u8",
+		Thumbnail: &Thumbnail{
+			PosterObjectName:  id.Hex() + "-poster.jpg",
+			SpriteObjectName:  id.Hex() + "-sprite.jpg",
+			PreviewObjectName: id.Hex() + "-preview.vtt",
 		},
 	}
 }
@@ -2,9 +2,13 @@ package dao
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
+	"fmt"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -24,7 +28,8 @@ func NewMongoVideoDAO(collection *mongo.Collection) *mongoVideoDAO {
 
 func (dao *mongoVideoDAO) Get(ctx context.Context, id primitive.ObjectID) (*Video, error) {
 	var video Video
-	if err := dao.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&video); err != nil {
+	filter := bson.M{"_id": id, "deleted_at": bson.M{"$exists": false}}
+	if err := dao.collection.FindOne(ctx, filter).Decode(&video); err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
 			return nil, ErrVideoNotFound
 		}
@@ -34,10 +39,76 @@ func (dao *mongoVideoDAO) Get(ctx context.Context, id primitive.ObjectID) (*Vide
 	return &video, nil
 }
 
-func (dao *mongoVideoDAO) List(ctx context.Context, limit, skip int64) ([]*Video, error) {
-	o := options.Find().SetLimit(limit).SetSkip(skip)
+func (dao *mongoVideoDAO) GetIncludingDeleted(ctx context.Context, id primitive.ObjectID) (*Video, error) {
+	var video Video
+	filter := bson.M{"_id": id, "purging_at": bson.M{"$exists": false}}
+	if err := dao.collection.FindOne(ctx, filter).Decode(&video); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrVideoNotFound
+		}
+		return nil, err
+	}
+
+	return &video, nil
+}
+
+// List returns a page of the videos sorted by the field and the ID, the page token keeps the sort key
+// of the last video so the next page starts after it even if videos are inserted in between.
+// The sort fields are indexed with the ID by the video migration.
+func (dao *mongoVideoDAO) List(ctx context.Context, opts *ListVideoOptions) (*VideoPage, error) {
+	sortBy := opts.sortBy()
+	queryKey := opts.queryKey()
+
+	filter := bson.M{"deleted_at": bson.M{"$exists": opts.Deleted}}
+	switch {
+	case opts.Deleted:
+		if !opts.DeletedBefore.IsZero() {
+			filter["deleted_at"] = bson.M{"$lte": opts.DeletedBefore}
+		}
+	case opts.Scheduled:
+		filter["publish_status"] = VideoPublishStatusScheduled
+		if !opts.PublishBefore.IsZero() {
+			filter["publish_at"] = bson.M{"$lte": opts.PublishBefore}
+		}
+	default:
+		filter["visibility"] = VideoVisibilityPublic
+		filter["$nor"] = notPublishedFilter(time.Now().UTC())
+	}
+	if len(opts.Statuses) > 0 {
+		filter["status"] = bson.M{"$in": opts.Statuses}
+	}
+	if len(opts.Tags) > 0 {
+		filter["tags"] = bson.M{"$all": opts.Tags}
+	}
+	if opts.OwnerID != "" {
+		filter["owner_id"] = opts.OwnerID
+	}
 
-	cursor, err := dao.collection.Find(ctx, bson.M{}, o)
+	direction := -1
+	if opts.Ascending {
+		direction = 1
+	}
+
+	if opts.PageToken != "" {
+		cursor, err := decodeVideoCursor(opts.PageToken, queryKey, sortBy.bsonType(), sortBy.optional())
+		if err != nil {
+			return nil, err
+		}
+
+		filter["$or"] = cursor.afterFilter(string(sortBy), opts.Ascending, sortBy.optional())
+	}
+
+	o := options.Find().
+		SetSort(bson.D{{Key: string(sortBy), Value: direction}, {Key: "_id", Value: direction}})
+	if opts.PageToken == "" {
+		o.SetSkip(opts.Skip)
+	}
+	if opts.Limit > 0 {
+		// one more video tells whether there is a next page
+		o.SetLimit(opts.Limit + 1)
+	}
+
+	cursor, err := dao.collection.Find(ctx, filter, o)
 	if err != nil {
 		return nil, err
 	}
@@ -53,10 +124,123 @@ func (dao *mongoVideoDAO) List(ctx context.Context, limit, skip int64) ([]*Video
 		videos = append(videos, &video)
 	}
 
-	return videos, nil
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	page := &VideoPage{Videos: videos}
+
+	if opts.Limit > 0 && int64(len(videos)) > opts.Limit {
+		page.Videos = videos[:opts.Limit]
+
+		last := page.Videos[len(page.Videos)-1]
+		if page.NextPageToken, err = encodeVideoCursor(queryKey, last.sortValue(sortBy), last.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	return page, nil
+}
+
+// notPublishedFilter matches the videos which are not live at the time for a `$nor` filter, which agrees with
+// Video.Published, so a scheduled video is listed and searched once its publish time has come even if the
+// scheduler has not published it yet.
+func notPublishedFilter(now time.Time) bson.A {
+	return bson.A{
+		bson.M{"publish_status": VideoPublishStatusScheduled, "publish_at": bson.M{"$gt": now}},
+	}
+}
+
+// videoSearchDocument is a matched video with the text score added by the search
+type videoSearchDocument struct {
+	Video `bson:",inline"`
+	Score float64 `bson:"score"`
+}
+
+// Search ranks the matched videos by the text score, and the page token keeps the score and the ID
+// of the last video like the page token of List. The text index is created by the video migration.
+func (dao *mongoVideoDAO) Search(ctx context.Context, opts *SearchVideoOptions) (*VideoSearchPage, error) {
+	queryKey := opts.queryKey()
+
+	match := bson.M{
+		"$text":      bson.M{"$search": opts.Query},
+		"deleted_at": bson.M{"$exists": false},
+		"visibility": VideoVisibilityPublic,
+		"$nor":       notPublishedFilter(time.Now().UTC()),
+	}
+	if len(opts.Statuses) > 0 {
+		match["status"] = bson.M{"$in": opts.Statuses}
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$addFields", Value: bson.M{"score": bson.M{"$meta": "textScore"}}}},
+	}
+
+	if opts.PageToken != "" {
+		cursor, err := decodeVideoCursor(opts.PageToken, queryKey, bsontype.Double, false)
+		if err != nil {
+			return nil, err
+		}
+
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"$or": cursor.afterFilter("score", false, false)}}})
+	}
+
+	pipeline = append(pipeline, bson.D{{Key: "$sort", Value: bson.D{{Key: "score", Value: -1}, {Key: "_id", Value: -1}}}})
+	if opts.Limit > 0 {
+		// one more video tells whether there is a next page
+		pipeline = append(pipeline, bson.D{{Key: "$limit", Value: opts.Limit + 1}})
+	}
+
+	cursor, err := dao.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	results := make([]*VideoSearchResult, 0)
+	for cursor.Next(ctx) {
+		var doc videoSearchDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+
+		video := doc.Video
+		results = append(results, &VideoSearchResult{Video: &video, Score: doc.Score})
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	page := &VideoSearchPage{Results: results}
+
+	if opts.Limit > 0 && int64(len(results)) > opts.Limit {
+		page.Results = results[:opts.Limit]
+
+		last := page.Results[len(page.Results)-1]
+		if page.NextPageToken, err = encodeVideoCursor(queryKey, last.Score, last.Video.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	return page, nil
 }
 
 func (dao *mongoVideoDAO) Create(ctx context.Context, video *Video) error {
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	video.CreatedAt = now
+	video.UpdatedAt = now
+	if video.Visibility == "" {
+		video.Visibility = VideoVisibilityPublic
+	}
+	if video.PublishStatus == "" {
+		video.PublishStatus = VideoPublishStatusPublished
+	}
+	if video.PublishAt.IsZero() {
+		video.PublishAt = now
+	}
+
 	result, err := dao.collection.InsertOne(ctx, video)
 	if err != nil {
 		return err
@@ -68,33 +252,317 @@ func (dao *mongoVideoDAO) Create(ctx context.Context, video *Video) error {
 }
 
 func (dao *mongoVideoDAO) Update(ctx context.Context, video *Video) error {
-	if result, err := dao.collection.ReplaceOne(
-		ctx,
-		bson.M{"_id": video.ID},
-		video,
-	); err != nil {
+	data, err := bson.Marshal(video)
+	if err != nil {
+		return err
+	}
+
+	var set bson.M
+	if err := bson.Unmarshal(data, &set); err != nil {
 		return err
-	} else if result.ModifiedCount == 0 {
+	}
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	delete(set, "_id")
+	delete(set, "created_at")
+	set["updated_at"] = now
+
+	opts := options.FindOneAndUpdate().
+		SetReturnDocument(options.After).
+		SetProjection(bson.M{"created_at": 1})
+
+	var updated Video
+	if err := dao.collection.FindOneAndUpdate(ctx, bson.M{"_id": video.ID}, bson.M{"$set": set}, opts).Decode(&updated); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return ErrVideoNotFound
+		}
+		return err
+	}
+
+	video.CreatedAt = updated.CreatedAt
+	video.UpdatedAt = now
+
+	return nil
+}
+
+func (dao *mongoVideoDAO) StartEncoding(ctx context.Context, id primitive.ObjectID, variants []string) error {
+	filter := bson.M{
+		"_id":    id,
+		"status": bson.M{"$in": []VideoStatus{VideoStatusUploaded, VideoStatusEncoding}},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"status":            VideoStatusEncoding,
+			"expected_variants": variants,
+			"updated_at":        time.Now().UTC().Truncate(time.Millisecond),
+		},
+	}
+
+	if result, err := dao.collection.UpdateOne(ctx, filter, update); err != nil {
+		return err
+	} else if result.MatchedCount == 0 {
+		return dao.statusConflict(ctx, id)
+	}
+
+	return nil
+}
+
+func (dao *mongoVideoDAO) UpdateVariant(ctx context.Context, id primitive.ObjectID, variant string, objectName string, playlist *Playlist) (*Video, error) {
+	filter := bson.M{
+		"_id":    id,
+		"status": bson.M{"$in": []VideoStatus{VideoStatusEncoding, VideoStatusSuccess}},
+	}
+	// the update pipeline sets the variant and then compares the variants with the expected variants,
+	// so concurrent variants cannot both miss the last one and the video is never left in `encoding`
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"variants":   bson.M{"$mergeObjects": bson.A{"$variants", bson.M{variant: objectName}}},
+			"playlists":  bson.M{"$mergeObjects": bson.A{"$playlists", bson.M{variant: playlist}}},
+			"updated_at": time.Now().UTC().Truncate(time.Millisecond),
+		}}},
+		{{Key: "$set", Value: bson.M{
+			"status": bson.M{"$cond": bson.A{
+				bson.M{"$setIsSubset": bson.A{
+					bson.M{"$ifNull": bson.A{"$expected_variants", bson.A{}}},
+					bson.M{"$map": bson.M{"input": bson.M{"$objectToArray": "$variants"}, "in": "$$this.k"}},
+				}},
+				VideoStatusSuccess,
+				"$status",
+			}},
+		}}},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var video Video
+	if err := dao.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&video); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, dao.statusConflict(ctx, id)
+		}
+		return nil, err
+	}
+
+	return &video, nil
+}
+
+func (dao *mongoVideoDAO) UpdateManifest(ctx context.Context, id primitive.ObjectID, objectName string) error {
+	filter := bson.M{"_id": id}
+	update := bson.M{
+		"$set": bson.M{
+			"manifest_object_name": objectName,
+			"updated_at":           time.Now().UTC().Truncate(time.Millisecond),
+		},
+	}
+
+	if result, err := dao.collection.UpdateOne(ctx, filter, update); err != nil {
+		return err
+	} else if result.MatchedCount == 0 {
 		return ErrVideoNotFound
 	}
 
 	return nil
 }
 
-func (dao *mongoVideoDAO) UpdateVariant(ctx context.Context, id primitive.ObjectID, variant string, url string) error {
+func (dao *mongoVideoDAO) UpdateThumbnail(ctx context.Context, id primitive.ObjectID, thumbnail *Thumbnail) error {
 	filter := bson.M{"_id": id}
-	update := bson.D{{Key: "$set", Value: bson.M{"variants." + variant: url}}}
-	opts := options.Update()
+	update := bson.M{
+		"$set": bson.M{
+			"thumbnail":  thumbnail,
+			"updated_at": time.Now().UTC().Truncate(time.Millisecond),
+		},
+	}
+
+	if result, err := dao.collection.UpdateOne(ctx, filter, update); err != nil {
+		return err
+	} else if result.MatchedCount == 0 {
+		return ErrVideoNotFound
+	}
+
+	return nil
+}
 
-	if result, err := dao.collection.UpdateOne(ctx, filter, update, opts); err != nil {
+func (dao *mongoVideoDAO) UpdateMetadata(ctx context.Context, id primitive.ObjectID, metadata *VideoMetadata, fields []string, updatedAt time.Time) (*Video, error) {
+	// the stream worker bumps updated_at as well, so only the updates of the metadata since then conflict
+	filter := bson.M{
+		"_id":                 id,
+		"metadata_updated_at": bson.M{"$not": bson.M{"$gt": updatedAt.UTC().Truncate(time.Millisecond)}},
+	}
+
+	// the values are literals so a value starting with `$` is not taken as a field path
+	set := bson.M{"metadata_updated_at": "$updated_at"}
+	for _, field := range fields {
+		switch field {
+		case VideoFieldTitle:
+			set[field] = bson.M{"$literal": metadata.Title}
+		case VideoFieldDescription:
+			set[field] = bson.M{"$literal": metadata.Description}
+		case VideoFieldTags:
+			set[field] = bson.M{"$literal": metadata.Tags}
+		case VideoFieldLanguage:
+			set[field] = bson.M{"$literal": metadata.Language}
+		case VideoFieldVisibility:
+			set[field] = bson.M{"$literal": metadata.Visibility}
+		default:
+			return nil, fmt.Errorf("unknown video field %q", field)
+		}
+	}
+
+	// the new timestamp must be after every one read before, otherwise a stale editor could still match
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"updated_at": bson.M{"$max": bson.A{
+				time.Now().UTC().Truncate(time.Millisecond),
+				bson.M{"$add": bson.A{"$updated_at", 1}},
+			}},
+		}}},
+		{{Key: "$set", Value: set}},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var video Video
+	if err := dao.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&video); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, dao.updateConflict(ctx, id)
+		}
+		return nil, err
+	}
+
+	return &video, nil
+}
+
+// updateConflict tells whether the update matched nothing because the video is not found or has been updated
+func (dao *mongoVideoDAO) updateConflict(ctx context.Context, id primitive.ObjectID) error {
+	count, err := dao.collection.CountDocuments(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return ErrVideoNotFound
+	}
+
+	return ErrVideoUpdateConflict
+}
+
+func (dao *mongoVideoDAO) UpdateStatus(ctx context.Context, id primitive.ObjectID, from, to VideoStatus) error {
+	filter := bson.M{
+		"_id":    id,
+		"status": from,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"status":     to,
+			"updated_at": time.Now().UTC().Truncate(time.Millisecond),
+		},
+	}
+
+	if result, err := dao.collection.UpdateOne(ctx, filter, update); err != nil {
 		return err
 	} else if result.MatchedCount == 0 {
+		return dao.statusConflict(ctx, id)
+	}
+
+	return nil
+}
+
+func (dao *mongoVideoDAO) SoftDelete(ctx context.Context, id primitive.ObjectID) error {
+	filter := bson.M{"_id": id, "deleted_at": bson.M{"$exists": false}}
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	update := bson.M{
+		"$set": bson.M{
+			"deleted_at": now,
+			"updated_at": now,
+		},
+	}
+
+	if result, err := dao.collection.UpdateOne(ctx, filter, update); err != nil {
+		return err
+	} else if result.MatchedCount == 0 {
+		return ErrVideoNotFound
+	}
+
+	return nil
+}
+
+func (dao *mongoVideoDAO) Restore(ctx context.Context, id primitive.ObjectID, ownerID string) (*Video, error) {
+	filter := bson.M{
+		"_id":        id,
+		"owner_id":   ownerID,
+		"deleted_at": bson.M{"$exists": true},
+		"purging_at": bson.M{"$exists": false},
+	}
+	update := bson.M{
+		"$unset": bson.M{"deleted_at": ""},
+		"$set":   bson.M{"updated_at": time.Now().UTC().Truncate(time.Millisecond)},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var video Video
+	if err := dao.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&video); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrVideoNotFound
+		}
+		return nil, err
+	}
+
+	return &video, nil
+}
+
+func (dao *mongoVideoDAO) StartPurge(ctx context.Context, id primitive.ObjectID, deletedBefore time.Time) error {
+	filter := bson.M{"_id": id, "deleted_at": bson.M{"$lte": deletedBefore}}
+	// the time of the first attempt is kept when the purge is retried
+	update := bson.M{
+		"$min": bson.M{"purging_at": time.Now().UTC().Truncate(time.Millisecond)},
+	}
+
+	if result, err := dao.collection.UpdateOne(ctx, filter, update); err != nil {
+		return err
+	} else if result.MatchedCount == 0 {
+		return ErrVideoNotFound
+	}
+
+	return nil
+}
+
+func (dao *mongoVideoDAO) Purge(ctx context.Context, id primitive.ObjectID, deletedBefore time.Time) error {
+	filter := bson.M{"_id": id, "deleted_at": bson.M{"$lte": deletedBefore}, "purging_at": bson.M{"$exists": true}}
+
+	if result, err := dao.collection.DeleteOne(ctx, filter); err != nil {
+		return err
+	} else if result.DeletedCount == 0 {
 		return ErrVideoNotFound
 	}
 
 	return nil
 }
 
+func (dao *mongoVideoDAO) Publish(ctx context.Context, id primitive.ObjectID, publishBefore time.Time) (*Video, error) {
+	filter := bson.M{
+		"_id":            id,
+		"deleted_at":     bson.M{"$exists": false},
+		"publish_status": VideoPublishStatusScheduled,
+		"publish_at":     bson.M{"$lte": publishBefore},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"publish_status": VideoPublishStatusPublished,
+			"updated_at":     time.Now().UTC().Truncate(time.Millisecond),
+		},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var video Video
+	if err := dao.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&video); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrVideoNotFound
+		}
+		return nil, err
+	}
+
+	return &video, nil
+}
+
 func (dao *mongoVideoDAO) Delete(ctx context.Context, id primitive.ObjectID) error {
 	if result, err := dao.collection.DeleteOne(ctx, bson.M{"_id": id}); err != nil {
 		return err
@@ -104,3 +572,123 @@ func (dao *mongoVideoDAO) Delete(ctx context.Context, id primitive.ObjectID) err
 
 	return nil
 }
+
+// videoCursor is the position of the last video of a page, which is encoded as an opaque page token
+type videoCursor struct {
+	QueryKey string             `bson:"q"`
+	Value    bson.RawValue      `bson:"v"`
+	ID       primitive.ObjectID `bson:"id"`
+}
+
+// encodeVideoCursor encodes the position, a nil value is encoded as null for the video without the sort field
+func encodeVideoCursor(queryKey string, value interface{}, id primitive.ObjectID) (string, error) {
+	cursor := &videoCursor{
+		QueryKey: queryKey,
+		Value:    bson.RawValue{Type: bsontype.Null},
+		ID:       id,
+	}
+
+	if value != nil {
+		t, data, err := bson.MarshalValue(value)
+		if err != nil {
+			return "", err
+		}
+
+		cursor.Value = bson.RawValue{Type: t, Value: data}
+	}
+
+	data, err := bson.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodeVideoCursor decodes the page token, which must be issued for the same sorting and filters.
+// The value must be of the type of the sort field, so a crafted token cannot inject an operator into the filter.
+func decodeVideoCursor(token string, queryKey string, valueType bsontype.Type, optional bool) (*videoCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+
+	var cursor videoCursor
+	if err := bson.Unmarshal(data, &cursor); err != nil {
+		return nil, ErrInvalidPageToken
+	}
+
+	if cursor.QueryKey != queryKey || cursor.ID.IsZero() {
+		return nil, ErrInvalidPageToken
+	}
+
+	if cursor.Value.Type != valueType && !(optional && cursor.Value.Type == bsontype.Null) {
+		return nil, ErrInvalidPageToken
+	}
+
+	if err := cursor.Value.Validate(); err != nil {
+		return nil, ErrInvalidPageToken
+	}
+
+	return &cursor, nil
+}
+
+// afterFilter matches the videos after the cursor. The videos without an optional sort field sort as null,
+// which is before any value in ascending order and after any value in descending order.
+func (cursor *videoCursor) afterFilter(field string, ascending bool, optional bool) bson.A {
+	after := "$lt"
+	if ascending {
+		after = "$gt"
+	}
+
+	if cursor.Value.Type == bsontype.Null {
+		filter := bson.A{bson.M{field: nil, "_id": bson.M{after: cursor.ID}}}
+		if ascending {
+			filter = append(filter, bson.M{field: bson.M{"$ne": nil}})
+		}
+
+		return filter
+	}
+
+	filter := bson.A{
+		bson.M{field: bson.M{after: cursor.Value}},
+		bson.M{field: cursor.Value, "_id": bson.M{after: cursor.ID}},
+	}
+	if optional && !ascending {
+		filter = append(filter, bson.M{field: nil})
+	}
+
+	return filter
+}
+
+// optional tells whether the field may be missing from the videos, which sort as null
+func (f VideoSortField) optional() bool {
+	return f == VideoSortSize || f == VideoSortDuration
+}
+
+// bsonType returns the BSON type of the field, which the value of a page token must be of
+func (f VideoSortField) bsonType() bsontype.Type {
+	switch f {
+	case VideoSortSize:
+		return bsontype.Int64
+	case VideoSortDuration:
+		return bsontype.Double
+	default:
+		return bsontype.DateTime
+	}
+}
+
+// statusConflict tells apart why a conditional update matches nothing,
+// it is only called on the failure path so the happy path is a single update
+func (dao *mongoVideoDAO) statusConflict(ctx context.Context, id primitive.ObjectID) error {
+	count, err := dao.collection.CountDocuments(ctx, bson.M{"_id": id}, options.Count().SetLimit(1))
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return ErrVideoNotFound
+	}
+
+	return ErrVideoStatusConflict
+}
@@ -2,6 +2,8 @@ package dao
 
 import (
 	"context"
+	"encoding/base64"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -61,22 +63,81 @@ var _ = Describe("mongoVideoDAO", func() {
 		})
 	})
 
+	Describe("GetIncludingDeleted", func() {
+		var (
+			video *Video
+			id    primitive.ObjectID
+
+			resp *Video
+			err  error
+		)
+
+		BeforeEach(func() {
+			video = NewFakeVideo()
+			id = video.ID
+
+			insertVideo(ctx, videoDAO, video)
+			Expect(videoDAO.SoftDelete(ctx, id)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			deleteVideo(ctx, videoDAO, video.ID)
+		})
+
+		JustBeforeEach(func() {
+			resp, err = videoDAO.GetIncludingDeleted(ctx, id)
+		})
+
+		When("video is being purged", func() {
+			BeforeEach(func() {
+				Expect(videoDAO.StartPurge(ctx, id, time.Now().UTC().Add(time.Minute))).To(Succeed())
+			})
+
+			It("returns video not found error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrVideoNotFound))
+			})
+		})
+
+		When("video is in the trash", func() {
+			It("returns the video with no error", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.ID).To(Equal(id))
+				Expect(resp.DeletedAt).NotTo(BeZero())
+			})
+		})
+	})
+
 	Describe("List", func() {
 		var (
 			videos []*Video
-			limit  int64
-			skip   int64
+			opts   *ListVideoOptions
 
-			resp []*Video
+			resp *VideoPage
 			err  error
 		)
 
 		BeforeEach(func() {
+			now := time.Now().UTC().Truncate(time.Millisecond)
+
 			videos = []*Video{NewFakeVideo(), NewFakeVideo(), NewFakeVideo()}
+			for i, video := range videos {
+				// the videos are created in order, the later video is smaller
+				video.CreatedAt = now.Add(time.Duration(i) * time.Second)
+				video.UpdatedAt = video.CreatedAt
+				video.Size = uint64(len(videos) - i)
 
-			for _, video := range videos {
 				insertVideo(ctx, videoDAO, video)
 			}
+
+			videos[1].Status = VideoStatusFailed
+			videos[1].Tags = []string{"animation"}
+			Expect(videoDAO.collection.UpdateByID(ctx, videos[1].ID, bson.M{"$set": bson.M{
+				"status": videos[1].Status,
+				"tags":   videos[1].Tags,
+			}})).NotTo(BeNil())
+
+			opts = &ListVideoOptions{}
 		})
 
 		AfterEach(func() {
@@ -86,32 +147,338 @@ var _ = Describe("mongoVideoDAO", func() {
 		})
 
 		JustBeforeEach(func() {
-			resp, err = videoDAO.List(ctx, limit, skip)
+			resp, err = videoDAO.List(ctx, opts)
 		})
 
 		Context("success", func() {
 			When("no limit and offset", func() {
-				It("returns videos with no error", func() {
-					Expect(resp).To(Equal(videos))
+				It("returns the newest videos first with no next page", func() {
+					Expect(resp).To(Equal(&VideoPage{Videos: []*Video{videos[2], videos[1], videos[0]}}))
 					Expect(err).NotTo(HaveOccurred())
 				})
 			})
 
-			When("limit = 1", func() {
-				BeforeEach(func() { limit = 1 })
+			When("limit = 1 and skip = 1", func() {
+				BeforeEach(func() { opts.Limit, opts.Skip = 1, 1 })
 
-				It("returns the first video with no error", func() {
-					Expect(resp).To(Equal(videos[:1]))
+				It("returns the second video with no error", func() {
+					Expect(resp.Videos).To(Equal(videos[1:2]))
 					Expect(err).NotTo(HaveOccurred())
 				})
 			})
 
-			When("limit = 1 and skip = 1", func() {
-				BeforeEach(func() { limit, skip = 1, 1 })
+			When("sorting by size in ascending order", func() {
+				BeforeEach(func() { opts.SortBy, opts.Ascending = VideoSortSize, true })
 
-				It("returns the second video with no error", func() {
-					Expect(resp).To(Equal(videos[1:2]))
+				It("returns the smallest videos first", func() {
+					Expect(resp.Videos).To(Equal([]*Video{videos[2], videos[1], videos[0]}))
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+
+			When("filtering by statuses and tags", func() {
+				BeforeEach(func() {
+					opts.Statuses = []VideoStatus{VideoStatusSuccess}
+					opts.Tags = []string{"comedy"}
+				})
+
+				It("returns the matched videos", func() {
+					Expect(resp.Videos).To(Equal([]*Video{videos[2], videos[0]}))
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+
+			When("the videos are not public", func() {
+				BeforeEach(func() {
+					Expect(videoDAO.collection.UpdateByID(ctx, videos[0].ID, bson.M{"$set": bson.M{
+						"visibility": VideoVisibilityUnlisted,
+					}})).NotTo(BeNil())
+					Expect(videoDAO.collection.UpdateByID(ctx, videos[1].ID, bson.M{"$set": bson.M{
+						"visibility": VideoVisibilityPrivate,
+					}})).NotTo(BeNil())
+				})
+
+				It("returns the public videos only", func() {
+					Expect(resp).To(Equal(&VideoPage{Videos: []*Video{videos[2]}}))
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+
+			When("the videos are scheduled", func() {
+				BeforeEach(func() {
+					for i, video := range videos[:2] {
+						video.PublishStatus = VideoPublishStatusScheduled
+						video.PublishAt = video.CreatedAt.Add(time.Duration(i+1) * time.Hour)
+						Expect(videoDAO.collection.UpdateByID(ctx, video.ID, bson.M{"$set": bson.M{
+							"publish_status": video.PublishStatus,
+							"publish_at":     video.PublishAt,
+						}})).NotTo(BeNil())
+					}
+				})
+
+				It("returns the published videos only", func() {
+					Expect(resp).To(Equal(&VideoPage{Videos: []*Video{videos[2]}}))
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("returns the scheduled videos once their publish time has come", func() {
+					videos[0].PublishAt = time.Now().UTC().Add(-time.Minute).Truncate(time.Millisecond)
+					Expect(videoDAO.collection.UpdateByID(ctx, videos[0].ID, bson.M{"$set": bson.M{
+						"publish_at": videos[0].PublishAt,
+					}})).NotTo(BeNil())
+
+					page, err := videoDAO.List(ctx, &ListVideoOptions{})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(page).To(Equal(&VideoPage{Videos: []*Video{videos[2], videos[0]}}))
+				})
+
+				It("returns the scheduled videos due before the time in the order of the publish time", func() {
+					page, err := videoDAO.List(ctx, &ListVideoOptions{
+						SortBy:        VideoSortPublishAt,
+						Ascending:     true,
+						Scheduled:     true,
+						PublishBefore: videos[1].PublishAt,
+					})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(page).To(Equal(&VideoPage{Videos: []*Video{videos[0], videos[1]}}))
+
+					page, err = videoDAO.List(ctx, &ListVideoOptions{
+						Scheduled:     true,
+						PublishBefore: videos[0].PublishAt,
+					})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(page).To(Equal(&VideoPage{Videos: []*Video{videos[0]}}))
+				})
+			})
+
+			When("listing by pages", func() {
+				BeforeEach(func() { opts.Limit = 2 })
+
+				It("returns the next page by the page token", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(resp.Videos).To(Equal([]*Video{videos[2], videos[1]}))
+					Expect(resp.NextPageToken).NotTo(BeEmpty())
+
+					opts.PageToken = resp.NextPageToken
+					next, err := videoDAO.List(ctx, opts)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(next).To(Equal(&VideoPage{Videos: []*Video{videos[0]}}))
+				})
+			})
+
+			When("listing by size in pages with a video without the size", func() {
+				listAll := func() []*Video {
+					var listed []*Video
+					for {
+						page, err := videoDAO.List(ctx, opts)
+						Expect(err).NotTo(HaveOccurred())
+
+						listed = append(listed, page.Videos...)
+						if page.NextPageToken == "" {
+							return listed
+						}
+						opts.PageToken = page.NextPageToken
+					}
+				}
+
+				BeforeEach(func() {
+					opts.SortBy, opts.Limit = VideoSortSize, 1
+
+					videos[1].Size = 0
+					Expect(videoDAO.collection.UpdateByID(ctx, videos[1].ID, bson.M{"$unset": bson.M{"size": ""}})).NotTo(BeNil())
+				})
+
+				It("lists the video without the size last in descending order", func() {
+					Expect(listAll()).To(Equal([]*Video{videos[0], videos[2], videos[1]}))
+				})
+
+				It("lists the video without the size first in ascending order", func() {
+					opts.Ascending = true
+					Expect(listAll()).To(Equal([]*Video{videos[1], videos[2], videos[0]}))
+				})
+			})
+
+			When("listing by pages after skipping", func() {
+				BeforeEach(func() { opts.Limit, opts.Skip = 1, 1 })
+
+				It("skips the videos on the first page only", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(resp.Videos).To(Equal([]*Video{videos[1]}))
+
+					opts.PageToken = resp.NextPageToken
+					next, err := videoDAO.List(ctx, opts)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(next).To(Equal(&VideoPage{Videos: []*Video{videos[0]}}))
+				})
+			})
+		})
+
+		Context("failure", func() {
+			When("page token is malformed", func() {
+				BeforeEach(func() { opts.PageToken = "malformed" })
+
+				It("returns invalid page token error", func() {
+					Expect(resp).To(BeNil())
+					Expect(err).To(MatchError(ErrInvalidPageToken))
+				})
+			})
+
+			When("page token is of another query", func() {
+				BeforeEach(func() {
+					opts.Limit = 1
+					page, err := videoDAO.List(ctx, opts)
+					Expect(err).NotTo(HaveOccurred())
+
+					opts.SortBy = VideoSortSize
+					opts.PageToken = page.NextPageToken
+				})
+
+				It("returns invalid page token error", func() {
+					Expect(resp).To(BeNil())
+					Expect(err).To(MatchError(ErrInvalidPageToken))
+				})
+			})
+
+			When("page token carries an operator instead of the sort value", func() {
+				BeforeEach(func() {
+					opts.Limit = 1
+
+					data, err := bson.Marshal(bson.M{"q": opts.queryKey(), "v": bson.M{"$gt": ""}, "id": videos[0].ID})
+					Expect(err).NotTo(HaveOccurred())
+					opts.PageToken = base64.RawURLEncoding.EncodeToString(data)
+				})
+
+				It("returns invalid page token error", func() {
+					Expect(resp).To(BeNil())
+					Expect(err).To(MatchError(ErrInvalidPageToken))
+				})
+			})
+
+			When("page token carries a value of another type than the sort field", func() {
+				BeforeEach(func() {
+					opts.Limit = 1
+
+					var err error
+					opts.PageToken, err = encodeVideoCursor(opts.queryKey(), "2006-01-02", videos[0].ID)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("returns invalid page token error", func() {
+					Expect(resp).To(BeNil())
+					Expect(err).To(MatchError(ErrInvalidPageToken))
+				})
+			})
+		})
+	})
+
+	Describe("Search", func() {
+		var (
+			videos []*Video
+			opts   *SearchVideoOptions
+
+			resp *VideoSearchPage
+			err  error
+		)
+
+		BeforeEach(func() {
+			videos = []*Video{NewFakeVideo(), NewFakeVideo(), NewFakeVideo()}
+			videos[0].Title = "Sintel"
+			videos[0].Description = "A girl searches for a baby dragon"
+			videos[0].Tags = []string{"fantasy"}
+			videos[1].Title = "Dragon Tales"
+			videos[1].Description = "Dragons and dragons"
+			videos[1].Tags = []string{"dragon"}
+			videos[2].Title = "Big Buck Bunny"
+			videos[2].Status = VideoStatusFailed
+
+			for _, video := range videos {
+				insertVideo(ctx, videoDAO, video)
+			}
+
+			opts = &SearchVideoOptions{Query: "dragons"}
+		})
+
+		AfterEach(func() {
+			for _, video := range videos {
+				deleteVideo(ctx, videoDAO, video.ID)
+			}
+		})
+
+		JustBeforeEach(func() {
+			resp, err = videoDAO.Search(ctx, opts)
+		})
+
+		Context("success", func() {
+			When("the words are matched by the stems", func() {
+				It("returns the matched videos in the order of relevance", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(resp.Results).To(HaveLen(2))
+					Expect(resp.Results[0].Video).To(Equal(videos[1]))
+					Expect(resp.Results[1].Video).To(Equal(videos[0]))
+					Expect(resp.Results[0].Score).To(BeNumerically(">", resp.Results[1].Score))
+					Expect(resp.NextPageToken).To(BeEmpty())
+				})
+			})
+
+			When("filtering by statuses", func() {
+				BeforeEach(func() {
+					opts.Query = "bunny"
+					opts.Statuses = []VideoStatus{VideoStatusSuccess}
+				})
+
+				It("returns no video", func() {
+					Expect(resp.Results).To(BeEmpty())
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+
+			When("the video is unlisted", func() {
+				BeforeEach(func() {
+					Expect(videoDAO.collection.UpdateByID(ctx, videos[1].ID, bson.M{"$set": bson.M{
+						"visibility": VideoVisibilityUnlisted,
+					}})).NotTo(BeNil())
+				})
+
+				It("returns the public videos only", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(resp.Results).To(HaveLen(1))
+					Expect(resp.Results[0].Video).To(Equal(videos[0]))
+				})
+			})
+
+			When("searching by pages", func() {
+				BeforeEach(func() { opts.Limit = 1 })
+
+				It("returns the next page by the page token", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(resp.Results).To(HaveLen(1))
+					Expect(resp.Results[0].Video).To(Equal(videos[1]))
+					Expect(resp.NextPageToken).NotTo(BeEmpty())
+
+					opts.PageToken = resp.NextPageToken
+					next, err := videoDAO.Search(ctx, opts)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(next.Results).To(HaveLen(1))
+					Expect(next.Results[0].Video).To(Equal(videos[0]))
+					Expect(next.NextPageToken).To(BeEmpty())
+				})
+			})
+		})
+
+		Context("failure", func() {
+			When("page token is of another query", func() {
+				BeforeEach(func() {
+					opts.Limit = 1
+					page, err := videoDAO.Search(ctx, opts)
 					Expect(err).NotTo(HaveOccurred())
+
+					opts.Query = "dragon"
+					opts.PageToken = page.NextPageToken
+				})
+
+				It("returns invalid page token error", func() {
+					Expect(resp).To(BeNil())
+					Expect(err).To(MatchError(ErrInvalidPageToken))
 				})
 			})
 		})
@@ -152,13 +519,54 @@ var _ = Describe("mongoVideoDAO", func() {
 
 				Expect(&getVideo).To(Equal(video))
 			})
+
+			It("sets both timestamps to now", func() {
+				Expect(video.CreatedAt).To(BeTemporally("~", time.Now(), time.Second))
+				Expect(video.UpdatedAt).To(Equal(video.CreatedAt))
+			})
+		})
+
+		When("visibility is empty", func() {
+			BeforeEach(func() { video.Visibility = "" })
+
+			It("defaults the visibility to public", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(video.Visibility).To(Equal(VideoVisibilityPublic))
+			})
+		})
+
+		When("video is not scheduled", func() {
+			BeforeEach(func() { video.PublishStatus = "" })
+
+			It("publishes the video now", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(video.PublishStatus).To(Equal(VideoPublishStatusPublished))
+				Expect(video.PublishAt).To(Equal(video.CreatedAt))
+			})
+		})
+
+		When("video is scheduled", func() {
+			var publishAt time.Time
+
+			BeforeEach(func() {
+				publishAt = time.Now().UTC().Add(time.Hour).Truncate(time.Millisecond)
+				video.PublishStatus = VideoPublishStatusScheduled
+				video.PublishAt = publishAt
+			})
+
+			It("keeps the publish time", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(video.PublishStatus).To(Equal(VideoPublishStatusScheduled))
+				Expect(video.PublishAt).To(Equal(publishAt))
+			})
 		})
 	})
 
 	Describe("Update", func() {
 		var (
-			video *Video
-			id    primitive.ObjectID
+			video     *Video
+			id        primitive.ObjectID
+			createdAt time.Time
 
 			err error
 		)
@@ -166,6 +574,9 @@ var _ = Describe("mongoVideoDAO", func() {
 		BeforeEach(func() {
 			video = NewFakeVideo()
 			id = video.ID
+			createdAt = time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC)
+			video.CreatedAt = createdAt
+			video.UpdatedAt = createdAt
 
 			insertVideo(ctx, videoDAO, video)
 		})
@@ -192,12 +603,21 @@ var _ = Describe("mongoVideoDAO", func() {
 			BeforeEach(func() {
 				size = 1234
 				video.Size = size
+				// the caller does not need to know created_at
+				video.CreatedAt = time.Time{}
 			})
 
 			It("returns no error", func() {
 				Expect(err).NotTo(HaveOccurred())
 			})
 
+			It("preserves created_at and bumps updated_at", func() {
+				getVideo := findVideo(ctx, videoDAO, id)
+				Expect(getVideo.CreatedAt).To(Equal(createdAt))
+				Expect(getVideo.UpdatedAt).To(BeTemporally("~", time.Now(), time.Second))
+				Expect(video.CreatedAt).To(Equal(createdAt))
+			})
+
 			It("updates the document", func() {
 				var getVideo Video
 
@@ -211,21 +631,21 @@ var _ = Describe("mongoVideoDAO", func() {
 		})
 	})
 
-	Describe("UpdateVariant", func() {
+	Describe("StartEncoding", func() {
 		var (
-			video   *Video
-			id      primitive.ObjectID
-			url     string
-			variant string
+			video    *Video
+			id       primitive.ObjectID
+			variants []string
 
 			err error
 		)
 
 		BeforeEach(func() {
 			video = NewFakeVideo()
+			video.Status = VideoStatusUploaded
+			video.Variants = nil
 			id = video.ID
-			variant = "720p"
-			url = video.URL
+			variants = []string{"1080", "720"}
 
 			insertVideo(ctx, videoDAO, video)
 		})
@@ -235,7 +655,7 @@ var _ = Describe("mongoVideoDAO", func() {
 		})
 
 		JustBeforeEach(func() {
-			err = videoDAO.UpdateVariant(ctx, video.ID, variant, url)
+			err = videoDAO.StartEncoding(ctx, video.ID, variants)
 		})
 
 		When("video not found", func() {
@@ -246,76 +666,858 @@ var _ = Describe("mongoVideoDAO", func() {
 			})
 		})
 
-		When("success", func() {
+		When("video is already transcoded", func() {
+			BeforeEach(func() {
+				updateVideoStatus(ctx, videoDAO, id, VideoStatusSuccess)
+			})
+
+			It("returns video status conflict error", func() {
+				Expect(err).To(MatchError(ErrVideoStatusConflict))
+			})
+
+			It("does not change the status", func() {
+				Expect(findVideo(ctx, videoDAO, id).Status).To(Equal(VideoStatusSuccess))
+			})
+		})
+
+		When("video is already encoding", func() {
 			BeforeEach(func() {
-				video.Variants[variant] = url
+				updateVideoStatus(ctx, videoDAO, id, VideoStatusEncoding)
 			})
 
 			It("returns no error", func() {
 				Expect(err).NotTo(HaveOccurred())
 			})
+		})
 
-			It("updates the variant", func() {
-				var getVideo Video
+		When("success", func() {
+			It("returns no error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
 
-				Expect(
-					videoDAO.collection.FindOne(ctx, bson.M{"_id": video.ID}).Decode(&getVideo),
-				).NotTo(HaveOccurred())
+			It("bumps updated_at", func() {
+				Expect(findVideo(ctx, videoDAO, id).UpdatedAt).To(BeTemporally("~", time.Now(), time.Second))
+			})
 
-				Expect(getVideo.Variants[variant]).To(Equal(url))
+			It("records the expected variants", func() {
+				getVideo := findVideo(ctx, videoDAO, id)
+				Expect(getVideo.Status).To(Equal(VideoStatusEncoding))
+				Expect(getVideo.ExpectedVariants).To(Equal(variants))
 			})
 		})
 	})
 
-	Describe("Delete", func() {
+	Describe("UpdateVariant", func() {
 		var (
-			video *Video
-			id    primitive.ObjectID
-
-			err error
+			video      *Video
+			id         primitive.ObjectID
+			objectName string
+			variant    string
+			playlist   *Playlist
+
+			updatedVideo *Video
+			err          error
 		)
 
 		BeforeEach(func() {
 			video = NewFakeVideo()
+			video.Status = VideoStatusEncoding
+			video.Variants = nil
+			video.Playlists = nil
+			video.ExpectedVariants = []string{"1080", "720"}
 			id = video.ID
+			variant = "720"
+			objectName = id.Hex() + "-720.mp4"
+			playlist = &Playlist{ObjectName: id.Hex() + "-hls/720/index.m3u8", Width: 1280, Height: 720, Bandwidth: 2880000}
 
 			insertVideo(ctx, videoDAO, video)
 		})
 
+		AfterEach(func() {
+			deleteVideo(ctx, videoDAO, id)
+		})
+
 		JustBeforeEach(func() {
-			err = videoDAO.Delete(ctx, video.ID)
+			updatedVideo, err = videoDAO.UpdateVariant(ctx, video.ID, variant, objectName, playlist)
 		})
 
 		When("video not found", func() {
 			BeforeEach(func() { video.ID = primitive.NewObjectID() })
 
-			AfterEach(func() {
-				deleteVideo(ctx, videoDAO, id)
+			It("returns video not found error", func() {
+				Expect(err).To(MatchError(ErrVideoNotFound))
 			})
+		})
 
-			It("returns video not found error", func() {
+		When("video is not encoding", func() {
+			BeforeEach(func() {
+				updateVideoStatus(ctx, videoDAO, id, VideoStatusFailed)
+			})
 
-				Expect(err).To(MatchError(ErrVideoNotFound))
+			It("returns video status conflict error", func() {
+				Expect(err).To(MatchError(ErrVideoStatusConflict))
+			})
+
+			It("does not update the variant", func() {
+				Expect(findVideo(ctx, videoDAO, id).Variants).To(BeEmpty())
 			})
 		})
 
-		When("success", func() {
-			It("returns no error", func() {
+		When("other variants are not transcoded", func() {
+			It("returns the updated video with no error", func() {
 				Expect(err).NotTo(HaveOccurred())
+				Expect(updatedVideo.Playlists).To(Equal(map[string]*Playlist{variant: playlist}))
 			})
 
-			It("deletes the document", func() {
-				var getVideo Video
+			It("bumps updated_at", func() {
+				Expect(findVideo(ctx, videoDAO, id).UpdatedAt).To(BeTemporally("~", time.Now(), time.Second))
+			})
 
-				Expect(
-					videoDAO.collection.FindOne(ctx, bson.M{"_id": video.ID}).Decode(&getVideo),
-				).To(Equal(mongo.ErrNoDocuments))
+			It("updates the variant and keeps encoding", func() {
+				getVideo := findVideo(ctx, videoDAO, id)
+				Expect(getVideo.Variants).To(Equal(map[string]string{variant: objectName}))
+				Expect(getVideo.Playlists).To(Equal(map[string]*Playlist{variant: playlist}))
+				Expect(getVideo.Status).To(Equal(VideoStatusEncoding))
 			})
 		})
-	})
-})
 
-// useful methods for testing
+		When("video is already succeeded", func() {
+			BeforeEach(func() {
+				updateVideoStatus(ctx, videoDAO, id, VideoStatusSuccess)
+			})
+
+			It("returns no error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("updates the variant and keeps succeeded", func() {
+				getVideo := findVideo(ctx, videoDAO, id)
+				Expect(getVideo.Variants).To(Equal(map[string]string{variant: objectName}))
+				Expect(getVideo.Status).To(Equal(VideoStatusSuccess))
+			})
+		})
+
+		When("the last variant is transcoded", func() {
+			BeforeEach(func() {
+				_, err := videoDAO.UpdateVariant(ctx, id, "1080", id.Hex()+"-1080.mp4", &Playlist{ObjectName: id.Hex() + "-hls/1080/index.m3u8"})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns the updated video with no error", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(updatedVideo.Status).To(Equal(VideoStatusSuccess))
+				Expect(updatedVideo.Playlists).To(HaveLen(2))
+			})
+
+			It("updates the variant and succeeds the video", func() {
+				getVideo := findVideo(ctx, videoDAO, id)
+				Expect(getVideo.Variants).To(Equal(map[string]string{
+					"1080":  id.Hex() + "-1080.mp4",
+					variant: objectName,
+				}))
+				Expect(getVideo.Status).To(Equal(VideoStatusSuccess))
+			})
+		})
+	})
+
+	Describe("UpdateManifest", func() {
+		var (
+			video      *Video
+			id         primitive.ObjectID
+			objectName string
+
+			err error
+		)
+
+		BeforeEach(func() {
+			video = NewFakeVideo()
+			video.ManifestObjectName = ""
+			id = video.ID
+			objectName = id.Hex() + "-hls/master.m3u8"
+
+			insertVideo(ctx, videoDAO, video)
+		})
+
+		AfterEach(func() {
+			deleteVideo(ctx, videoDAO, id)
+		})
+
+		JustBeforeEach(func() {
+			err = videoDAO.UpdateManifest(ctx, video.ID, objectName)
+		})
+
+		When("video not found", func() {
+			BeforeEach(func() { video.ID = primitive.NewObjectID() })
+
+			It("returns video not found error", func() {
+				Expect(err).To(MatchError(ErrVideoNotFound))
+			})
+		})
+
+		When("success", func() {
+			It("returns no error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("updates the manifest", func() {
+				Expect(findVideo(ctx, videoDAO, id).ManifestObjectName).To(Equal(objectName))
+			})
+		})
+	})
+
+	Describe("UpdateThumbnail", func() {
+		var (
+			video     *Video
+			id        primitive.ObjectID
+			thumbnail *Thumbnail
+
+			err error
+		)
+
+		BeforeEach(func() {
+			video = NewFakeVideo()
+			video.Thumbnail = nil
+			id = video.ID
+			thumbnail = &Thumbnail{
+				PosterObjectName:  id.Hex() + "-poster.jpg",
+				SpriteObjectName:  id.Hex() + "-sprite.jpg",
+				PreviewObjectName: id.Hex() + "-preview.vtt",
+			}
+
+			insertVideo(ctx, videoDAO, video)
+		})
+
+		AfterEach(func() {
+			deleteVideo(ctx, videoDAO, id)
+		})
+
+		JustBeforeEach(func() {
+			err = videoDAO.UpdateThumbnail(ctx, video.ID, thumbnail)
+		})
+
+		When("video not found", func() {
+			BeforeEach(func() { video.ID = primitive.NewObjectID() })
+
+			It("returns video not found error", func() {
+				Expect(err).To(MatchError(ErrVideoNotFound))
+			})
+		})
+
+		When("success", func() {
+			It("returns no error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("updates the thumbnail", func() {
+				Expect(findVideo(ctx, videoDAO, id).Thumbnail).To(Equal(thumbnail))
+			})
+		})
+	})
+
+	Describe("UpdateMetadata", func() {
+		var (
+			video     *Video
+			id        primitive.ObjectID
+			metadata  *VideoMetadata
+			fields    []string
+			updatedAt time.Time
+
+			updatedVideo *Video
+			err          error
+		)
+
+		BeforeEach(func() {
+			video = NewFakeVideo()
+			id = video.ID
+			metadata = &VideoMetadata{Title: "New Title", Tags: []string{"new"}}
+			fields = []string{VideoFieldTitle, VideoFieldTags}
+			updatedAt = time.Time{}
+
+			insertVideo(ctx, videoDAO, video)
+		})
+
+		AfterEach(func() {
+			deleteVideo(ctx, videoDAO, id)
+		})
+
+		JustBeforeEach(func() {
+			updatedVideo, err = videoDAO.UpdateMetadata(ctx, video.ID, metadata, fields, updatedAt)
+		})
+
+		When("video not found", func() {
+			BeforeEach(func() { video.ID = primitive.NewObjectID() })
+
+			It("returns video not found error", func() {
+				Expect(err).To(MatchError(ErrVideoNotFound))
+			})
+		})
+
+		When("metadata has been updated since", func() {
+			BeforeEach(func() {
+				_, err := videoDAO.UpdateMetadata(ctx, id, &VideoMetadata{Title: "Other Title"}, []string{VideoFieldTitle}, updatedAt)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns video update conflict error", func() {
+				Expect(err).To(MatchError(ErrVideoUpdateConflict))
+			})
+
+			It("keeps the other update", func() {
+				Expect(findVideo(ctx, videoDAO, id).Title).To(Equal("Other Title"))
+			})
+		})
+
+		When("video has been updated by the stream worker since", func() {
+			BeforeEach(func() {
+				Expect(videoDAO.UpdateStatus(ctx, id, video.Status, VideoStatusFailed)).To(Succeed())
+			})
+
+			It("returns the updated video", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(updatedVideo.Title).To(Equal("New Title"))
+				Expect(updatedVideo.Status).To(Equal(VideoStatusFailed))
+			})
+		})
+
+		When("success based on the last update", func() {
+			BeforeEach(func() {
+				previous, err := videoDAO.UpdateMetadata(ctx, id, &VideoMetadata{Title: "Other Title"}, []string{VideoFieldTitle}, updatedAt)
+				Expect(err).NotTo(HaveOccurred())
+
+				updatedAt = previous.UpdatedAt
+			})
+
+			It("returns the updated video with a later timestamp", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(updatedVideo.Title).To(Equal("New Title"))
+				Expect(updatedVideo.UpdatedAt).To(BeTemporally(">", updatedAt))
+			})
+		})
+
+		When("video has been updated later than now", func() {
+			BeforeEach(func() {
+				updatedAt = time.Now().Add(time.Hour).UTC().Truncate(time.Millisecond)
+				Expect(videoDAO.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"updated_at": updatedAt}})).
+					To(Equal(&mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}))
+			})
+
+			It("returns the updated video with a later timestamp", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(updatedVideo.UpdatedAt).To(BeTemporally(">", updatedAt))
+			})
+		})
+
+		When("title starts with a dollar sign", func() {
+			BeforeEach(func() { metadata.Title = "$title" })
+
+			It("sets the title as is", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(findVideo(ctx, videoDAO, id).Title).To(Equal("$title"))
+			})
+		})
+
+		When("success", func() {
+			It("returns the updated video", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(updatedVideo.ID).To(Equal(id))
+				Expect(updatedVideo.UpdatedAt).NotTo(BeZero())
+				Expect(updatedVideo.MetadataUpdatedAt).To(Equal(updatedVideo.UpdatedAt))
+			})
+
+			It("updates only the fields in the mask", func() {
+				getVideo := findVideo(ctx, videoDAO, id)
+				Expect(getVideo.VideoMetadata).To(Equal(VideoMetadata{
+					Title:       "New Title",
+					Description: video.Description,
+					Tags:        []string{"new"},
+					Language:    video.Language,
+				}))
+			})
+		})
+	})
+
+	Describe("UpdateStatus", func() {
+		var (
+			video *Video
+			id    primitive.ObjectID
+
+			err error
+		)
+
+		BeforeEach(func() {
+			video = NewFakeVideo()
+			video.Status = VideoStatusEncoding
+			id = video.ID
+
+			insertVideo(ctx, videoDAO, video)
+		})
+
+		AfterEach(func() {
+			deleteVideo(ctx, videoDAO, id)
+		})
+
+		JustBeforeEach(func() {
+			err = videoDAO.UpdateStatus(ctx, video.ID, VideoStatusEncoding, VideoStatusFailed)
+		})
+
+		When("video not found", func() {
+			BeforeEach(func() { video.ID = primitive.NewObjectID() })
+
+			It("returns video not found error", func() {
+				Expect(err).To(MatchError(ErrVideoNotFound))
+			})
+		})
+
+		When("video is not in the from status", func() {
+			BeforeEach(func() {
+				updateVideoStatus(ctx, videoDAO, id, VideoStatusSuccess)
+			})
+
+			It("returns video status conflict error", func() {
+				Expect(err).To(MatchError(ErrVideoStatusConflict))
+			})
+
+			It("does not move the video backwards", func() {
+				Expect(findVideo(ctx, videoDAO, id).Status).To(Equal(VideoStatusSuccess))
+			})
+		})
+
+		When("success", func() {
+			It("returns no error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("updates the status", func() {
+				Expect(findVideo(ctx, videoDAO, id).Status).To(Equal(VideoStatusFailed))
+			})
+
+			It("bumps updated_at", func() {
+				Expect(findVideo(ctx, videoDAO, id).UpdatedAt).To(BeTemporally("~", time.Now(), time.Second))
+			})
+		})
+	})
+
+	Describe("SoftDelete", func() {
+		var (
+			video *Video
+			id    primitive.ObjectID
+
+			err error
+		)
+
+		BeforeEach(func() {
+			video = NewFakeVideo()
+			id = video.ID
+
+			insertVideo(ctx, videoDAO, video)
+		})
+
+		AfterEach(func() {
+			deleteVideo(ctx, videoDAO, video.ID)
+		})
+
+		JustBeforeEach(func() {
+			err = videoDAO.SoftDelete(ctx, id)
+		})
+
+		When("video not found", func() {
+			BeforeEach(func() { id = primitive.NewObjectID() })
+
+			It("returns video not found error", func() {
+				Expect(err).To(MatchError(ErrVideoNotFound))
+			})
+		})
+
+		When("video is already in the trash", func() {
+			BeforeEach(func() {
+				Expect(videoDAO.SoftDelete(ctx, id)).To(Succeed())
+			})
+
+			It("returns video not found error", func() {
+				Expect(err).To(MatchError(ErrVideoNotFound))
+			})
+		})
+
+		When("success", func() {
+			It("moves the video to the trash", func() {
+				Expect(err).NotTo(HaveOccurred())
+
+				deleted := findVideo(ctx, videoDAO, id)
+				Expect(deleted.DeletedAt).To(BeTemporally("~", time.Now(), time.Second))
+				Expect(deleted.UpdatedAt).To(Equal(deleted.DeletedAt))
+			})
+
+			It("hides the video from Get, List and Search", func() {
+				_, getErr := videoDAO.Get(ctx, id)
+				Expect(getErr).To(MatchError(ErrVideoNotFound))
+
+				page, listErr := videoDAO.List(ctx, &ListVideoOptions{})
+				Expect(listErr).NotTo(HaveOccurred())
+				for _, v := range page.Videos {
+					Expect(v.ID).NotTo(Equal(id))
+				}
+
+				results, searchErr := videoDAO.Search(ctx, &SearchVideoOptions{Query: "bunny"})
+				Expect(searchErr).NotTo(HaveOccurred())
+				for _, result := range results.Results {
+					Expect(result.Video.ID).NotTo(Equal(id))
+				}
+			})
+
+			It("lists the video in the trash", func() {
+				page, listErr := videoDAO.List(ctx, &ListVideoOptions{
+					SortBy:        VideoSortDeletedAt,
+					Ascending:     true,
+					Deleted:       true,
+					DeletedBefore: time.Now().Add(time.Second),
+				})
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(page.Videos).To(HaveLen(1))
+				Expect(page.Videos[0].ID).To(Equal(id))
+
+				page, listErr = videoDAO.List(ctx, &ListVideoOptions{
+					SortBy:        VideoSortDeletedAt,
+					Deleted:       true,
+					DeletedBefore: time.Now().Add(-time.Hour),
+				})
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(page.Videos).To(BeEmpty())
+
+				page, listErr = videoDAO.List(ctx, &ListVideoOptions{
+					SortBy:  VideoSortDeletedAt,
+					Deleted: true,
+					OwnerID: "another owner",
+				})
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(page.Videos).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("Restore", func() {
+		var (
+			video   *Video
+			id      primitive.ObjectID
+			ownerID string
+
+			resp *Video
+			err  error
+		)
+
+		BeforeEach(func() {
+			video = NewFakeVideo()
+			video.OwnerID = "owner"
+			id = video.ID
+			ownerID = video.OwnerID
+
+			insertVideo(ctx, videoDAO, video)
+		})
+
+		AfterEach(func() {
+			deleteVideo(ctx, videoDAO, video.ID)
+		})
+
+		JustBeforeEach(func() {
+			resp, err = videoDAO.Restore(ctx, id, ownerID)
+		})
+
+		When("video is not in the trash", func() {
+			It("returns video not found error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrVideoNotFound))
+			})
+		})
+
+		When("video is of another owner", func() {
+			BeforeEach(func() {
+				Expect(videoDAO.SoftDelete(ctx, id)).To(Succeed())
+				ownerID = "another owner"
+			})
+
+			It("returns video not found error and keeps the video in the trash", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrVideoNotFound))
+				Expect(findVideo(ctx, videoDAO, id).DeletedAt).NotTo(BeZero())
+			})
+		})
+
+		When("video is being purged", func() {
+			BeforeEach(func() {
+				Expect(videoDAO.SoftDelete(ctx, id)).To(Succeed())
+				Expect(videoDAO.StartPurge(ctx, id, time.Now().UTC().Add(time.Minute))).To(Succeed())
+			})
+
+			It("returns video not found error and keeps the video in the trash", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrVideoNotFound))
+				Expect(findVideo(ctx, videoDAO, id).DeletedAt).NotTo(BeZero())
+			})
+		})
+
+		When("success", func() {
+			BeforeEach(func() {
+				Expect(videoDAO.SoftDelete(ctx, id)).To(Succeed())
+			})
+
+			It("moves the video out of the trash", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.ID).To(Equal(id))
+				Expect(resp.DeletedAt).To(BeZero())
+
+				restored, getErr := videoDAO.Get(ctx, id)
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(restored).To(Equal(resp))
+			})
+		})
+
+		When("video is encoded while in the trash", func() {
+			BeforeEach(func() {
+				Expect(videoDAO.collection.UpdateByID(ctx, id, bson.M{
+					"$set":   bson.M{"status": VideoStatusUploaded},
+					"$unset": bson.M{"variants": "", "playlists": ""},
+				})).NotTo(BeNil())
+				Expect(videoDAO.StartEncoding(ctx, id, []string{"720"})).To(Succeed())
+				Expect(videoDAO.SoftDelete(ctx, id)).To(Succeed())
+
+				// the stream worker finishes the variant of the video in the trash
+				trashed, err := videoDAO.GetIncludingDeleted(ctx, id)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(trashed.Status).To(Equal(VideoStatusEncoding))
+
+				_, err = videoDAO.UpdateVariant(ctx, id, "720", id.Hex()+"-720.mp4", &Playlist{ObjectName: id.Hex() + "-hls/720/index.m3u8"})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("restores the encoded video", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.Status).To(Equal(VideoStatusSuccess))
+				Expect(resp.Playlists).To(HaveKey("720"))
+			})
+		})
+	})
+
+	Describe("StartPurge", func() {
+		var (
+			video         *Video
+			deletedBefore time.Time
+
+			err error
+		)
+
+		BeforeEach(func() {
+			video = NewFakeVideo()
+			video.DeletedAt = time.Now().UTC().Add(-time.Hour).Truncate(time.Millisecond)
+			deletedBefore = time.Now().UTC().Add(-time.Minute)
+
+			insertVideo(ctx, videoDAO, video)
+		})
+
+		AfterEach(func() {
+			deleteVideo(ctx, videoDAO, video.ID)
+		})
+
+		JustBeforeEach(func() {
+			err = videoDAO.StartPurge(ctx, video.ID, deletedBefore)
+		})
+
+		When("video is deleted after the time", func() {
+			BeforeEach(func() { deletedBefore = video.DeletedAt.Add(-time.Minute) })
+
+			It("returns video not found error and keeps the video restorable", func() {
+				Expect(err).To(MatchError(ErrVideoNotFound))
+				Expect(findVideo(ctx, videoDAO, video.ID).PurgingAt).To(BeZero())
+			})
+		})
+
+		When("video is already purging", func() {
+			var purgingAt time.Time
+
+			BeforeEach(func() {
+				Expect(videoDAO.StartPurge(ctx, video.ID, deletedBefore)).To(Succeed())
+				purgingAt = findVideo(ctx, videoDAO, video.ID).PurgingAt
+			})
+
+			It("returns no error and keeps the time of the first attempt", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(findVideo(ctx, videoDAO, video.ID).PurgingAt).To(Equal(purgingAt))
+			})
+		})
+
+		When("success", func() {
+			It("marks the video as purging", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(findVideo(ctx, videoDAO, video.ID).PurgingAt).NotTo(BeZero())
+			})
+		})
+	})
+
+	Describe("Purge", func() {
+		var (
+			video         *Video
+			deletedBefore time.Time
+
+			err error
+		)
+
+		BeforeEach(func() {
+			video = NewFakeVideo()
+			video.DeletedAt = time.Now().UTC().Add(-time.Hour).Truncate(time.Millisecond)
+			video.PurgingAt = time.Now().UTC().Add(-time.Minute).Truncate(time.Millisecond)
+			deletedBefore = time.Now().UTC().Add(-time.Minute)
+
+			insertVideo(ctx, videoDAO, video)
+		})
+
+		JustBeforeEach(func() {
+			err = videoDAO.Purge(ctx, video.ID, deletedBefore)
+		})
+
+		When("video is deleted after the time", func() {
+			BeforeEach(func() { deletedBefore = video.DeletedAt.Add(-time.Minute) })
+
+			AfterEach(func() {
+				deleteVideo(ctx, videoDAO, video.ID)
+			})
+
+			It("returns video not found error and keeps the video", func() {
+				Expect(err).To(MatchError(ErrVideoNotFound))
+				Expect(findVideo(ctx, videoDAO, video.ID)).NotTo(BeNil())
+			})
+		})
+
+		When("video is not purging", func() {
+			BeforeEach(func() {
+				Expect(videoDAO.collection.UpdateOne(ctx, bson.M{"_id": video.ID}, bson.M{"$unset": bson.M{"purging_at": ""}})).
+					To(Equal(&mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}))
+			})
+
+			AfterEach(func() {
+				deleteVideo(ctx, videoDAO, video.ID)
+			})
+
+			It("returns video not found error and keeps the video", func() {
+				Expect(err).To(MatchError(ErrVideoNotFound))
+				Expect(findVideo(ctx, videoDAO, video.ID)).NotTo(BeNil())
+			})
+		})
+
+		When("success", func() {
+			It("deletes the document", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(videoDAO.collection.FindOne(ctx, bson.M{"_id": video.ID}).Err()).To(Equal(mongo.ErrNoDocuments))
+			})
+		})
+	})
+
+	Describe("Publish", func() {
+		var (
+			video         *Video
+			publishBefore time.Time
+
+			resp *Video
+			err  error
+		)
+
+		BeforeEach(func() {
+			video = NewFakeVideo()
+			video.PublishStatus = VideoPublishStatusScheduled
+			video.PublishAt = time.Now().UTC().Add(-time.Minute).Truncate(time.Millisecond)
+			publishBefore = time.Now().UTC()
+
+			insertVideo(ctx, videoDAO, video)
+		})
+
+		AfterEach(func() {
+			deleteVideo(ctx, videoDAO, video.ID)
+		})
+
+		JustBeforeEach(func() {
+			resp, err = videoDAO.Publish(ctx, video.ID, publishBefore)
+		})
+
+		When("video is rescheduled after the time", func() {
+			BeforeEach(func() { publishBefore = video.PublishAt.Add(-time.Minute) })
+
+			It("returns video not found error and keeps the video scheduled", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrVideoNotFound))
+				Expect(findVideo(ctx, videoDAO, video.ID).PublishStatus).To(Equal(VideoPublishStatusScheduled))
+			})
+		})
+
+		When("video is in the trash", func() {
+			BeforeEach(func() {
+				Expect(videoDAO.collection.UpdateByID(ctx, video.ID, bson.M{"$set": bson.M{
+					"deleted_at": time.Now().UTC(),
+				}})).NotTo(BeNil())
+			})
+
+			It("returns video not found error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrVideoNotFound))
+			})
+		})
+
+		When("success", func() {
+			It("publishes the video and keeps the publish time", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.PublishStatus).To(Equal(VideoPublishStatusPublished))
+				Expect(resp.PublishAt).To(Equal(video.PublishAt))
+				Expect(resp.UpdatedAt).To(BeTemporally("~", time.Now(), time.Second))
+				Expect(findVideo(ctx, videoDAO, video.ID)).To(Equal(resp))
+			})
+		})
+	})
+
+	Describe("Delete", func() {
+		var (
+			video *Video
+			id    primitive.ObjectID
+
+			err error
+		)
+
+		BeforeEach(func() {
+			video = NewFakeVideo()
+			id = video.ID
+
+			insertVideo(ctx, videoDAO, video)
+		})
+
+		JustBeforeEach(func() {
+			err = videoDAO.Delete(ctx, video.ID)
+		})
+
+		When("video not found", func() {
+			BeforeEach(func() { video.ID = primitive.NewObjectID() })
+
+			AfterEach(func() {
+				deleteVideo(ctx, videoDAO, id)
+			})
+
+			It("returns video not found error", func() {
+
+				Expect(err).To(MatchError(ErrVideoNotFound))
+			})
+		})
+
+		When("success", func() {
+			It("returns no error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("deletes the document", func() {
+				var getVideo Video
+
+				Expect(
+					videoDAO.collection.FindOne(ctx, bson.M{"_id": video.ID}).Decode(&getVideo),
+				).To(Equal(mongo.ErrNoDocuments))
+			})
+		})
+	})
+})
+
+// useful methods for testing
 
 func insertVideo(ctx context.Context, videoDAO *mongoVideoDAO, video *Video) {
 	Expect(videoDAO.collection.InsertOne(ctx, video)).
@@ -326,3 +1528,16 @@ func deleteVideo(ctx context.Context, videoDAO *mongoVideoDAO, id primitive.Obje
 	Expect(videoDAO.collection.DeleteOne(ctx, bson.M{"_id": id})).
 		To(Equal(&mongo.DeleteResult{DeletedCount: 1}))
 }
+
+func findVideo(ctx context.Context, videoDAO *mongoVideoDAO, id primitive.ObjectID) *Video {
+	var video Video
+
+	Expect(videoDAO.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&video)).To(Succeed())
+
+	return &video
+}
+
+func updateVideoStatus(ctx context.Context, videoDAO *mongoVideoDAO, id primitive.ObjectID, status VideoStatus) {
+	Expect(videoDAO.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"status": status}})).
+		To(Equal(&mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}))
+}
@@ -2,16 +2,35 @@ package dao
 
 import (
 	"context"
+	"errors"
 	"time"
 
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/logkit"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/otelkit"
 	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/rediskit"
 	"github.com/go-redis/cache/v8"
+	"github.com/go-redis/redis/v8"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
 )
 
+type RedisVideoDAOConfig struct {
+	NegativeTTL          time.Duration `long:"negative_ttl" env:"NEGATIVE_TTL" description:"the duration to cache that a video is not found, which is at least a second, zero disables the negative caching" default:"10s"`
+	TTLJitter            float64       `long:"ttl_jitter" env:"TTL_JITTER" description:"the ratio of the TTLs to extend the cached entries by at random, so the entries cached together do not expire together" default:"0.1"`
+	StaleWhileRevalidate time.Duration `long:"stale_while_revalidate" env:"STALE_WHILE_REVALIDATE" description:"the duration to serve an expired video while it is revalidated in the background, zero disables the stale serving" default:"0"`
+}
+
 type redisVideoDAO struct {
+	client  *rediskit.RedisClient
 	cache   *cache.Cache
 	baseDAO VideoDAO
+	meter   *otelkit.CacheMeter
+
+	negativeTTL          time.Duration
+	ttlJitter            float64
+	staleWhileRevalidate time.Duration
+	revalidating         singleflight.Group
 }
 
 var _ VideoDAO = (*redisVideoDAO)(nil)
@@ -20,66 +39,433 @@ const (
 	videoDAOLocalCacheSize     = 1024
 	videoDAOLocalCacheDuration = 1 * time.Minute
 	videoDAORedisCacheDuration = 3 * time.Minute
+	// the search results are cached shortly since the queries are diverse and rarely repeated for long
+	videoDAOSearchCacheDuration = 30 * time.Second
+	// the revalidation outlives the read which serves the stale video, so it is bounded by its own timeout
+	videoDAORevalidateTimeout = 10 * time.Second
+	// the invalidation outlives the write as well, so a canceled request does not leave the cache stale
+	videoDAOInvalidateTimeout = 5 * time.Second
+
+	// videoListGenerationKey is increased on every write which changes what the lists contain, the cached
+	// pages of the previous generations are never read again and expire by their TTL
+	videoListGenerationKey = "listVideo:generation"
 )
 
-func NewRedisVideoDAO(client *rediskit.RedisClient, baseDAO VideoDAO) *redisVideoDAO {
+func NewRedisVideoDAO(client *rediskit.RedisClient, baseDAO VideoDAO, meter *otelkit.CacheMeter, conf *RedisVideoDAOConfig) *redisVideoDAO {
 	return &redisVideoDAO{
+		client: client,
 		cache: cache.New(&cache.Options{
 			Redis:      client,
-			LocalCache: cache.NewTinyLFU(videoDAOLocalCacheSize, videoDAOLocalCacheDuration),
+			LocalCache: client.InvalidationBus().NewLocalCache(videoDAOLocalCacheSize, videoDAOLocalCacheDuration),
 		}),
-		baseDAO: baseDAO,
+		baseDAO:              baseDAO,
+		meter:                meter,
+		negativeTTL:          conf.NegativeTTL,
+		ttlJitter:            conf.TTLJitter,
+		staleWhileRevalidate: conf.StaleWhileRevalidate,
 	}
 }
 
+// cachedVideo is the cached entry of a video, the entry without a video caches that the video is not found
+type cachedVideo struct {
+	Video *Video
+	// ExpiresAt is when the entry turns stale, a stale entry is kept in Redis for the stale-while-revalidate duration
+	ExpiresAt time.Time
+}
+
+func (entry *cachedVideo) video() (*Video, error) {
+	if entry.Video == nil {
+		return nil, ErrVideoNotFound
+	}
+
+	return entry.Video, nil
+}
+
+// Get serves the fresh video from the cache, and the stale video while it is revalidated in the background.
+// Otherwise the video is read from baseDAO once among the concurrent misses, and baseDAO is read directly
+// when Redis is unavailable.
 func (dao *redisVideoDAO) Get(ctx context.Context, id primitive.ObjectID) (*Video, error) {
-	var video Video
+	key := getVideoKey(id)
+
+	var entry cachedVideo
+
+	err := dao.cache.Get(ctx, key, &entry)
+	switch {
+	case err == nil:
+		now := time.Now()
+
+		if now.Before(entry.ExpiresAt) {
+			dao.meter.Hit(ctx)
+			return entry.video()
+		}
+
+		if now.Before(entry.ExpiresAt.Add(dao.staleWhileRevalidate)) {
+			dao.meter.Hit(ctx)
+			dao.revalidate(ctx, id)
+			return entry.video()
+		}
+
+		// the expired entry may be kept by the local cache, or by Redis for a moment
+		if err := dao.cache.Delete(ctx, key); err != nil && !errors.Is(err, cache.ErrCacheMiss) {
+			logkit.FromContext(ctx).Error("failed to evict the expired video", zap.String("id", id.Hex()), zap.Error(err))
+		}
+	case errors.Is(err, cache.ErrCacheMiss):
+	default:
+		logkit.FromContext(ctx).Error("failed to get the cached video", zap.String("id", id.Hex()), zap.Error(err))
+		dao.meter.Fallback(ctx)
+
+		return dao.baseDAO.Get(ctx, id)
+	}
+
+	dao.meter.Miss(ctx)
 
 	if err := dao.cache.Once(&cache.Item{
-		Key:   getVideoKey(id),
-		Value: &video,
-		TTL:   videoDAORedisCacheDuration,
-		Do: func(*cache.Item) (interface{}, error) {
-			return dao.baseDAO.Get(ctx, id)
+		Ctx:   ctx,
+		Key:   key,
+		Value: &entry,
+		Do: func(item *cache.Item) (interface{}, error) {
+			fetched, ttl, err := dao.fetch(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+
+			item.TTL = ttl
+			return fetched, nil
 		},
 	}); err != nil {
 		return nil, err
 	}
 
-	return &video, nil
+	return entry.video()
+}
+
+// GetIncludingDeleted is not cached since the trash is only read by the workers
+func (dao *redisVideoDAO) GetIncludingDeleted(ctx context.Context, id primitive.ObjectID) (*Video, error) {
+	return dao.baseDAO.GetIncludingDeleted(ctx, id)
 }
 
-func (dao *redisVideoDAO) List(ctx context.Context, limit, skip int64) ([]*Video, error) {
-	var videos []*Video
+// List caches the pages by the query, the page token keeps a cached page stable
+// while videos are inserted, unlike the offset which shifts the pages.
+func (dao *redisVideoDAO) List(ctx context.Context, opts *ListVideoOptions) (*VideoPage, error) {
+	generation, ok := dao.listGeneration(ctx)
+	if !ok {
+		return dao.baseDAO.List(ctx, opts)
+	}
+
+	var page VideoPage
 
-	if err := dao.cache.Once(&cache.Item{
-		Key:   listVideoKey(limit, skip),
-		Value: &videos,
-		TTL:   videoDAORedisCacheDuration,
+	ok, err := dao.getPage(ctx, &cache.Item{
+		Key:   listVideoKey(generation, opts),
+		Value: &page,
+		TTL:   rediskit.JitterTTL(videoDAORedisCacheDuration, dao.ttlJitter),
 		Do: func(*cache.Item) (interface{}, error) {
-			return dao.baseDAO.List(ctx, limit, skip)
+			return dao.baseDAO.List(ctx, opts)
 		},
-	}); err != nil {
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return dao.baseDAO.List(ctx, opts)
+	}
+
+	return &page, nil
+}
+
+// Search caches the pages by the query in Redis only, the local cache would keep
+// the results for a minute which outlives the short TTL of the search.
+func (dao *redisVideoDAO) Search(ctx context.Context, opts *SearchVideoOptions) (*VideoSearchPage, error) {
+	generation, ok := dao.listGeneration(ctx)
+	if !ok {
+		return dao.baseDAO.Search(ctx, opts)
+	}
+
+	var page VideoSearchPage
+
+	ok, err := dao.getPage(ctx, &cache.Item{
+		Key:            searchVideoKey(generation, opts),
+		Value:          &page,
+		TTL:            rediskit.JitterTTL(videoDAOSearchCacheDuration, dao.ttlJitter),
+		SkipLocalCache: true,
+		Do: func(*cache.Item) (interface{}, error) {
+			return dao.baseDAO.Search(ctx, opts)
+		},
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	return videos, nil
+	if !ok {
+		return dao.baseDAO.Search(ctx, opts)
+	}
+
+	return &page, nil
 }
 
-// The following operations are not cachable, just pass down to baseDAO.
+// The following operations write to baseDAO and then invalidate the cache. A write
+// that fails may still have been applied, so the cache is invalidated regardless.
+// The cached lists are only invalidated by the writes which change which videos are listed,
+// their order or what the lists show of them.
 
 func (dao *redisVideoDAO) Create(ctx context.Context, video *Video) error {
+	defer dao.invalidateLists(ctx)
+
 	return dao.baseDAO.Create(ctx, video)
 }
 
 func (dao *redisVideoDAO) Update(ctx context.Context, video *Video) error {
+	defer dao.invalidate(ctx, video.ID)
+
 	return dao.baseDAO.Update(ctx, video)
 }
 
-func (dao *redisVideoDAO) UpdateVariant(ctx context.Context, id primitive.ObjectID, variant string, url string) error {
-	return dao.baseDAO.UpdateVariant(ctx, id, variant, url)
+func (dao *redisVideoDAO) StartEncoding(ctx context.Context, id primitive.ObjectID, variants []string) error {
+	defer dao.invalidate(ctx, id)
+
+	return dao.baseDAO.StartEncoding(ctx, id, variants)
+}
+
+func (dao *redisVideoDAO) UpdateVariant(ctx context.Context, id primitive.ObjectID, variant string, objectName string, playlist *Playlist) (*Video, error) {
+	video, err := dao.baseDAO.UpdateVariant(ctx, id, variant, objectName, playlist)
+
+	// the lists show the video as encoding until the last variant, which changes the status
+	if err == nil && video.Status == VideoStatusEncoding {
+		dao.evict(ctx, id)
+	} else {
+		dao.invalidate(ctx, id)
+	}
+
+	return video, err
+}
+
+func (dao *redisVideoDAO) UpdateManifest(ctx context.Context, id primitive.ObjectID, objectName string) error {
+	defer dao.invalidate(ctx, id)
+
+	return dao.baseDAO.UpdateManifest(ctx, id, objectName)
+}
+
+func (dao *redisVideoDAO) UpdateThumbnail(ctx context.Context, id primitive.ObjectID, thumbnail *Thumbnail) error {
+	defer dao.invalidate(ctx, id)
+
+	return dao.baseDAO.UpdateThumbnail(ctx, id, thumbnail)
+}
+
+func (dao *redisVideoDAO) UpdateMetadata(ctx context.Context, id primitive.ObjectID, metadata *VideoMetadata, fields []string, updatedAt time.Time) (*Video, error) {
+	defer dao.invalidate(ctx, id)
+
+	return dao.baseDAO.UpdateMetadata(ctx, id, metadata, fields, updatedAt)
+}
+
+func (dao *redisVideoDAO) UpdateStatus(ctx context.Context, id primitive.ObjectID, from, to VideoStatus) error {
+	defer dao.invalidate(ctx, id)
+
+	return dao.baseDAO.UpdateStatus(ctx, id, from, to)
+}
+
+func (dao *redisVideoDAO) SoftDelete(ctx context.Context, id primitive.ObjectID) error {
+	defer dao.invalidate(ctx, id)
+
+	return dao.baseDAO.SoftDelete(ctx, id)
+}
+
+func (dao *redisVideoDAO) Restore(ctx context.Context, id primitive.ObjectID, ownerID string) (*Video, error) {
+	defer dao.invalidate(ctx, id)
+
+	return dao.baseDAO.Restore(ctx, id, ownerID)
+}
+
+func (dao *redisVideoDAO) StartPurge(ctx context.Context, id primitive.ObjectID, deletedBefore time.Time) error {
+	defer dao.invalidate(ctx, id)
+
+	return dao.baseDAO.StartPurge(ctx, id, deletedBefore)
+}
+
+func (dao *redisVideoDAO) Purge(ctx context.Context, id primitive.ObjectID, deletedBefore time.Time) error {
+	defer dao.invalidate(ctx, id)
+
+	return dao.baseDAO.Purge(ctx, id, deletedBefore)
+}
+
+func (dao *redisVideoDAO) Publish(ctx context.Context, id primitive.ObjectID, publishBefore time.Time) (*Video, error) {
+	defer dao.invalidate(ctx, id)
+
+	return dao.baseDAO.Publish(ctx, id, publishBefore)
 }
 
 func (dao *redisVideoDAO) Delete(ctx context.Context, id primitive.ObjectID) error {
+	defer dao.invalidate(ctx, id)
+
 	return dao.baseDAO.Delete(ctx, id)
 }
+
+// fetch reads the video from baseDAO into a cached entry, and returns the TTL of the entry in Redis.
+// A video not found is cached for the negative TTL, unless the negative caching is disabled.
+func (dao *redisVideoDAO) fetch(ctx context.Context, id primitive.ObjectID) (*cachedVideo, time.Duration, error) {
+	video, err := dao.baseDAO.Get(ctx, id)
+	if err != nil {
+		if !errors.Is(err, ErrVideoNotFound) || dao.negativeTTL <= 0 {
+			return nil, 0, err
+		}
+
+		entry, ttl := dao.newCachedVideo(nil, dao.negativeTTL)
+		return entry, ttl, nil
+	}
+
+	entry, ttl := dao.newCachedVideo(video, videoDAORedisCacheDuration)
+	return entry, ttl, nil
+}
+
+func (dao *redisVideoDAO) newCachedVideo(video *Video, ttl time.Duration) (*cachedVideo, time.Duration) {
+	ttl = rediskit.JitterTTL(ttl, dao.ttlJitter)
+
+	return &cachedVideo{
+		Video:     video,
+		ExpiresAt: time.Now().Add(ttl),
+	}, ttl + dao.staleWhileRevalidate
+}
+
+// revalidate refreshes the stale video in the background, once among the concurrent reads of the replica
+func (dao *redisVideoDAO) revalidate(ctx context.Context, id primitive.ObjectID) {
+	logger := logkit.FromContext(ctx).With(zap.String("id", id.Hex()))
+	key := getVideoKey(id)
+
+	dao.revalidating.DoChan(key, func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(logger.WithContext(context.Background()), videoDAORevalidateTimeout)
+		defer cancel()
+
+		entry, ttl, err := dao.fetch(ctx, id)
+		if err != nil {
+			if errors.Is(err, ErrVideoNotFound) {
+				// the negative caching is disabled, so the video is evicted instead
+				if err := dao.cache.Delete(ctx, key); err != nil && !errors.Is(err, cache.ErrCacheMiss) {
+					logger.Error("failed to evict the stale video", zap.Error(err))
+				}
+			} else {
+				logger.Error("failed to revalidate the stale video", zap.Error(err))
+			}
+
+			return nil, nil
+		}
+
+		// the local cache does not overwrite the stale entry once it is read, so the entry is dropped first
+		dao.cache.DeleteFromLocalCache(key)
+
+		if err := dao.cache.Set(&cache.Item{
+			Ctx:   ctx,
+			Key:   key,
+			Value: entry,
+			TTL:   ttl,
+		}); err != nil {
+			logger.Error("failed to cache the revalidated video", zap.Error(err))
+		}
+
+		return nil, nil
+	})
+}
+
+// listGeneration returns the current generation of the cached lists, which is zero before the first write.
+// It returns false when Redis is unavailable, so the caller reads baseDAO directly.
+func (dao *redisVideoDAO) listGeneration(ctx context.Context) (int64, bool) {
+	generation, err := dao.client.Get(ctx, videoListGenerationKey).Int64()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		logkit.FromContext(ctx).Error("failed to get the generation of the cached lists", zap.Error(err))
+		dao.meter.Fallback(ctx)
+
+		return 0, false
+	}
+
+	return generation, true
+}
+
+// getPage reads the cached page of the item into item.Value, the page is read by item.Do once among
+// the concurrent misses. It returns false when Redis is unavailable, so the caller reads baseDAO directly.
+func (dao *redisVideoDAO) getPage(ctx context.Context, item *cache.Item) (bool, error) {
+	get := dao.cache.Get
+	if item.SkipLocalCache {
+		get = dao.cache.GetSkippingLocalCache
+	}
+
+	err := get(ctx, item.Key, item.Value)
+	switch {
+	case err == nil:
+		dao.meter.Hit(ctx)
+		return true, nil
+	case errors.Is(err, cache.ErrCacheMiss):
+	default:
+		logkit.FromContext(ctx).Error("failed to get the cached page", zap.String("key", item.Key), zap.Error(err))
+		dao.meter.Fallback(ctx)
+
+		return false, nil
+	}
+
+	dao.meter.Miss(ctx)
+
+	item.Ctx = ctx
+	return true, dao.cache.Once(item)
+}
+
+// invalidate evicts the cached video and invalidates the cached lists, which may contain the video
+func (dao *redisVideoDAO) invalidate(ctx context.Context, id primitive.ObjectID) {
+	dao.evict(ctx, id)
+	dao.invalidateLists(ctx)
+}
+
+// evict evicts the cached video. The video is evicted after the write, so a read racing with the write
+// may cache the previous video again until the TTL expires, the lists are not affected since they are versioned.
+// The eviction is broadcast to the local caches of the other replicas as well.
+func (dao *redisVideoDAO) evict(ctx context.Context, id primitive.ObjectID) {
+	ctx, cancel := invalidationContext(ctx)
+	defer cancel()
+
+	if err := dao.cache.Delete(ctx, getVideoKey(id)); err != nil && !errors.Is(err, cache.ErrCacheMiss) {
+		logkit.FromContext(ctx).Error("failed to evict the cached video", zap.String("id", id.Hex()), zap.Error(err))
+	}
+
+	if err := dao.client.InvalidationBus().Publish(ctx, getVideoKey(id)); err != nil {
+		logkit.FromContext(ctx).Error("failed to broadcast the eviction of the cached video", zap.String("id", id.Hex()), zap.Error(err))
+	}
+}
+
+// invalidateLists moves the lists to the next generation, a failure is logged instead of failing
+// the write which has been applied, and the stale pages expire by their TTL.
+func (dao *redisVideoDAO) invalidateLists(ctx context.Context) {
+	ctx, cancel := invalidationContext(ctx)
+	defer cancel()
+
+	if err := dao.client.Incr(ctx, videoListGenerationKey).Err(); err != nil {
+		logkit.FromContext(ctx).Error("failed to invalidate the cached lists", zap.Error(err))
+	}
+}
+
+// invalidationContext detaches the invalidation from the cancellation of the write, the write has been applied
+// even if the caller has gone away, and bounds it by its own timeout instead
+func invalidationContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(logkit.FromContext(ctx).WithContext(context.Background()), videoDAOInvalidateTimeout)
+}
+
+// uncachedVideoDAO reads baseDAO directly and invalidates the cache of the Redis DAO on writes,
+// the workers need the latest videos while their writes must not leave the cache of the API stale.
+type uncachedVideoDAO struct {
+	*redisVideoDAO
+}
+
+var _ VideoDAO = (*uncachedVideoDAO)(nil)
+
+func NewUncachedVideoDAO(client *rediskit.RedisClient, baseDAO VideoDAO) *uncachedVideoDAO {
+	return &uncachedVideoDAO{
+		redisVideoDAO: NewRedisVideoDAO(client, baseDAO, otelkit.NewNopCacheMeter(), &RedisVideoDAOConfig{}),
+	}
+}
+
+func (dao *uncachedVideoDAO) Get(ctx context.Context, id primitive.ObjectID) (*Video, error) {
+	return dao.baseDAO.Get(ctx, id)
+}
+
+func (dao *uncachedVideoDAO) List(ctx context.Context, opts *ListVideoOptions) (*VideoPage, error) {
+	return dao.baseDAO.List(ctx, opts)
+}
+
+func (dao *uncachedVideoDAO) Search(ctx context.Context, opts *SearchVideoOptions) (*VideoSearchPage, error) {
+	return dao.baseDAO.Search(ctx, opts)
+}
@@ -2,12 +2,17 @@ package dao
 
 import (
 	"context"
+	"time"
 
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/logkit"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/otelkit"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/rediskit"
 	"github.com/go-redis/cache/v8"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
 	"github.com/onsi/gomega/types"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -17,9 +22,12 @@ var _ = Describe("VideoRedisDAO", func() {
 	var ctx context.Context
 
 	BeforeEach(func() {
-		ctx = context.Background()
+		ctx = logkit.WithContext(context.Background(), logkit.NewNopLogger())
 		mongoVideoDAO = NewMongoVideoDAO(mongoClient.Database().Collection("videos"))
-		redisVideoDAO = NewRedisVideoDAO(redisClient, mongoVideoDAO)
+		redisVideoDAO = NewRedisVideoDAO(redisClient, mongoVideoDAO, otelkit.NewNopCacheMeter(), &RedisVideoDAOConfig{
+			NegativeTTL: time.Minute,
+			TTLJitter:   0.1,
+		})
 	})
 
 	Describe("Get", func() {
@@ -72,10 +80,22 @@ var _ = Describe("VideoRedisDAO", func() {
 			When("video not found", func() {
 				BeforeEach(func() { id = primitive.NewObjectID() })
 
+				AfterEach(func() {
+					deleteVideoInRedis(ctx, redisVideoDAO, getVideoKey(id))
+				})
+
 				It("returns video not found error", func() {
 					Expect(resp).To(BeNil())
 					Expect(err).To(MatchError(ErrVideoNotFound))
 				})
+
+				It("caches that the video is not found for the negative TTL", func() {
+					var entry cachedVideo
+
+					Expect(redisVideoDAO.cache.Get(ctx, getVideoKey(id), &entry)).NotTo(HaveOccurred())
+					Expect(entry.Video).To(BeNil())
+					Expect(entry.ExpiresAt).To(BeTemporally("<=", time.Now().Add(time.Minute+6*time.Second)))
+				})
 			})
 
 			When("success", func() {
@@ -87,12 +107,76 @@ var _ = Describe("VideoRedisDAO", func() {
 				})
 
 				It("insert the video to cache", func() {
-					var getVideo Video
+					var entry cachedVideo
 
 					Expect(
-						redisVideoDAO.cache.Get(ctx, getVideoKey(id), &getVideo),
+						redisVideoDAO.cache.Get(ctx, getVideoKey(id), &entry),
 					).NotTo(HaveOccurred())
+					Expect(entry.Video).To(matchVideo(video))
+					Expect(entry.ExpiresAt).To(BeTemporally(">", time.Now().Add(videoDAORedisCacheDuration-time.Second)))
+				})
+			})
+		})
+
+		Context("negative cache hit", func() {
+			BeforeEach(func() {
+				insertCachedVideoInRedis(ctx, redisVideoDAO, video.ID, &cachedVideo{
+					ExpiresAt: time.Now().Add(time.Minute),
+				})
+				// the video is created after the negative entry, which is not invalidated by a write
+				insertVideo(ctx, mongoVideoDAO, video)
+			})
+
+			AfterEach(func() {
+				deleteVideo(ctx, mongoVideoDAO, video.ID)
+				deleteVideoInRedis(ctx, redisVideoDAO, getVideoKey(video.ID))
+			})
+
+			It("returns video not found error without reading the database", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrVideoNotFound))
+			})
+		})
+
+		Context("cache expired", func() {
+			var stale *Video
+
+			BeforeEach(func() {
+				stale = NewFakeVideo()
+				stale.ID = video.ID
+				stale.Variants = map[string]string{"480p": "stale.mp4"}
+
+				insertCachedVideoInRedis(ctx, redisVideoDAO, video.ID, &cachedVideo{
+					Video:     stale,
+					ExpiresAt: time.Now().Add(-time.Second),
+				})
+				insertVideo(ctx, mongoVideoDAO, video)
+			})
+
+			AfterEach(func() {
+				deleteVideo(ctx, mongoVideoDAO, video.ID)
+				deleteVideoInRedis(ctx, redisVideoDAO, getVideoKey(video.ID))
+			})
+
+			When("stale-while-revalidate is disabled", func() {
+				It("returns the video read from the database", func() {
 					Expect(resp).To(matchVideo(video))
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+
+			When("stale-while-revalidate is enabled", func() {
+				BeforeEach(func() {
+					redisVideoDAO.staleWhileRevalidate = time.Minute
+				})
+
+				It("returns the stale video and revalidates it in the background", func() {
+					Expect(resp).To(matchVideo(stale))
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(func() (*Video, error) {
+						return redisVideoDAO.Get(ctx, id)
+					}).Should(matchVideo(video))
 				})
 			})
 		})
@@ -101,36 +185,37 @@ var _ = Describe("VideoRedisDAO", func() {
 	Describe("List", func() {
 		var (
 			videos []*Video
-			limit  int64
-			skip   int64
+			opts   *ListVideoOptions
 
-			resp []*Video
+			resp *VideoPage
 			err  error
 		)
 
 		BeforeEach(func() {
 			videos = []*Video{NewFakeVideo(), NewFakeVideo(), NewFakeVideo()}
+			opts = &ListVideoOptions{Limit: 3}
 		})
 
 		JustBeforeEach(func() {
-			resp, err = redisVideoDAO.List(ctx, limit, skip)
+			resp, err = redisVideoDAO.List(ctx, opts)
 		})
 
 		Context("cache hit", func() {
 			BeforeEach(func() {
-				limit, skip = 3, 0
-				insertVideosInRedis(ctx, redisVideoDAO, videos, limit, skip)
+				insertVideosInRedis(ctx, redisVideoDAO, &VideoPage{Videos: videos, NextPageToken: "next"}, opts)
 			})
 
 			AfterEach(func() {
-				deleteVideosInRedis(ctx, redisVideoDAO, limit, skip)
+				deleteVideosInRedis(ctx, redisVideoDAO, opts)
 			})
 
 			When("success", func() {
 				It("returns the videos with no error", func() {
-					for i := range resp {
-						Expect(resp[i]).To(matchVideo(videos[i]))
+					Expect(resp.Videos).To(HaveLen(len(videos)))
+					for i := range resp.Videos {
+						Expect(resp.Videos[i]).To(matchVideo(videos[i]))
 					}
+					Expect(resp.NextPageToken).To(Equal("next"))
 					Expect(err).NotTo(HaveOccurred())
 				})
 			})
@@ -138,7 +223,6 @@ var _ = Describe("VideoRedisDAO", func() {
 
 		Context("cache miss", func() {
 			BeforeEach(func() {
-				limit, skip = 3, 0
 				for _, video := range videos {
 					insertVideo(ctx, mongoVideoDAO, video)
 				}
@@ -148,43 +232,338 @@ var _ = Describe("VideoRedisDAO", func() {
 				for _, video := range videos {
 					deleteVideo(ctx, mongoVideoDAO, video.ID)
 				}
-				deleteVideoInRedis(ctx, redisVideoDAO, listVideoKey(limit, skip))
+				deleteVideoInRedis(ctx, redisVideoDAO, listVideoKey(listGeneration(ctx, redisVideoDAO), opts))
 			})
 
 			When("videos not found", func() {
-				BeforeEach(func() { limit, skip = 4, 4 })
+				BeforeEach(func() { opts.Skip = 4 })
 
 				It("returns empty list with no error", func() {
-					Expect(resp).To(HaveLen(0))
+					Expect(resp.Videos).To(HaveLen(0))
 					Expect(err).NotTo(HaveOccurred())
 				})
 			})
 
 			When("success", func() {
 				It("returns the videos with no error", func() {
-					for i := range resp {
-						Expect(resp[i]).To(matchVideo(videos[i]))
-					}
+					Expect(resp.Videos).To(HaveLen(len(videos)))
 					Expect(err).NotTo(HaveOccurred())
 				})
 
 				It("insert the videos to cache", func() {
-					var getVideos []*Video
-					Expect(redisVideoDAO.cache.Get(ctx, listVideoKey(limit, skip), &getVideos)).NotTo(HaveOccurred())
-					for i := range getVideos {
-						Expect(getVideos[i]).To(matchVideo(videos[i]))
+					var page VideoPage
+					Expect(redisVideoDAO.cache.Get(ctx, listVideoKey(listGeneration(ctx, redisVideoDAO), opts), &page)).NotTo(HaveOccurred())
+					Expect(page.Videos).To(HaveLen(len(resp.Videos)))
+					for i := range page.Videos {
+						Expect(page.Videos[i]).To(matchVideo(resp.Videos[i]))
 					}
 				})
 			})
 		})
+
+		Context("generation is unreadable", func() {
+			var restore func()
+
+			BeforeEach(func() {
+				for _, video := range videos {
+					insertVideo(ctx, mongoVideoDAO, video)
+				}
+				restore = corruptListGeneration(ctx)
+			})
+
+			AfterEach(func() {
+				restore()
+				for _, video := range videos {
+					deleteVideo(ctx, mongoVideoDAO, video.ID)
+				}
+			})
+
+			It("returns the videos read from the database", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.Videos).To(HaveLen(len(videos)))
+			})
+		})
+	})
+
+	Describe("invalidation", func() {
+		var (
+			video *Video
+			opts  *ListVideoOptions
+		)
+
+		BeforeEach(func() {
+			video = NewFakeVideo()
+			video.OwnerID = "owner"
+			// the tag lists the video of the test only
+			video.Tags = []string{primitive.NewObjectID().Hex()}
+			opts = &ListVideoOptions{Tags: video.Tags}
+
+			insertVideo(ctx, mongoVideoDAO, video)
+
+			// read through the cache so the stale video and the stale page are cached
+			Expect(redisVideoDAO.Get(ctx, video.ID)).To(matchVideo(video))
+			Expect(redisVideoDAO.List(ctx, opts)).To(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Videos": ConsistOf(matchVideo(video)),
+			})))
+		})
+
+		AfterEach(func() {
+			_, _ = mongoVideoDAO.collection.DeleteMany(ctx, bson.M{"tags": video.Tags[0]})
+			_ = redisVideoDAO.cache.Delete(ctx, getVideoKey(video.ID))
+		})
+
+		When("a video is created", func() {
+			var created *Video
+
+			BeforeEach(func() {
+				created = NewFakeVideo()
+				created.ID = primitive.NilObjectID
+				created.Tags = video.Tags
+				Expect(redisVideoDAO.Create(ctx, created)).To(Succeed())
+			})
+
+			It("lists the new video", func() {
+				page, err := redisVideoDAO.List(ctx, opts)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(page.Videos).To(ConsistOf(matchVideo(video), matchVideo(created)))
+			})
+		})
+
+		When("the metadata is updated", func() {
+			BeforeEach(func() {
+				updated, err := redisVideoDAO.UpdateMetadata(ctx, video.ID, &VideoMetadata{Title: "Sintel"}, []string{VideoFieldTitle}, video.UpdatedAt)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(updated.Title).To(Equal("Sintel"))
+			})
+
+			It("returns the updated video", func() {
+				got, err := redisVideoDAO.Get(ctx, video.ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(got.Title).To(Equal("Sintel"))
+			})
+
+			It("lists the updated video", func() {
+				page, err := redisVideoDAO.List(ctx, opts)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(page.Videos).To(HaveLen(1))
+				Expect(page.Videos[0].Title).To(Equal("Sintel"))
+			})
+		})
+
+		When("a variant is added", func() {
+			BeforeEach(func() {
+				Expect(redisVideoDAO.UpdateVariant(ctx, video.ID, "480p", video.ID.Hex()+"-480p.mp4", &Playlist{
+					ObjectName: video.ID.Hex() + "-hls/480p/index.m3u8",
+				})).NotTo(BeNil())
+			})
+
+			It("returns the video with the new variant", func() {
+				got, err := redisVideoDAO.Get(ctx, video.ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(got.Variants).To(HaveKeyWithValue("480p", video.ID.Hex()+"-480p.mp4"))
+			})
+		})
+
+		When("a variant is added while the other variants are encoding", func() {
+			var generation int64
+
+			BeforeEach(func() {
+				Expect(mongoVideoDAO.collection.UpdateOne(ctx, bson.M{"_id": video.ID}, bson.M{"$set": bson.M{
+					"status":            VideoStatusEncoding,
+					"expected_variants": []string{"480p", "720p"},
+				}})).NotTo(BeNil())
+				generation = listGeneration(ctx, redisVideoDAO)
+
+				Expect(redisVideoDAO.UpdateVariant(ctx, video.ID, "480p", video.ID.Hex()+"-480p.mp4", &Playlist{
+					ObjectName: video.ID.Hex() + "-hls/480p/index.m3u8",
+				})).NotTo(BeNil())
+			})
+
+			It("evicts the video without invalidating the lists", func() {
+				got, err := redisVideoDAO.Get(ctx, video.ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(got.Variants).To(HaveKeyWithValue("480p", video.ID.Hex()+"-480p.mp4"))
+				Expect(listGeneration(ctx, redisVideoDAO)).To(Equal(generation))
+			})
+
+			It("invalidates the lists once the last variant is added", func() {
+				updated, err := redisVideoDAO.UpdateVariant(ctx, video.ID, "720p", video.ID.Hex()+"-720p.mp4", &Playlist{
+					ObjectName: video.ID.Hex() + "-hls/720p/index.m3u8",
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(updated.Status).To(Equal(VideoStatusSuccess))
+				Expect(listGeneration(ctx, redisVideoDAO)).To(BeNumerically(">", generation))
+			})
+		})
+
+		When("the video is moved to the trash", func() {
+			BeforeEach(func() {
+				Expect(redisVideoDAO.SoftDelete(ctx, video.ID)).To(Succeed())
+			})
+
+			It("returns video not found error", func() {
+				got, err := redisVideoDAO.Get(ctx, video.ID)
+				Expect(got).To(BeNil())
+				Expect(err).To(MatchError(ErrVideoNotFound))
+			})
+
+			It("does not list the video", func() {
+				page, err := redisVideoDAO.List(ctx, opts)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(page.Videos).To(BeEmpty())
+			})
+
+			It("returns the video once it is restored", func() {
+				Expect(redisVideoDAO.Restore(ctx, video.ID, video.OwnerID)).NotTo(BeNil())
+
+				Expect(redisVideoDAO.Get(ctx, video.ID)).To(matchVideo(video))
+			})
+		})
+
+		When("the video is updated on another replica", func() {
+			var replicaClient *rediskit.RedisClient
+
+			BeforeEach(func() {
+				replicaClient = rediskit.NewRedisClient(ctx, &rediskit.RedisConfig{
+					Addr: redisClient.Options().Addr,
+				})
+				replicaVideoDAO := NewRedisVideoDAO(replicaClient, mongoVideoDAO, otelkit.NewNopCacheMeter(), &RedisVideoDAOConfig{})
+
+				_, err := replicaVideoDAO.UpdateMetadata(ctx, video.ID, &VideoMetadata{Title: "Sintel"}, []string{VideoFieldTitle}, video.UpdatedAt)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				Expect(replicaClient.Close()).To(Succeed())
+			})
+
+			It("returns the updated video once the local cache is invalidated", func() {
+				Eventually(func() (string, error) {
+					got, err := redisVideoDAO.Get(ctx, video.ID)
+					if err != nil {
+						return "", err
+					}
+					return got.Title, nil
+				}).Should(Equal("Sintel"))
+			})
+		})
+
+		When("the video is deleted", func() {
+			BeforeEach(func() {
+				Expect(redisVideoDAO.Delete(ctx, video.ID)).To(Succeed())
+			})
+
+			It("returns video not found error", func() {
+				got, err := redisVideoDAO.Get(ctx, video.ID)
+				Expect(got).To(BeNil())
+				Expect(err).To(MatchError(ErrVideoNotFound))
+			})
+
+			It("does not list the video", func() {
+				page, err := redisVideoDAO.List(ctx, opts)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(page.Videos).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("Search", func() {
+		var (
+			video *Video
+			opts  *SearchVideoOptions
+
+			resp *VideoSearchPage
+			err  error
+		)
+
+		BeforeEach(func() {
+			video = NewFakeVideo()
+			opts = &SearchVideoOptions{Query: "bunny", Limit: 1}
+		})
+
+		JustBeforeEach(func() {
+			resp, err = redisVideoDAO.Search(ctx, opts)
+		})
+
+		AfterEach(func() {
+			deleteVideoInRedis(ctx, redisVideoDAO, searchVideoKey(listGeneration(ctx, redisVideoDAO), opts))
+		})
+
+		Context("cache hit", func() {
+			BeforeEach(func() {
+				Expect(redisVideoDAO.cache.Set(&cache.Item{
+					Ctx:   ctx,
+					Key:   searchVideoKey(listGeneration(ctx, redisVideoDAO), opts),
+					Value: &VideoSearchPage{Results: []*VideoSearchResult{{Video: video, Score: 1.5}}},
+					TTL:   videoDAOSearchCacheDuration,
+				})).NotTo(HaveOccurred())
+			})
+
+			It("returns the cached page with no error", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.Results).To(HaveLen(1))
+				Expect(resp.Results[0].Video).To(matchVideo(video))
+				Expect(resp.Results[0].Score).To(Equal(1.5))
+			})
+		})
+
+		Context("cache miss", func() {
+			BeforeEach(func() {
+				video.Title = "Big Buck Bunny"
+				insertVideo(ctx, mongoVideoDAO, video)
+			})
+
+			AfterEach(func() {
+				deleteVideo(ctx, mongoVideoDAO, video.ID)
+			})
+
+			It("inserts the page to cache with the short TTL", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.Results).To(HaveLen(1))
+
+				var page VideoSearchPage
+				Expect(redisVideoDAO.cache.Get(ctx, searchVideoKey(listGeneration(ctx, redisVideoDAO), opts), &page)).NotTo(HaveOccurred())
+				Expect(page.Results).To(HaveLen(1))
+				Expect(page.Results[0].Video).To(matchVideo(video))
+				Expect(redisClient.TTL(ctx, searchVideoKey(listGeneration(ctx, redisVideoDAO), opts)).Val()).To(BeNumerically("<=", videoDAOSearchCacheDuration))
+			})
+		})
+
+		Context("generation is unreadable", func() {
+			var restore func()
+
+			BeforeEach(func() {
+				video.Title = "Big Buck Bunny"
+				insertVideo(ctx, mongoVideoDAO, video)
+				restore = corruptListGeneration(ctx)
+			})
+
+			AfterEach(func() {
+				restore()
+				deleteVideo(ctx, mongoVideoDAO, video.ID)
+			})
+
+			It("returns the page read from the database", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.Results).To(HaveLen(1))
+				Expect(resp.Results[0].Video).To(matchVideo(video))
+			})
+		})
 	})
 })
 
 func insertVideoInRedis(ctx context.Context, videoDAO *redisVideoDAO, video *Video) {
+	insertCachedVideoInRedis(ctx, videoDAO, video.ID, &cachedVideo{
+		Video:     video,
+		ExpiresAt: time.Now().Add(videoDAORedisCacheDuration),
+	})
+}
+
+func insertCachedVideoInRedis(ctx context.Context, videoDAO *redisVideoDAO, id primitive.ObjectID, entry *cachedVideo) {
 	Expect(videoDAO.cache.Set(&cache.Item{
 		Ctx:   ctx,
-		Key:   getVideoKey(video.ID),
-		Value: video,
+		Key:   getVideoKey(id),
+		Value: entry,
 		TTL:   videoDAORedisCacheDuration,
 	})).NotTo(HaveOccurred())
 }
@@ -193,28 +572,51 @@ func deleteVideoInRedis(ctx context.Context, videoDAO *redisVideoDAO, key string
 	Expect(videoDAO.cache.Delete(ctx, key)).NotTo(HaveOccurred())
 }
 
-func insertVideosInRedis(ctx context.Context, videoDAO *redisVideoDAO, videos []*Video, limit int64, skip int64) {
+func insertVideosInRedis(ctx context.Context, videoDAO *redisVideoDAO, page *VideoPage, opts *ListVideoOptions) {
 	Expect(videoDAO.cache.Set(&cache.Item{
 		Ctx:   ctx,
-		Key:   listVideoKey(limit, skip),
-		Value: videos,
+		Key:   listVideoKey(listGeneration(ctx, videoDAO), opts),
+		Value: page,
 		TTL:   videoDAORedisCacheDuration,
 	})).NotTo(HaveOccurred())
 }
 
-func deleteVideosInRedis(ctx context.Context, videoDAO *redisVideoDAO, limit int64, skip int64) {
-	Expect(videoDAO.cache.Delete(ctx, listVideoKey(limit, skip))).NotTo(HaveOccurred())
+func deleteVideosInRedis(ctx context.Context, videoDAO *redisVideoDAO, opts *ListVideoOptions) {
+	Expect(videoDAO.cache.Delete(ctx, listVideoKey(listGeneration(ctx, videoDAO), opts))).NotTo(HaveOccurred())
+}
+
+func listGeneration(ctx context.Context, videoDAO *redisVideoDAO) int64 {
+	generation, ok := videoDAO.listGeneration(ctx)
+	Expect(ok).To(BeTrue())
+
+	return generation
 }
 
 func matchVideo(video *Video) types.GomegaMatcher {
 	return PointTo(MatchFields(IgnoreExtras, Fields{
-		"ID":       Equal(video.ID),
-		"Width":    Equal(video.Width),
-		"Height":   Equal(video.Height),
-		"Size":     Equal(video.Size),
-		"Duration": Equal(video.Duration),
-		"URL":      Equal(video.URL),
-		"Status":   Equal(video.Status),
-		"Variants": Equal(video.Variants),
+		"ID":         Equal(video.ID),
+		"Width":      Equal(video.Width),
+		"Height":     Equal(video.Height),
+		"Size":       Equal(video.Size),
+		"Duration":   Equal(video.Duration),
+		"ObjectName": Equal(video.ObjectName),
+		"Status":     Equal(video.Status),
+		"Variants":   Equal(video.Variants),
 	}))
 }
+
+// corruptListGeneration makes the generation of the cached lists unreadable as if Redis failed to read it,
+// and returns the function to restore the generation
+func corruptListGeneration(ctx context.Context) func() {
+	generation := redisClient.Get(ctx, videoListGenerationKey).Val()
+	Expect(redisClient.Set(ctx, videoListGenerationKey, "not a generation", 0).Err()).NotTo(HaveOccurred())
+
+	return func() {
+		if generation == "" {
+			Expect(redisClient.Del(ctx, videoListGenerationKey).Err()).NotTo(HaveOccurred())
+			return
+		}
+
+		Expect(redisClient.Set(ctx, videoListGenerationKey, generation, 0).Err()).NotTo(HaveOccurred())
+	}
+}
@@ -2,22 +2,38 @@ package gateway
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
 	"errors"
 	"io"
 	"net/http"
+	"strconv"
 
 	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/pb"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/grpckit"
 	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/logkit"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 )
 
 type Handler interface {
 	HandleUploadVideo(w http.ResponseWriter, r *http.Request, params map[string]string)
+	HandleUploadPart(w http.ResponseWriter, r *http.Request, params map[string]string)
+	HandleGetUploadOffset(w http.ResponseWriter, r *http.Request, params map[string]string)
 }
 
+const (
+	// uploadOffsetHeader is the header carrying the received offset of an upload session, same as the tus protocol
+	uploadOffsetHeader = "Upload-Offset"
+	// uploadLengthHeader is the header carrying the total size of an upload session, same as the tus protocol
+	uploadLengthHeader = "Upload-Length"
+
+	uploadPartChunkSize = 64 << 10
+)
+
 type handler struct {
 	client pb.VideoClient
 	logger *logkit.Logger
@@ -43,9 +59,10 @@ func (h *handler) HandleUploadVideo(w http.ResponseWriter, req *http.Request, pa
 	}
 	defer f.Close()
 
-	stream, err := h.client.UploadVideo(req.Context())
+	stream, err := h.client.UploadVideo(requestContext(req))
 	if err != nil {
 		h.encodeJSONResponse(w, NewResponseError(http.StatusInternalServerError, "failed to create stream client", err))
+		return
 	}
 
 	// 1. send file header first
@@ -95,6 +112,98 @@ func (h *handler) HandleUploadVideo(w http.ResponseWriter, req *http.Request, pa
 	h.encodeJSONResponse(w, resp)
 }
 
+// HandleUploadPart uploads the request body as the next part of the upload session,
+// the `Upload-Offset` header must be equal to the received offset of the session.
+func (h *handler) HandleUploadPart(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	offset, err := strconv.ParseUint(req.Header.Get(uploadOffsetHeader), 10, 64)
+	if err != nil {
+		h.encodeJSONResponse(w, NewResponseError(http.StatusBadRequest, "invalid upload offset", err))
+		return
+	}
+
+	if req.ContentLength <= 0 {
+		h.encodeJSONResponse(w, NewResponseError(http.StatusLengthRequired, "content length is required", nil))
+		return
+	}
+
+	stream, err := h.client.UploadPart(requestContext(req))
+	if err != nil {
+		h.encodeJSONResponse(w, NewResponseError(http.StatusInternalServerError, "failed to create stream client", err))
+		return
+	}
+
+	// 1. send part header first
+	if serr := stream.Send(&pb.UploadPartRequest{
+		Data: &pb.UploadPartRequest_Header{
+			Header: &pb.UploadPartHeader{
+				SessionId: params["id"],
+				Offset:    offset,
+				Size:      uint64(req.ContentLength),
+			},
+		},
+	}); serr != nil {
+		h.encodeJSONResponse(w, NewResponseError(http.StatusInternalServerError, "failed to send part header", serr))
+		return
+	}
+
+	// 2. send part chunk
+	buffer := make([]byte, uploadPartChunkSize)
+
+	for {
+		n, rerr := req.Body.Read(buffer)
+		if n > 0 {
+			if serr := stream.Send(&pb.UploadPartRequest{
+				Data: &pb.UploadPartRequest_ChunkData{
+					ChunkData: buffer[:n],
+				},
+			}); serr != nil {
+				// the server may reject the part before receiving all chunks, the error is returned by CloseAndRecv
+				if errors.Is(serr, io.EOF) {
+					break
+				}
+
+				h.encodeJSONResponse(w, NewResponseError(http.StatusInternalServerError, "failed to send part chunk data", serr))
+				return
+			}
+		}
+
+		if rerr != nil {
+			if errors.Is(rerr, io.EOF) {
+				break
+			}
+
+			h.encodeJSONResponse(w, NewResponseError(http.StatusInternalServerError, "failed to read part into buffer", rerr))
+			return
+		}
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		h.encodeJSONResponse(w, NewResponseError(runtime.HTTPStatusFromCode(status.Code(err)), "failed to upload part", err))
+		return
+	}
+
+	w.Header().Set(uploadOffsetHeader, strconv.FormatUint(resp.GetSession().GetOffset(), 10))
+	h.encodeJSONResponse(w, resp)
+}
+
+// HandleGetUploadOffset responds the received offset of the upload session in the headers,
+// so that the client knows where to resume the upload.
+func (h *handler) HandleGetUploadOffset(w http.ResponseWriter, req *http.Request, params map[string]string) {
+	resp, err := h.client.GetUploadSession(requestContext(req), &pb.GetUploadSessionRequest{
+		Id: params["id"],
+	})
+	if err != nil {
+		w.WriteHeader(runtime.HTTPStatusFromCode(status.Code(err)))
+		return
+	}
+
+	w.Header().Set(uploadOffsetHeader, strconv.FormatUint(resp.GetSession().GetOffset(), 10))
+	w.Header().Set(uploadLengthHeader, strconv.FormatUint(resp.GetSession().GetSize(), 10))
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+}
+
 func (h *handler) encodeJSONResponse(w http.ResponseWriter, resp interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 
@@ -103,18 +212,14 @@ func (h *handler) encodeJSONResponse(w http.ResponseWriter, resp interface{}) {
 		return
 	}
 
-	if err := json.NewEncoder(w).Encode(resp); err != nil {
+	bytes, err := json.Marshal(resp)
+	if err != nil {
 		h.logger.Error("failed to encode JSON response", zap.Error(err))
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
-	if coder, ok := resp.(StatusCoder); ok {
-		w.WriteHeader(coder.StatusCode())
-		return
-	}
-
-	w.WriteHeader(http.StatusOK)
+	h.writeResponse(w, resp, bytes)
 }
 
 func (h *handler) encodeProtoJSONResponse(w http.ResponseWriter, resp proto.Message) {
@@ -129,16 +234,24 @@ func (h *handler) encodeProtoJSONResponse(w http.ResponseWriter, resp proto.Mess
 		return
 	}
 
-	if _, err := w.Write(bytes); err != nil {
-		h.logger.Error("failed to write response", zap.Error(err))
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
+	h.writeResponse(w, resp, bytes)
+}
 
+// writeResponse writes the status code before the body, since the status cannot be changed once the body is written
+func (h *handler) writeResponse(w http.ResponseWriter, resp interface{}, bytes []byte) {
+	statusCode := http.StatusOK
 	if coder, ok := resp.(StatusCoder); ok {
-		w.WriteHeader(coder.StatusCode())
-		return
+		statusCode = coder.StatusCode()
 	}
 
-	w.WriteHeader(http.StatusOK)
+	w.WriteHeader(statusCode)
+
+	if _, err := w.Write(bytes); err != nil {
+		h.logger.Error("failed to write response", zap.Error(err))
+	}
+}
+
+// requestContext forwards the user ID header like the generated routes, which forward it by the header matcher
+func requestContext(req *http.Request) context.Context {
+	return grpckit.WithUserID(req.Context(), req.Header.Get(grpckit.UserIDHeader))
 }
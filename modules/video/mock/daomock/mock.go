@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/dao (interfaces: VideoDAO)
+// Source: github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/dao (interfaces: VideoDAO,UploadSessionDAO,ProfileDAO)
 
 // Package daomock is a generated GoMock package.
 package daomock
@@ -7,6 +7,7 @@ package daomock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	dao "github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/dao"
 	gomock "github.com/golang/mock/gomock"
@@ -79,19 +80,135 @@ func (mr *MockVideoDAOMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockVideoDAO)(nil).Get), arg0, arg1)
 }
 
+// GetIncludingDeleted mocks base method.
+func (m *MockVideoDAO) GetIncludingDeleted(arg0 context.Context, arg1 primitive.ObjectID) (*dao.Video, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIncludingDeleted", arg0, arg1)
+	ret0, _ := ret[0].(*dao.Video)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIncludingDeleted indicates an expected call of GetIncludingDeleted.
+func (mr *MockVideoDAOMockRecorder) GetIncludingDeleted(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIncludingDeleted", reflect.TypeOf((*MockVideoDAO)(nil).GetIncludingDeleted), arg0, arg1)
+}
+
 // List mocks base method.
-func (m *MockVideoDAO) List(arg0 context.Context, arg1, arg2 int64) ([]*dao.Video, error) {
+func (m *MockVideoDAO) List(arg0 context.Context, arg1 *dao.ListVideoOptions) (*dao.VideoPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", arg0, arg1, arg2)
-	ret0, _ := ret[0].([]*dao.Video)
+	ret := m.ctrl.Call(m, "List", arg0, arg1)
+	ret0, _ := ret[0].(*dao.VideoPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // List indicates an expected call of List.
-func (mr *MockVideoDAOMockRecorder) List(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockVideoDAOMockRecorder) List(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockVideoDAO)(nil).List), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockVideoDAO)(nil).List), arg0, arg1)
+}
+
+// Publish mocks base method.
+func (m *MockVideoDAO) Publish(arg0 context.Context, arg1 primitive.ObjectID, arg2 time.Time) (*dao.Video, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*dao.Video)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockVideoDAOMockRecorder) Publish(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockVideoDAO)(nil).Publish), arg0, arg1, arg2)
+}
+
+// Purge mocks base method.
+func (m *MockVideoDAO) Purge(arg0 context.Context, arg1 primitive.ObjectID, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockVideoDAOMockRecorder) Purge(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockVideoDAO)(nil).Purge), arg0, arg1, arg2)
+}
+
+// Restore mocks base method.
+func (m *MockVideoDAO) Restore(arg0 context.Context, arg1 primitive.ObjectID, arg2 string) (*dao.Video, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*dao.Video)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockVideoDAOMockRecorder) Restore(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockVideoDAO)(nil).Restore), arg0, arg1, arg2)
+}
+
+// Search mocks base method.
+func (m *MockVideoDAO) Search(arg0 context.Context, arg1 *dao.SearchVideoOptions) (*dao.VideoSearchPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", arg0, arg1)
+	ret0, _ := ret[0].(*dao.VideoSearchPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockVideoDAOMockRecorder) Search(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockVideoDAO)(nil).Search), arg0, arg1)
+}
+
+// SoftDelete mocks base method.
+func (m *MockVideoDAO) SoftDelete(arg0 context.Context, arg1 primitive.ObjectID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SoftDelete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SoftDelete indicates an expected call of SoftDelete.
+func (mr *MockVideoDAOMockRecorder) SoftDelete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftDelete", reflect.TypeOf((*MockVideoDAO)(nil).SoftDelete), arg0, arg1)
+}
+
+// StartEncoding mocks base method.
+func (m *MockVideoDAO) StartEncoding(arg0 context.Context, arg1 primitive.ObjectID, arg2 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartEncoding", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartEncoding indicates an expected call of StartEncoding.
+func (mr *MockVideoDAOMockRecorder) StartEncoding(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartEncoding", reflect.TypeOf((*MockVideoDAO)(nil).StartEncoding), arg0, arg1, arg2)
+}
+
+// StartPurge mocks base method.
+func (m *MockVideoDAO) StartPurge(arg0 context.Context, arg1 primitive.ObjectID, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartPurge", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartPurge indicates an expected call of StartPurge.
+func (mr *MockVideoDAOMockRecorder) StartPurge(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartPurge", reflect.TypeOf((*MockVideoDAO)(nil).StartPurge), arg0, arg1, arg2)
 }
 
 // Update mocks base method.
@@ -108,16 +225,222 @@ func (mr *MockVideoDAOMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockVideoDAO)(nil).Update), arg0, arg1)
 }
 
-// UpdateVariant mocks base method.
-func (m *MockVideoDAO) UpdateVariant(arg0 context.Context, arg1 primitive.ObjectID, arg2, arg3 string) error {
+// UpdateManifest mocks base method.
+func (m *MockVideoDAO) UpdateManifest(arg0 context.Context, arg1 primitive.ObjectID, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateManifest", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateManifest indicates an expected call of UpdateManifest.
+func (mr *MockVideoDAOMockRecorder) UpdateManifest(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateManifest", reflect.TypeOf((*MockVideoDAO)(nil).UpdateManifest), arg0, arg1, arg2)
+}
+
+// UpdateMetadata mocks base method.
+func (m *MockVideoDAO) UpdateMetadata(arg0 context.Context, arg1 primitive.ObjectID, arg2 *dao.VideoMetadata, arg3 []string, arg4 time.Time) (*dao.Video, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMetadata", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*dao.Video)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMetadata indicates an expected call of UpdateMetadata.
+func (mr *MockVideoDAOMockRecorder) UpdateMetadata(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMetadata", reflect.TypeOf((*MockVideoDAO)(nil).UpdateMetadata), arg0, arg1, arg2, arg3, arg4)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockVideoDAO) UpdateStatus(arg0 context.Context, arg1 primitive.ObjectID, arg2, arg3 dao.VideoStatus) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateVariant", arg0, arg1, arg2, arg3)
+	ret := m.ctrl.Call(m, "UpdateStatus", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockVideoDAOMockRecorder) UpdateStatus(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockVideoDAO)(nil).UpdateStatus), arg0, arg1, arg2, arg3)
+}
+
+// UpdateThumbnail mocks base method.
+func (m *MockVideoDAO) UpdateThumbnail(arg0 context.Context, arg1 primitive.ObjectID, arg2 *dao.Thumbnail) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateThumbnail", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateThumbnail indicates an expected call of UpdateThumbnail.
+func (mr *MockVideoDAOMockRecorder) UpdateThumbnail(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateThumbnail", reflect.TypeOf((*MockVideoDAO)(nil).UpdateThumbnail), arg0, arg1, arg2)
+}
+
+// UpdateVariant mocks base method.
+func (m *MockVideoDAO) UpdateVariant(arg0 context.Context, arg1 primitive.ObjectID, arg2, arg3 string, arg4 *dao.Playlist) (*dao.Video, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateVariant", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*dao.Video)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // UpdateVariant indicates an expected call of UpdateVariant.
-func (mr *MockVideoDAOMockRecorder) UpdateVariant(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+func (mr *MockVideoDAOMockRecorder) UpdateVariant(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVariant", reflect.TypeOf((*MockVideoDAO)(nil).UpdateVariant), arg0, arg1, arg2, arg3, arg4)
+}
+
+// MockUploadSessionDAO is a mock of UploadSessionDAO interface.
+type MockUploadSessionDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockUploadSessionDAOMockRecorder
+}
+
+// MockUploadSessionDAOMockRecorder is the mock recorder for MockUploadSessionDAO.
+type MockUploadSessionDAOMockRecorder struct {
+	mock *MockUploadSessionDAO
+}
+
+// NewMockUploadSessionDAO creates a new mock instance.
+func NewMockUploadSessionDAO(ctrl *gomock.Controller) *MockUploadSessionDAO {
+	mock := &MockUploadSessionDAO{ctrl: ctrl}
+	mock.recorder = &MockUploadSessionDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUploadSessionDAO) EXPECT() *MockUploadSessionDAOMockRecorder {
+	return m.recorder
+}
+
+// AppendPart mocks base method.
+func (m *MockUploadSessionDAO) AppendPart(arg0 context.Context, arg1 primitive.ObjectID, arg2 uint64, arg3 *dao.UploadPart) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppendPart", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AppendPart indicates an expected call of AppendPart.
+func (mr *MockUploadSessionDAOMockRecorder) AppendPart(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendPart", reflect.TypeOf((*MockUploadSessionDAO)(nil).AppendPart), arg0, arg1, arg2, arg3)
+}
+
+// Create mocks base method.
+func (m *MockUploadSessionDAO) Create(arg0 context.Context, arg1 *dao.UploadSession) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockUploadSessionDAOMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUploadSessionDAO)(nil).Create), arg0, arg1)
+}
+
+// Get mocks base method.
+func (m *MockUploadSessionDAO) Get(arg0 context.Context, arg1 primitive.ObjectID) (*dao.UploadSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(*dao.UploadSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockUploadSessionDAOMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockUploadSessionDAO)(nil).Get), arg0, arg1)
+}
+
+// ReservePartNumber mocks base method.
+func (m *MockUploadSessionDAO) ReservePartNumber(arg0 context.Context, arg1 primitive.ObjectID, arg2 uint64) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReservePartNumber", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReservePartNumber indicates an expected call of ReservePartNumber.
+func (mr *MockUploadSessionDAOMockRecorder) ReservePartNumber(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReservePartNumber", reflect.TypeOf((*MockUploadSessionDAO)(nil).ReservePartNumber), arg0, arg1, arg2)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockUploadSessionDAO) UpdateStatus(arg0 context.Context, arg1 primitive.ObjectID, arg2, arg3 dao.UploadSessionStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockUploadSessionDAOMockRecorder) UpdateStatus(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockUploadSessionDAO)(nil).UpdateStatus), arg0, arg1, arg2, arg3)
+}
+
+// MockProfileDAO is a mock of ProfileDAO interface.
+type MockProfileDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockProfileDAOMockRecorder
+}
+
+// MockProfileDAOMockRecorder is the mock recorder for MockProfileDAO.
+type MockProfileDAOMockRecorder struct {
+	mock *MockProfileDAO
+}
+
+// NewMockProfileDAO creates a new mock instance.
+func NewMockProfileDAO(ctrl *gomock.Controller) *MockProfileDAO {
+	mock := &MockProfileDAO{ctrl: ctrl}
+	mock.recorder = &MockProfileDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProfileDAO) EXPECT() *MockProfileDAOMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockProfileDAO) Get(arg0 context.Context, arg1 string) (*dao.Profile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(*dao.Profile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockProfileDAOMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockProfileDAO)(nil).Get), arg0, arg1)
+}
+
+// List mocks base method.
+func (m *MockProfileDAO) List(arg0 context.Context) ([]*dao.Profile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0)
+	ret0, _ := ret[0].([]*dao.Profile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockProfileDAOMockRecorder) List(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVariant", reflect.TypeOf((*MockVideoDAO)(nil).UpdateVariant), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockProfileDAO)(nil).List), arg0)
 }
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/pb (interfaces: Video_UploadVideoServer,VideoClient)
+// Source: github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/pb (interfaces: Video_UploadVideoServer,Video_UploadPartServer,VideoClient)
 
 // Package pbmock is a generated GoMock package.
 package pbmock
@@ -10,6 +10,7 @@ import (
 
 	pb "github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/pb"
 	gomock "github.com/golang/mock/gomock"
+	httpbody "google.golang.org/genproto/googleapis/api/httpbody"
 	grpc "google.golang.org/grpc"
 	metadata "google.golang.org/grpc/metadata"
 )
@@ -148,6 +149,140 @@ func (mr *MockVideo_UploadVideoServerMockRecorder) SetTrailer(arg0 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockVideo_UploadVideoServer)(nil).SetTrailer), arg0)
 }
 
+// MockVideo_UploadPartServer is a mock of Video_UploadPartServer interface.
+type MockVideo_UploadPartServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockVideo_UploadPartServerMockRecorder
+}
+
+// MockVideo_UploadPartServerMockRecorder is the mock recorder for MockVideo_UploadPartServer.
+type MockVideo_UploadPartServerMockRecorder struct {
+	mock *MockVideo_UploadPartServer
+}
+
+// NewMockVideo_UploadPartServer creates a new mock instance.
+func NewMockVideo_UploadPartServer(ctrl *gomock.Controller) *MockVideo_UploadPartServer {
+	mock := &MockVideo_UploadPartServer{ctrl: ctrl}
+	mock.recorder = &MockVideo_UploadPartServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVideo_UploadPartServer) EXPECT() *MockVideo_UploadPartServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockVideo_UploadPartServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockVideo_UploadPartServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockVideo_UploadPartServer)(nil).Context))
+}
+
+// Recv mocks base method.
+func (m *MockVideo_UploadPartServer) Recv() (*pb.UploadPartRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*pb.UploadPartRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockVideo_UploadPartServerMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockVideo_UploadPartServer)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m *MockVideo_UploadPartServer) RecvMsg(arg0 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecvMsg", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockVideo_UploadPartServerMockRecorder) RecvMsg(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockVideo_UploadPartServer)(nil).RecvMsg), arg0)
+}
+
+// SendAndClose mocks base method.
+func (m *MockVideo_UploadPartServer) SendAndClose(arg0 *pb.UploadPartResponse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendAndClose", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendAndClose indicates an expected call of SendAndClose.
+func (mr *MockVideo_UploadPartServerMockRecorder) SendAndClose(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendAndClose", reflect.TypeOf((*MockVideo_UploadPartServer)(nil).SendAndClose), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockVideo_UploadPartServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockVideo_UploadPartServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockVideo_UploadPartServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m *MockVideo_UploadPartServer) SendMsg(arg0 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMsg", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockVideo_UploadPartServerMockRecorder) SendMsg(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockVideo_UploadPartServer)(nil).SendMsg), arg0)
+}
+
+// SetHeader mocks base method.
+func (m *MockVideo_UploadPartServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockVideo_UploadPartServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockVideo_UploadPartServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockVideo_UploadPartServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer.
+func (mr *MockVideo_UploadPartServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockVideo_UploadPartServer)(nil).SetTrailer), arg0)
+}
+
 // MockVideoClient is a mock of VideoClient interface.
 type MockVideoClient struct {
 	ctrl     *gomock.Controller
@@ -171,6 +306,66 @@ func (m *MockVideoClient) EXPECT() *MockVideoClientMockRecorder {
 	return m.recorder
 }
 
+// AbortUploadSession mocks base method.
+func (m *MockVideoClient) AbortUploadSession(arg0 context.Context, arg1 *pb.AbortUploadSessionRequest, arg2 ...grpc.CallOption) (*pb.AbortUploadSessionResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AbortUploadSession", varargs...)
+	ret0, _ := ret[0].(*pb.AbortUploadSessionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AbortUploadSession indicates an expected call of AbortUploadSession.
+func (mr *MockVideoClientMockRecorder) AbortUploadSession(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AbortUploadSession", reflect.TypeOf((*MockVideoClient)(nil).AbortUploadSession), varargs...)
+}
+
+// CompleteUploadSession mocks base method.
+func (m *MockVideoClient) CompleteUploadSession(arg0 context.Context, arg1 *pb.CompleteUploadSessionRequest, arg2 ...grpc.CallOption) (*pb.CompleteUploadSessionResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CompleteUploadSession", varargs...)
+	ret0, _ := ret[0].(*pb.CompleteUploadSessionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteUploadSession indicates an expected call of CompleteUploadSession.
+func (mr *MockVideoClientMockRecorder) CompleteUploadSession(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteUploadSession", reflect.TypeOf((*MockVideoClient)(nil).CompleteUploadSession), varargs...)
+}
+
+// CreateUploadSession mocks base method.
+func (m *MockVideoClient) CreateUploadSession(arg0 context.Context, arg1 *pb.CreateUploadSessionRequest, arg2 ...grpc.CallOption) (*pb.CreateUploadSessionResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateUploadSession", varargs...)
+	ret0, _ := ret[0].(*pb.CreateUploadSessionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUploadSession indicates an expected call of CreateUploadSession.
+func (mr *MockVideoClientMockRecorder) CreateUploadSession(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUploadSession", reflect.TypeOf((*MockVideoClient)(nil).CreateUploadSession), varargs...)
+}
+
 // DeleteVideo mocks base method.
 func (m *MockVideoClient) DeleteVideo(arg0 context.Context, arg1 *pb.DeleteVideoRequest, arg2 ...grpc.CallOption) (*pb.DeleteVideoResponse, error) {
 	m.ctrl.T.Helper()
@@ -191,6 +386,26 @@ func (mr *MockVideoClientMockRecorder) DeleteVideo(arg0, arg1 interface{}, arg2
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVideo", reflect.TypeOf((*MockVideoClient)(nil).DeleteVideo), varargs...)
 }
 
+// GetUploadSession mocks base method.
+func (m *MockVideoClient) GetUploadSession(arg0 context.Context, arg1 *pb.GetUploadSessionRequest, arg2 ...grpc.CallOption) (*pb.GetUploadSessionResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetUploadSession", varargs...)
+	ret0, _ := ret[0].(*pb.GetUploadSessionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUploadSession indicates an expected call of GetUploadSession.
+func (mr *MockVideoClientMockRecorder) GetUploadSession(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUploadSession", reflect.TypeOf((*MockVideoClient)(nil).GetUploadSession), varargs...)
+}
+
 // GetVideo mocks base method.
 func (m *MockVideoClient) GetVideo(arg0 context.Context, arg1 *pb.GetVideoRequest, arg2 ...grpc.CallOption) (*pb.GetVideoResponse, error) {
 	m.ctrl.T.Helper()
@@ -211,6 +426,66 @@ func (mr *MockVideoClientMockRecorder) GetVideo(arg0, arg1 interface{}, arg2 ...
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVideo", reflect.TypeOf((*MockVideoClient)(nil).GetVideo), varargs...)
 }
 
+// GetVideoManifest mocks base method.
+func (m *MockVideoClient) GetVideoManifest(arg0 context.Context, arg1 *pb.GetVideoManifestRequest, arg2 ...grpc.CallOption) (*httpbody.HttpBody, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetVideoManifest", varargs...)
+	ret0, _ := ret[0].(*httpbody.HttpBody)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVideoManifest indicates an expected call of GetVideoManifest.
+func (mr *MockVideoClientMockRecorder) GetVideoManifest(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVideoManifest", reflect.TypeOf((*MockVideoClient)(nil).GetVideoManifest), varargs...)
+}
+
+// GetVideoPlaylist mocks base method.
+func (m *MockVideoClient) GetVideoPlaylist(arg0 context.Context, arg1 *pb.GetVideoPlaylistRequest, arg2 ...grpc.CallOption) (*httpbody.HttpBody, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetVideoPlaylist", varargs...)
+	ret0, _ := ret[0].(*httpbody.HttpBody)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVideoPlaylist indicates an expected call of GetVideoPlaylist.
+func (mr *MockVideoClientMockRecorder) GetVideoPlaylist(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVideoPlaylist", reflect.TypeOf((*MockVideoClient)(nil).GetVideoPlaylist), varargs...)
+}
+
+// GetVideoPreview mocks base method.
+func (m *MockVideoClient) GetVideoPreview(arg0 context.Context, arg1 *pb.GetVideoPreviewRequest, arg2 ...grpc.CallOption) (*httpbody.HttpBody, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetVideoPreview", varargs...)
+	ret0, _ := ret[0].(*httpbody.HttpBody)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVideoPreview indicates an expected call of GetVideoPreview.
+func (mr *MockVideoClientMockRecorder) GetVideoPreview(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVideoPreview", reflect.TypeOf((*MockVideoClient)(nil).GetVideoPreview), varargs...)
+}
+
 // Healthz mocks base method.
 func (m *MockVideoClient) Healthz(arg0 context.Context, arg1 *pb.HealthzRequest, arg2 ...grpc.CallOption) (*pb.HealthzResponse, error) {
 	m.ctrl.T.Helper()
@@ -231,6 +506,26 @@ func (mr *MockVideoClientMockRecorder) Healthz(arg0, arg1 interface{}, arg2 ...i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Healthz", reflect.TypeOf((*MockVideoClient)(nil).Healthz), varargs...)
 }
 
+// ListDeletedVideos mocks base method.
+func (m *MockVideoClient) ListDeletedVideos(arg0 context.Context, arg1 *pb.ListDeletedVideosRequest, arg2 ...grpc.CallOption) (*pb.ListDeletedVideosResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListDeletedVideos", varargs...)
+	ret0, _ := ret[0].(*pb.ListDeletedVideosResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeletedVideos indicates an expected call of ListDeletedVideos.
+func (mr *MockVideoClientMockRecorder) ListDeletedVideos(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeletedVideos", reflect.TypeOf((*MockVideoClient)(nil).ListDeletedVideos), varargs...)
+}
+
 // ListVideo mocks base method.
 func (m *MockVideoClient) ListVideo(arg0 context.Context, arg1 *pb.ListVideoRequest, arg2 ...grpc.CallOption) (*pb.ListVideoResponse, error) {
 	m.ctrl.T.Helper()
@@ -251,6 +546,86 @@ func (mr *MockVideoClientMockRecorder) ListVideo(arg0, arg1 interface{}, arg2 ..
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVideo", reflect.TypeOf((*MockVideoClient)(nil).ListVideo), varargs...)
 }
 
+// RestoreVideo mocks base method.
+func (m *MockVideoClient) RestoreVideo(arg0 context.Context, arg1 *pb.RestoreVideoRequest, arg2 ...grpc.CallOption) (*pb.RestoreVideoResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RestoreVideo", varargs...)
+	ret0, _ := ret[0].(*pb.RestoreVideoResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreVideo indicates an expected call of RestoreVideo.
+func (mr *MockVideoClientMockRecorder) RestoreVideo(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreVideo", reflect.TypeOf((*MockVideoClient)(nil).RestoreVideo), varargs...)
+}
+
+// SearchVideo mocks base method.
+func (m *MockVideoClient) SearchVideo(arg0 context.Context, arg1 *pb.SearchVideoRequest, arg2 ...grpc.CallOption) (*pb.SearchVideoResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SearchVideo", varargs...)
+	ret0, _ := ret[0].(*pb.SearchVideoResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchVideo indicates an expected call of SearchVideo.
+func (mr *MockVideoClientMockRecorder) SearchVideo(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchVideo", reflect.TypeOf((*MockVideoClient)(nil).SearchVideo), varargs...)
+}
+
+// UpdateVideo mocks base method.
+func (m *MockVideoClient) UpdateVideo(arg0 context.Context, arg1 *pb.UpdateVideoRequest, arg2 ...grpc.CallOption) (*pb.UpdateVideoResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateVideo", varargs...)
+	ret0, _ := ret[0].(*pb.UpdateVideoResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateVideo indicates an expected call of UpdateVideo.
+func (mr *MockVideoClientMockRecorder) UpdateVideo(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVideo", reflect.TypeOf((*MockVideoClient)(nil).UpdateVideo), varargs...)
+}
+
+// UploadPart mocks base method.
+func (m *MockVideoClient) UploadPart(arg0 context.Context, arg1 ...grpc.CallOption) (pb.Video_UploadPartClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UploadPart", varargs...)
+	ret0, _ := ret[0].(pb.Video_UploadPartClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadPart indicates an expected call of UploadPart.
+func (mr *MockVideoClientMockRecorder) UploadPart(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadPart", reflect.TypeOf((*MockVideoClient)(nil).UploadPart), varargs...)
+}
+
 // UploadVideo mocks base method.
 func (m *MockVideoClient) UploadVideo(arg0 context.Context, arg1 ...grpc.CallOption) (pb.Video_UploadVideoClient, error) {
 	m.ctrl.T.Helper()
@@ -8,6 +8,7 @@ package pb
 
 import (
 	context "context"
+	httpbody "google.golang.org/genproto/googleapis/api/httpbody"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -24,9 +25,21 @@ const _ = grpc.SupportPackageIsVersion7
 type VideoClient interface {
 	Healthz(ctx context.Context, in *HealthzRequest, opts ...grpc.CallOption) (*HealthzResponse, error)
 	GetVideo(ctx context.Context, in *GetVideoRequest, opts ...grpc.CallOption) (*GetVideoResponse, error)
+	GetVideoManifest(ctx context.Context, in *GetVideoManifestRequest, opts ...grpc.CallOption) (*httpbody.HttpBody, error)
+	GetVideoPlaylist(ctx context.Context, in *GetVideoPlaylistRequest, opts ...grpc.CallOption) (*httpbody.HttpBody, error)
+	GetVideoPreview(ctx context.Context, in *GetVideoPreviewRequest, opts ...grpc.CallOption) (*httpbody.HttpBody, error)
 	ListVideo(ctx context.Context, in *ListVideoRequest, opts ...grpc.CallOption) (*ListVideoResponse, error)
+	SearchVideo(ctx context.Context, in *SearchVideoRequest, opts ...grpc.CallOption) (*SearchVideoResponse, error)
 	UploadVideo(ctx context.Context, opts ...grpc.CallOption) (Video_UploadVideoClient, error)
+	UpdateVideo(ctx context.Context, in *UpdateVideoRequest, opts ...grpc.CallOption) (*UpdateVideoResponse, error)
 	DeleteVideo(ctx context.Context, in *DeleteVideoRequest, opts ...grpc.CallOption) (*DeleteVideoResponse, error)
+	RestoreVideo(ctx context.Context, in *RestoreVideoRequest, opts ...grpc.CallOption) (*RestoreVideoResponse, error)
+	ListDeletedVideos(ctx context.Context, in *ListDeletedVideosRequest, opts ...grpc.CallOption) (*ListDeletedVideosResponse, error)
+	CreateUploadSession(ctx context.Context, in *CreateUploadSessionRequest, opts ...grpc.CallOption) (*CreateUploadSessionResponse, error)
+	GetUploadSession(ctx context.Context, in *GetUploadSessionRequest, opts ...grpc.CallOption) (*GetUploadSessionResponse, error)
+	UploadPart(ctx context.Context, opts ...grpc.CallOption) (Video_UploadPartClient, error)
+	CompleteUploadSession(ctx context.Context, in *CompleteUploadSessionRequest, opts ...grpc.CallOption) (*CompleteUploadSessionResponse, error)
+	AbortUploadSession(ctx context.Context, in *AbortUploadSessionRequest, opts ...grpc.CallOption) (*AbortUploadSessionResponse, error)
 }
 
 type videoClient struct {
@@ -55,6 +68,33 @@ func (c *videoClient) GetVideo(ctx context.Context, in *GetVideoRequest, opts ..
 	return out, nil
 }
 
+func (c *videoClient) GetVideoManifest(ctx context.Context, in *GetVideoManifestRequest, opts ...grpc.CallOption) (*httpbody.HttpBody, error) {
+	out := new(httpbody.HttpBody)
+	err := c.cc.Invoke(ctx, "/video.pb.Video/GetVideoManifest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoClient) GetVideoPlaylist(ctx context.Context, in *GetVideoPlaylistRequest, opts ...grpc.CallOption) (*httpbody.HttpBody, error) {
+	out := new(httpbody.HttpBody)
+	err := c.cc.Invoke(ctx, "/video.pb.Video/GetVideoPlaylist", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoClient) GetVideoPreview(ctx context.Context, in *GetVideoPreviewRequest, opts ...grpc.CallOption) (*httpbody.HttpBody, error) {
+	out := new(httpbody.HttpBody)
+	err := c.cc.Invoke(ctx, "/video.pb.Video/GetVideoPreview", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *videoClient) ListVideo(ctx context.Context, in *ListVideoRequest, opts ...grpc.CallOption) (*ListVideoResponse, error) {
 	out := new(ListVideoResponse)
 	err := c.cc.Invoke(ctx, "/video.pb.Video/ListVideo", in, out, opts...)
@@ -64,6 +104,15 @@ func (c *videoClient) ListVideo(ctx context.Context, in *ListVideoRequest, opts
 	return out, nil
 }
 
+func (c *videoClient) SearchVideo(ctx context.Context, in *SearchVideoRequest, opts ...grpc.CallOption) (*SearchVideoResponse, error) {
+	out := new(SearchVideoResponse)
+	err := c.cc.Invoke(ctx, "/video.pb.Video/SearchVideo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *videoClient) UploadVideo(ctx context.Context, opts ...grpc.CallOption) (Video_UploadVideoClient, error) {
 	stream, err := c.cc.NewStream(ctx, &Video_ServiceDesc.Streams[0], "/video.pb.Video/UploadVideo", opts...)
 	if err != nil {
@@ -98,6 +147,15 @@ func (x *videoUploadVideoClient) CloseAndRecv() (*UploadVideoResponse, error) {
 	return m, nil
 }
 
+func (c *videoClient) UpdateVideo(ctx context.Context, in *UpdateVideoRequest, opts ...grpc.CallOption) (*UpdateVideoResponse, error) {
+	out := new(UpdateVideoResponse)
+	err := c.cc.Invoke(ctx, "/video.pb.Video/UpdateVideo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *videoClient) DeleteVideo(ctx context.Context, in *DeleteVideoRequest, opts ...grpc.CallOption) (*DeleteVideoResponse, error) {
 	out := new(DeleteVideoResponse)
 	err := c.cc.Invoke(ctx, "/video.pb.Video/DeleteVideo", in, out, opts...)
@@ -107,15 +165,115 @@ func (c *videoClient) DeleteVideo(ctx context.Context, in *DeleteVideoRequest, o
 	return out, nil
 }
 
+func (c *videoClient) RestoreVideo(ctx context.Context, in *RestoreVideoRequest, opts ...grpc.CallOption) (*RestoreVideoResponse, error) {
+	out := new(RestoreVideoResponse)
+	err := c.cc.Invoke(ctx, "/video.pb.Video/RestoreVideo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoClient) ListDeletedVideos(ctx context.Context, in *ListDeletedVideosRequest, opts ...grpc.CallOption) (*ListDeletedVideosResponse, error) {
+	out := new(ListDeletedVideosResponse)
+	err := c.cc.Invoke(ctx, "/video.pb.Video/ListDeletedVideos", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoClient) CreateUploadSession(ctx context.Context, in *CreateUploadSessionRequest, opts ...grpc.CallOption) (*CreateUploadSessionResponse, error) {
+	out := new(CreateUploadSessionResponse)
+	err := c.cc.Invoke(ctx, "/video.pb.Video/CreateUploadSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoClient) GetUploadSession(ctx context.Context, in *GetUploadSessionRequest, opts ...grpc.CallOption) (*GetUploadSessionResponse, error) {
+	out := new(GetUploadSessionResponse)
+	err := c.cc.Invoke(ctx, "/video.pb.Video/GetUploadSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoClient) UploadPart(ctx context.Context, opts ...grpc.CallOption) (Video_UploadPartClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Video_ServiceDesc.Streams[1], "/video.pb.Video/UploadPart", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &videoUploadPartClient{stream}
+	return x, nil
+}
+
+type Video_UploadPartClient interface {
+	Send(*UploadPartRequest) error
+	CloseAndRecv() (*UploadPartResponse, error)
+	grpc.ClientStream
+}
+
+type videoUploadPartClient struct {
+	grpc.ClientStream
+}
+
+func (x *videoUploadPartClient) Send(m *UploadPartRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *videoUploadPartClient) CloseAndRecv() (*UploadPartResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(UploadPartResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *videoClient) CompleteUploadSession(ctx context.Context, in *CompleteUploadSessionRequest, opts ...grpc.CallOption) (*CompleteUploadSessionResponse, error) {
+	out := new(CompleteUploadSessionResponse)
+	err := c.cc.Invoke(ctx, "/video.pb.Video/CompleteUploadSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoClient) AbortUploadSession(ctx context.Context, in *AbortUploadSessionRequest, opts ...grpc.CallOption) (*AbortUploadSessionResponse, error) {
+	out := new(AbortUploadSessionResponse)
+	err := c.cc.Invoke(ctx, "/video.pb.Video/AbortUploadSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // VideoServer is the server API for Video service.
 // All implementations must embed UnimplementedVideoServer
 // for forward compatibility
 type VideoServer interface {
 	Healthz(context.Context, *HealthzRequest) (*HealthzResponse, error)
 	GetVideo(context.Context, *GetVideoRequest) (*GetVideoResponse, error)
+	GetVideoManifest(context.Context, *GetVideoManifestRequest) (*httpbody.HttpBody, error)
+	GetVideoPlaylist(context.Context, *GetVideoPlaylistRequest) (*httpbody.HttpBody, error)
+	GetVideoPreview(context.Context, *GetVideoPreviewRequest) (*httpbody.HttpBody, error)
 	ListVideo(context.Context, *ListVideoRequest) (*ListVideoResponse, error)
+	SearchVideo(context.Context, *SearchVideoRequest) (*SearchVideoResponse, error)
 	UploadVideo(Video_UploadVideoServer) error
+	UpdateVideo(context.Context, *UpdateVideoRequest) (*UpdateVideoResponse, error)
 	DeleteVideo(context.Context, *DeleteVideoRequest) (*DeleteVideoResponse, error)
+	RestoreVideo(context.Context, *RestoreVideoRequest) (*RestoreVideoResponse, error)
+	ListDeletedVideos(context.Context, *ListDeletedVideosRequest) (*ListDeletedVideosResponse, error)
+	CreateUploadSession(context.Context, *CreateUploadSessionRequest) (*CreateUploadSessionResponse, error)
+	GetUploadSession(context.Context, *GetUploadSessionRequest) (*GetUploadSessionResponse, error)
+	UploadPart(Video_UploadPartServer) error
+	CompleteUploadSession(context.Context, *CompleteUploadSessionRequest) (*CompleteUploadSessionResponse, error)
+	AbortUploadSession(context.Context, *AbortUploadSessionRequest) (*AbortUploadSessionResponse, error)
 	mustEmbedUnimplementedVideoServer()
 }
 
@@ -129,15 +287,51 @@ func (UnimplementedVideoServer) Healthz(context.Context, *HealthzRequest) (*Heal
 func (UnimplementedVideoServer) GetVideo(context.Context, *GetVideoRequest) (*GetVideoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetVideo not implemented")
 }
+func (UnimplementedVideoServer) GetVideoManifest(context.Context, *GetVideoManifestRequest) (*httpbody.HttpBody, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVideoManifest not implemented")
+}
+func (UnimplementedVideoServer) GetVideoPlaylist(context.Context, *GetVideoPlaylistRequest) (*httpbody.HttpBody, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVideoPlaylist not implemented")
+}
+func (UnimplementedVideoServer) GetVideoPreview(context.Context, *GetVideoPreviewRequest) (*httpbody.HttpBody, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVideoPreview not implemented")
+}
 func (UnimplementedVideoServer) ListVideo(context.Context, *ListVideoRequest) (*ListVideoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListVideo not implemented")
 }
+func (UnimplementedVideoServer) SearchVideo(context.Context, *SearchVideoRequest) (*SearchVideoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchVideo not implemented")
+}
 func (UnimplementedVideoServer) UploadVideo(Video_UploadVideoServer) error {
 	return status.Errorf(codes.Unimplemented, "method UploadVideo not implemented")
 }
+func (UnimplementedVideoServer) UpdateVideo(context.Context, *UpdateVideoRequest) (*UpdateVideoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateVideo not implemented")
+}
 func (UnimplementedVideoServer) DeleteVideo(context.Context, *DeleteVideoRequest) (*DeleteVideoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteVideo not implemented")
 }
+func (UnimplementedVideoServer) RestoreVideo(context.Context, *RestoreVideoRequest) (*RestoreVideoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreVideo not implemented")
+}
+func (UnimplementedVideoServer) ListDeletedVideos(context.Context, *ListDeletedVideosRequest) (*ListDeletedVideosResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeletedVideos not implemented")
+}
+func (UnimplementedVideoServer) CreateUploadSession(context.Context, *CreateUploadSessionRequest) (*CreateUploadSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUploadSession not implemented")
+}
+func (UnimplementedVideoServer) GetUploadSession(context.Context, *GetUploadSessionRequest) (*GetUploadSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUploadSession not implemented")
+}
+func (UnimplementedVideoServer) UploadPart(Video_UploadPartServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadPart not implemented")
+}
+func (UnimplementedVideoServer) CompleteUploadSession(context.Context, *CompleteUploadSessionRequest) (*CompleteUploadSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompleteUploadSession not implemented")
+}
+func (UnimplementedVideoServer) AbortUploadSession(context.Context, *AbortUploadSessionRequest) (*AbortUploadSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AbortUploadSession not implemented")
+}
 func (UnimplementedVideoServer) mustEmbedUnimplementedVideoServer() {}
 
 // UnsafeVideoServer may be embedded to opt out of forward compatibility for this service.
@@ -187,6 +381,60 @@ func _Video_GetVideo_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Video_GetVideoManifest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVideoManifestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServer).GetVideoManifest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/video.pb.Video/GetVideoManifest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServer).GetVideoManifest(ctx, req.(*GetVideoManifestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Video_GetVideoPlaylist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVideoPlaylistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServer).GetVideoPlaylist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/video.pb.Video/GetVideoPlaylist",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServer).GetVideoPlaylist(ctx, req.(*GetVideoPlaylistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Video_GetVideoPreview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVideoPreviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServer).GetVideoPreview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/video.pb.Video/GetVideoPreview",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServer).GetVideoPreview(ctx, req.(*GetVideoPreviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Video_ListVideo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListVideoRequest)
 	if err := dec(in); err != nil {
@@ -205,6 +453,24 @@ func _Video_ListVideo_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Video_SearchVideo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchVideoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServer).SearchVideo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/video.pb.Video/SearchVideo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServer).SearchVideo(ctx, req.(*SearchVideoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Video_UploadVideo_Handler(srv interface{}, stream grpc.ServerStream) error {
 	return srv.(VideoServer).UploadVideo(&videoUploadVideoServer{stream})
 }
@@ -231,6 +497,24 @@ func (x *videoUploadVideoServer) Recv() (*UploadVideoRequest, error) {
 	return m, nil
 }
 
+func _Video_UpdateVideo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateVideoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServer).UpdateVideo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/video.pb.Video/UpdateVideo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServer).UpdateVideo(ctx, req.(*UpdateVideoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Video_DeleteVideo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteVideoRequest)
 	if err := dec(in); err != nil {
@@ -249,6 +533,140 @@ func _Video_DeleteVideo_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Video_RestoreVideo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreVideoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServer).RestoreVideo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/video.pb.Video/RestoreVideo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServer).RestoreVideo(ctx, req.(*RestoreVideoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Video_ListDeletedVideos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeletedVideosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServer).ListDeletedVideos(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/video.pb.Video/ListDeletedVideos",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServer).ListDeletedVideos(ctx, req.(*ListDeletedVideosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Video_CreateUploadSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateUploadSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServer).CreateUploadSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/video.pb.Video/CreateUploadSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServer).CreateUploadSession(ctx, req.(*CreateUploadSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Video_GetUploadSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUploadSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServer).GetUploadSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/video.pb.Video/GetUploadSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServer).GetUploadSession(ctx, req.(*GetUploadSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Video_UploadPart_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(VideoServer).UploadPart(&videoUploadPartServer{stream})
+}
+
+type Video_UploadPartServer interface {
+	SendAndClose(*UploadPartResponse) error
+	Recv() (*UploadPartRequest, error)
+	grpc.ServerStream
+}
+
+type videoUploadPartServer struct {
+	grpc.ServerStream
+}
+
+func (x *videoUploadPartServer) SendAndClose(m *UploadPartResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *videoUploadPartServer) Recv() (*UploadPartRequest, error) {
+	m := new(UploadPartRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Video_CompleteUploadSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteUploadSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServer).CompleteUploadSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/video.pb.Video/CompleteUploadSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServer).CompleteUploadSession(ctx, req.(*CompleteUploadSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Video_AbortUploadSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AbortUploadSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServer).AbortUploadSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/video.pb.Video/AbortUploadSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServer).AbortUploadSession(ctx, req.(*AbortUploadSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Video_ServiceDesc is the grpc.ServiceDesc for Video service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -264,14 +682,58 @@ var Video_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetVideo",
 			Handler:    _Video_GetVideo_Handler,
 		},
+		{
+			MethodName: "GetVideoManifest",
+			Handler:    _Video_GetVideoManifest_Handler,
+		},
+		{
+			MethodName: "GetVideoPlaylist",
+			Handler:    _Video_GetVideoPlaylist_Handler,
+		},
+		{
+			MethodName: "GetVideoPreview",
+			Handler:    _Video_GetVideoPreview_Handler,
+		},
 		{
 			MethodName: "ListVideo",
 			Handler:    _Video_ListVideo_Handler,
 		},
+		{
+			MethodName: "SearchVideo",
+			Handler:    _Video_SearchVideo_Handler,
+		},
+		{
+			MethodName: "UpdateVideo",
+			Handler:    _Video_UpdateVideo_Handler,
+		},
 		{
 			MethodName: "DeleteVideo",
 			Handler:    _Video_DeleteVideo_Handler,
 		},
+		{
+			MethodName: "RestoreVideo",
+			Handler:    _Video_RestoreVideo_Handler,
+		},
+		{
+			MethodName: "ListDeletedVideos",
+			Handler:    _Video_ListDeletedVideos_Handler,
+		},
+		{
+			MethodName: "CreateUploadSession",
+			Handler:    _Video_CreateUploadSession_Handler,
+		},
+		{
+			MethodName: "GetUploadSession",
+			Handler:    _Video_GetUploadSession_Handler,
+		},
+		{
+			MethodName: "CompleteUploadSession",
+			Handler:    _Video_CompleteUploadSession_Handler,
+		},
+		{
+			MethodName: "AbortUploadSession",
+			Handler:    _Video_AbortUploadSession_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -279,6 +741,11 @@ var Video_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Video_UploadVideo_Handler,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "UploadPart",
+			Handler:       _Video_UploadPart_Handler,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "modules/video/pb/rpc.proto",
 }
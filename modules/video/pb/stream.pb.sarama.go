@@ -12,6 +12,7 @@ import (
 
 type VideoStreamHandlers struct {
 	*HandleVideoCreatedHandler
+	*HandleVideoDeletedHandler
 }
 
 func NewVideoStreamHandlers(server VideoStreamServer, logger saramakit.Logger) *VideoStreamHandlers {
@@ -21,6 +22,11 @@ func NewVideoStreamHandlers(server VideoStreamServer, logger saramakit.Logger) *
 			unmarshaler: &proto.UnmarshalOptions{},
 			logger:      logger.With("HandlerName", "HandleVideoCreatedHandler"),
 		},
+		HandleVideoDeletedHandler: &HandleVideoDeletedHandler{
+			server:      server,
+			unmarshaler: &proto.UnmarshalOptions{},
+			logger:      logger.With("HandlerName", "HandleVideoDeletedHandler"),
+		},
 	}
 }
 
@@ -68,3 +74,48 @@ func (h *HandleVideoCreatedHandler) ConsumeClaim(sess sarama.ConsumerGroupSessio
 
 	return nil
 }
+
+type HandleVideoDeletedHandler struct {
+	server      VideoStreamServer
+	unmarshaler *proto.UnmarshalOptions
+	logger      saramakit.Logger
+}
+
+var _ sarama.ConsumerGroupHandler = (*HandleVideoDeletedHandler)(nil)
+
+func (h *HandleVideoDeletedHandler) Setup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (h *HandleVideoDeletedHandler) Cleanup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (h *HandleVideoDeletedHandler) ConsumeClaim(sess sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for msg := range claim.Messages() {
+		var req HandleVideoDeletedRequest
+
+		if err := h.unmarshaler.Unmarshal(msg.Value, &req); err != nil {
+			// unretryable failure, skip and consume the message
+			h.logger.Error("failed to unmarshal message", err)
+
+			continue
+		}
+
+		if _, err := h.server.HandleVideoDeleted(sess.Context(), &req); err != nil {
+			var e saramakit.HandlerError
+
+			if ok := errors.As(err, &e); ok && e.Retry {
+				h.logger.Error("failed to handle the message and the error is retryable", err)
+
+				return nil
+			}
+			h.logger.Error("failed to handle the message and the error is unretryable", err)
+		}
+
+		// mark message as completed
+		sess.MarkMessage(msg, "")
+	}
+
+	return nil
+}
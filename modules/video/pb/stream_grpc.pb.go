@@ -24,6 +24,7 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type VideoStreamClient interface {
 	HandleVideoCreated(ctx context.Context, in *HandleVideoCreatedRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	HandleVideoDeleted(ctx context.Context, in *HandleVideoDeletedRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type videoStreamClient struct {
@@ -43,11 +44,21 @@ func (c *videoStreamClient) HandleVideoCreated(ctx context.Context, in *HandleVi
 	return out, nil
 }
 
+func (c *videoStreamClient) HandleVideoDeleted(ctx context.Context, in *HandleVideoDeletedRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/video.pb.VideoStream/HandleVideoDeleted", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // VideoStreamServer is the server API for VideoStream service.
 // All implementations must embed UnimplementedVideoStreamServer
 // for forward compatibility
 type VideoStreamServer interface {
 	HandleVideoCreated(context.Context, *HandleVideoCreatedRequest) (*emptypb.Empty, error)
+	HandleVideoDeleted(context.Context, *HandleVideoDeletedRequest) (*emptypb.Empty, error)
 	mustEmbedUnimplementedVideoStreamServer()
 }
 
@@ -58,6 +69,9 @@ type UnimplementedVideoStreamServer struct {
 func (UnimplementedVideoStreamServer) HandleVideoCreated(context.Context, *HandleVideoCreatedRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method HandleVideoCreated not implemented")
 }
+func (UnimplementedVideoStreamServer) HandleVideoDeleted(context.Context, *HandleVideoDeletedRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HandleVideoDeleted not implemented")
+}
 func (UnimplementedVideoStreamServer) mustEmbedUnimplementedVideoStreamServer() {}
 
 // UnsafeVideoStreamServer may be embedded to opt out of forward compatibility for this service.
@@ -89,6 +103,24 @@ func _VideoStream_HandleVideoCreated_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _VideoStream_HandleVideoDeleted_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HandleVideoDeletedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoStreamServer).HandleVideoDeleted(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/video.pb.VideoStream/HandleVideoDeleted",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoStreamServer).HandleVideoDeleted(ctx, req.(*HandleVideoDeletedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // VideoStream_ServiceDesc is the grpc.ServiceDesc for VideoStream service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -100,6 +132,10 @@ var VideoStream_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "HandleVideoCreated",
 			Handler:    _VideoStream_HandleVideoCreated_Handler,
 		},
+		{
+			MethodName: "HandleVideoDeleted",
+			Handler:    _VideoStream_HandleVideoDeleted_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "modules/video/pb/stream.proto",
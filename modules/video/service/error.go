@@ -6,6 +6,32 @@ import (
 )
 
 var (
-	ErrInvalidObjectID = status.Errorf(codes.InvalidArgument, "invalid objectID")
-	ErrVideoNotFound   = status.Errorf(codes.NotFound, "video not found")
+	ErrInvalidObjectID        = status.Errorf(codes.InvalidArgument, "invalid objectID")
+	ErrDeletedVideoNotFound   = status.Errorf(codes.NotFound, "video not found in the trash")
+	ErrVideoNotFound          = status.Errorf(codes.NotFound, "video not found")
+	ErrPlaylistNotFound       = status.Errorf(codes.NotFound, "playlist not found, the video may not be transcoded yet")
+	ErrPreviewNotFound        = status.Errorf(codes.NotFound, "preview not found, the video may not be processed yet")
+	ErrVideoSizeMismatch      = status.Errorf(codes.InvalidArgument, "video size mismatch")
+	ErrInvalidVideo           = status.Errorf(codes.InvalidArgument, "invalid video, the file is not a MP4 video")
+	ErrInvalidUploadSize      = status.Errorf(codes.InvalidArgument, "invalid upload size")
+	ErrUploadSessionNotFound  = status.Errorf(codes.NotFound, "upload session not found")
+	ErrUploadSessionNotActive = status.Errorf(codes.FailedPrecondition, "upload session is not active")
+	ErrUploadOffsetMismatch   = status.Errorf(codes.Aborted, "upload offset mismatch")
+	ErrUploadIncomplete       = status.Errorf(codes.FailedPrecondition, "upload is incomplete")
+	ErrUploadSessionPresigned = status.Errorf(codes.FailedPrecondition, "upload session is uploaded by the presigned URL")
+	ErrPresignNotSupported    = status.Errorf(codes.Unimplemented, "presigned URL is not supported by the storage")
+	ErrInvalidTitle           = status.Errorf(codes.InvalidArgument, "invalid title, the title is at most 100 characters")
+	ErrInvalidDescription     = status.Errorf(codes.InvalidArgument, "invalid description, the description is at most 5000 characters")
+	ErrInvalidTags            = status.Errorf(codes.InvalidArgument, "invalid tags, there are at most 20 tags of at most 30 characters")
+	ErrInvalidLanguage        = status.Errorf(codes.InvalidArgument, "invalid language, the language must be a BCP 47 language tag")
+	ErrInvalidVisibility      = status.Errorf(codes.InvalidArgument, "invalid visibility, the visibility is public, unlisted or private, and a private video requires a signed-in user")
+	ErrInvalidUpdateMask      = status.Errorf(codes.InvalidArgument, "invalid update mask, the updatable fields are title, description, tags, language and visibility")
+	ErrUpdatedAtRequired      = status.Errorf(codes.InvalidArgument, "updated_at of the video to update is required")
+	ErrVideoUpdateConflict    = status.Errorf(codes.Aborted, "video has been updated, get the video and update again")
+	ErrInvalidListVideo       = status.Errorf(codes.InvalidArgument, "invalid sort field or status to list videos")
+	ErrInvalidSkip            = status.Errorf(codes.InvalidArgument, "invalid skip, skip cannot be combined with a page token")
+	ErrInvalidSearchVideo     = status.Errorf(codes.InvalidArgument, "invalid query or status to search videos")
+	ErrInvalidPageToken       = status.Errorf(codes.InvalidArgument, "invalid page token, the query, the sorting and the filters must be the same as the previous page")
+	ErrSignInRequired         = status.Errorf(codes.Unauthenticated, "a signed-in user is required")
+	ErrNotVideoOwner          = status.Errorf(codes.PermissionDenied, "only the owner can modify the video")
 )
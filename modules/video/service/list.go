@@ -0,0 +1,88 @@
+package service
+
+import (
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/dao"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/pb"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+var videoSortFields = map[pb.VideoSortField]dao.VideoSortField{
+	pb.VideoSortField_VIDEO_SORT_FIELD_CREATED_AT: dao.VideoSortCreatedAt,
+	pb.VideoSortField_VIDEO_SORT_FIELD_UPDATED_AT: dao.VideoSortUpdatedAt,
+	pb.VideoSortField_VIDEO_SORT_FIELD_SIZE:       dao.VideoSortSize,
+	pb.VideoSortField_VIDEO_SORT_FIELD_DURATION:   dao.VideoSortDuration,
+}
+
+var videoStatuses = map[string]dao.VideoStatus{
+	dao.VideoStatusUploaded.String(): dao.VideoStatusUploaded,
+	dao.VideoStatusEncoding.String(): dao.VideoStatusEncoding,
+	dao.VideoStatusFailed.String():   dao.VideoStatusFailed,
+	dao.VideoStatusSuccess.String():  dao.VideoStatusSuccess,
+}
+
+// listVideoOptions validates the list request and fills the defaults
+func listVideoOptions(req *pb.ListVideoRequest) (*dao.ListVideoOptions, error) {
+	limit := pageSize(req.GetLimit())
+
+	// the page token continues after the videos skipped by the first page, skipping again would drop videos
+	if req.GetSkip() != 0 && req.GetPageToken() != "" {
+		return nil, ErrInvalidSkip
+	}
+
+	sortBy, ok := videoSortFields[req.GetSortBy()]
+	if !ok {
+		return nil, ErrInvalidListVideo
+	}
+
+	statuses, ok := parseVideoStatuses(req.GetStatuses())
+	if !ok {
+		return nil, ErrInvalidListVideo
+	}
+
+	// the tags are stored normalized
+	tags, err := normalizeTags(req.GetTags())
+	if err != nil {
+		return nil, err
+	}
+
+	return &dao.ListVideoOptions{
+		Limit:     limit,
+		Skip:      req.GetSkip(),
+		SortBy:    sortBy,
+		Ascending: req.GetOrder() == pb.SortOrder_SORT_ORDER_ASC,
+		Statuses:  statuses,
+		Tags:      tags,
+		PageToken: req.GetPageToken(),
+	}, nil
+}
+
+// pageSize bounds the limit of a page so a page is never the whole collection
+func pageSize(limit int64) int64 {
+	if limit <= 0 {
+		return defaultPageSize
+	}
+	if limit > maxPageSize {
+		return maxPageSize
+	}
+
+	return limit
+}
+
+// parseVideoStatuses returns false if any of the statuses is unknown
+func parseVideoStatuses(ss []string) ([]dao.VideoStatus, bool) {
+	statuses := make([]dao.VideoStatus, 0, len(ss))
+	for _, s := range ss {
+		status, ok := videoStatuses[s]
+		if !ok {
+			return nil, false
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses, true
+}
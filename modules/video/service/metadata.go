@@ -0,0 +1,166 @@
+package service
+
+import (
+	"path"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/dao"
+	"golang.org/x/text/language"
+)
+
+const (
+	maxTitleLength       = 100
+	maxDescriptionLength = 5000
+	maxTags              = 20
+	maxTagLength         = 30
+)
+
+// updatableVideoFields maps the paths of the update mask to the fields of the video metadata
+var updatableVideoFields = map[string]string{
+	"title":       dao.VideoFieldTitle,
+	"description": dao.VideoFieldDescription,
+	"tags":        dao.VideoFieldTags,
+	"language":    dao.VideoFieldLanguage,
+	"visibility":  dao.VideoFieldVisibility,
+}
+
+// defaultTitle returns the filename without the extension as the title of an upload without a title
+func defaultTitle(filename string) string {
+	title := strings.TrimSuffix(path.Base(filename), path.Ext(filename))
+	if utf8.RuneCountInString(title) > maxTitleLength {
+		title = string([]rune(title)[:maxTitleLength])
+	}
+
+	return title
+}
+
+func normalizeTitle(title string) (string, error) {
+	title = strings.TrimSpace(title)
+	if utf8.RuneCountInString(title) > maxTitleLength {
+		return "", ErrInvalidTitle
+	}
+
+	return title, nil
+}
+
+func normalizeDescription(description string) (string, error) {
+	description = strings.TrimSpace(description)
+	if utf8.RuneCountInString(description) > maxDescriptionLength {
+		return "", ErrInvalidDescription
+	}
+
+	return description, nil
+}
+
+// normalizeTags lowercases the tags and removes the empty and the duplicated tags in order
+func normalizeTags(tags []string) ([]string, error) {
+	seen := make(map[string]struct{}, len(tags))
+	normalized := make([]string, 0, len(tags))
+
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			continue
+		}
+
+		if utf8.RuneCountInString(tag) > maxTagLength {
+			return nil, ErrInvalidTags
+		}
+
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+
+		seen[tag] = struct{}{}
+		normalized = append(normalized, tag)
+	}
+
+	if len(normalized) > maxTags {
+		return nil, ErrInvalidTags
+	}
+
+	return normalized, nil
+}
+
+// normalizeLanguage returns the canonical BCP 47 tag of the language, e.g. `zh-tw` becomes `zh-TW`
+func normalizeLanguage(lang string) (string, error) {
+	lang = strings.TrimSpace(lang)
+	if lang == "" {
+		return "", nil
+	}
+
+	tag, err := language.Parse(lang)
+	if err != nil {
+		return "", ErrInvalidLanguage
+	}
+
+	return tag.String(), nil
+}
+
+// normalizeVisibility defaults the empty visibility to public
+func normalizeVisibility(visibility string) (dao.VideoVisibility, error) {
+	switch v := dao.VideoVisibility(strings.ToLower(strings.TrimSpace(visibility))); v {
+	case "":
+		return dao.VideoVisibilityPublic, nil
+	case dao.VideoVisibilityPublic, dao.VideoVisibilityUnlisted, dao.VideoVisibilityPrivate:
+		return v, nil
+	default:
+		return "", ErrInvalidVisibility
+	}
+}
+
+// newVideoMetadata validates and normalizes the metadata of the fields, the other fields are left empty
+func newVideoMetadata(title, description string, tags []string, lang, visibility string, fields []string) (*dao.VideoMetadata, error) {
+	var (
+		metadata dao.VideoMetadata
+		err      error
+	)
+
+	for _, field := range fields {
+		switch field {
+		case dao.VideoFieldTitle:
+			metadata.Title, err = normalizeTitle(title)
+		case dao.VideoFieldDescription:
+			metadata.Description, err = normalizeDescription(description)
+		case dao.VideoFieldTags:
+			metadata.Tags, err = normalizeTags(tags)
+		case dao.VideoFieldLanguage:
+			metadata.Language, err = normalizeLanguage(lang)
+		case dao.VideoFieldVisibility:
+			metadata.Visibility, err = normalizeVisibility(visibility)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &metadata, nil
+}
+
+// updateMaskFields returns the fields of the video metadata in the paths of the update mask
+func updateMaskFields(paths []string) ([]string, error) {
+	if len(paths) == 0 {
+		return nil, ErrInvalidUpdateMask
+	}
+
+	seen := make(map[string]struct{}, len(paths))
+	fields := make([]string, 0, len(paths))
+
+	for _, p := range paths {
+		field, ok := updatableVideoFields[p]
+		if !ok {
+			return nil, ErrInvalidUpdateMask
+		}
+
+		if _, ok := seen[field]; ok {
+			continue
+		}
+
+		seen[field] = struct{}{}
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
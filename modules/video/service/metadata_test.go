@@ -0,0 +1,84 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/dao"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Metadata", func() {
+	Describe("defaultTitle", func() {
+		It("returns the filename without the extension", func() {
+			Expect(defaultTitle("big_buck_bunny.mp4")).To(Equal("big_buck_bunny"))
+			Expect(defaultTitle("dir/movie")).To(Equal("movie"))
+		})
+
+		It("truncates a long filename", func() {
+			Expect(defaultTitle(strings.Repeat("影", 120) + ".mp4")).To(Equal(strings.Repeat("影", 100)))
+		})
+	})
+
+	Describe("normalizeTags", func() {
+		It("lowercases and deduplicates the tags in order", func() {
+			Expect(normalizeTags([]string{" Music", "live", "music", ""})).To(Equal([]string{"music", "live"}))
+		})
+
+		It("rejects too many tags", func() {
+			tags := make([]string, 0, 21)
+			for i := 0; i < 21; i++ {
+				tags = append(tags, strings.Repeat("t", i+1))
+			}
+
+			_, err := normalizeTags(tags)
+			Expect(err).To(MatchError(ErrInvalidTags))
+		})
+
+		It("rejects a long tag", func() {
+			_, err := normalizeTags([]string{strings.Repeat("t", 31)})
+			Expect(err).To(MatchError(ErrInvalidTags))
+		})
+	})
+
+	Describe("normalizeLanguage", func() {
+		It("returns the canonical language tag", func() {
+			Expect(normalizeLanguage("zh-tw")).To(Equal("zh-TW"))
+			Expect(normalizeLanguage("")).To(Equal(""))
+		})
+
+		It("rejects an invalid language tag", func() {
+			_, err := normalizeLanguage("not a language")
+			Expect(err).To(MatchError(ErrInvalidLanguage))
+		})
+	})
+
+	Describe("normalizeVisibility", func() {
+		It("defaults to public", func() {
+			Expect(normalizeVisibility("")).To(Equal(dao.VideoVisibilityPublic))
+			Expect(normalizeVisibility(" Private ")).To(Equal(dao.VideoVisibilityPrivate))
+		})
+
+		It("rejects an unknown visibility", func() {
+			_, err := normalizeVisibility("friends")
+			Expect(err).To(MatchError(ErrInvalidVisibility))
+		})
+	})
+
+	Describe("updateMaskFields", func() {
+		It("returns the fields of the paths without duplicates", func() {
+			Expect(updateMaskFields([]string{"tags", "title", "tags"})).To(Equal([]string{"tags", "title"}))
+		})
+
+		It("rejects an empty mask", func() {
+			_, err := updateMaskFields(nil)
+			Expect(err).To(MatchError(ErrInvalidUpdateMask))
+		})
+
+		It("rejects a path not updatable", func() {
+			_, err := updateMaskFields([]string{"title", "object_name"})
+			Expect(err).To(MatchError(ErrInvalidUpdateMask))
+		})
+	})
+})
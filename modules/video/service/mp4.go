@@ -0,0 +1,366 @@
+package service
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// mp4Info is the metadata of a MP4 (ISO base media file format) video
+type mp4Info struct {
+	Width    uint32
+	Height   uint32
+	Duration float64
+	// Codec is the sample entry type of the video track, e.g. `avc1` for H.264
+	Codec string
+	// Bitrate is the average bitrate of the whole file in bits per second
+	Bitrate uint64
+}
+
+var errInvalidMP4 = errors.New("invalid MP4 file")
+
+const (
+	// mp4MaxMoovSize bounds the movie box read into memory, which only keeps the sample tables
+	mp4MaxMoovSize = 64 << 20
+
+	mp4HandlerVideo = "vide"
+)
+
+// mp4Box is a box read from a movie box in memory, data excludes the box header
+type mp4Box struct {
+	typ  string
+	data []byte
+}
+
+type mp4Track struct {
+	handler   string
+	width     uint32
+	height    uint32
+	codec     string
+	timescale uint32
+	duration  uint64
+}
+
+// parseMP4 reads the metadata of the MP4 file. Only the top-level box headers and the movie box
+// are read, so the media data is never loaded and the movie box may be at either end of the file.
+func parseMP4(r io.ReaderAt, size int64) (*mp4Info, error) {
+	var (
+		ftyp bool
+		moov []byte
+	)
+
+	// every box header is a ranged read of the storage, so the boxes after the file type and the movie are not read,
+	// e.g. the thousands of fragments of a fragmented MP4
+	for offset := int64(0); offset < size && !(ftyp && moov != nil); {
+		typ, headerSize, boxSize, err := readMP4BoxHeader(r, offset, size)
+		if err != nil {
+			return nil, err
+		}
+
+		switch typ {
+		case "ftyp":
+			ftyp = true
+		case "moov":
+			if boxSize-headerSize > mp4MaxMoovSize {
+				return nil, fmt.Errorf("%w: movie box of %d bytes is too large", errInvalidMP4, boxSize)
+			}
+
+			moov = make([]byte, boxSize-headerSize)
+			if err := readMP4At(r, moov, offset+headerSize); err != nil {
+				return nil, err
+			}
+		}
+
+		offset += boxSize
+	}
+
+	if !ftyp {
+		return nil, fmt.Errorf("%w: file type box not found", errInvalidMP4)
+	}
+
+	if moov == nil {
+		return nil, fmt.Errorf("%w: movie box not found", errInvalidMP4)
+	}
+
+	info, err := parseMP4Moov(moov)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.Duration > 0 {
+		info.Bitrate = uint64(float64(size*8) / info.Duration)
+	}
+
+	return info, nil
+}
+
+// readMP4BoxHeader reads the type, the header size and the box size of the box at the offset
+func readMP4BoxHeader(r io.ReaderAt, offset int64, size int64) (string, int64, int64, error) {
+	var header [16]byte
+
+	if size-offset < 8 {
+		return "", 0, 0, fmt.Errorf("%w: truncated box at %d", errInvalidMP4, offset)
+	}
+
+	if err := readMP4At(r, header[:8], offset); err != nil {
+		return "", 0, 0, err
+	}
+
+	typ := string(header[4:8])
+	headerSize := int64(8)
+	boxSize := int64(binary.BigEndian.Uint32(header[:4]))
+
+	switch boxSize {
+	case 0:
+		// the box extends to the end of the file
+		boxSize = size - offset
+	case 1:
+		// the size is in the 64-bit large size field after the type
+		if size-offset < 16 {
+			return "", 0, 0, fmt.Errorf("%w: truncated box at %d", errInvalidMP4, offset)
+		}
+
+		if err := readMP4At(r, header[8:16], offset+8); err != nil {
+			return "", 0, 0, err
+		}
+
+		headerSize = 16
+		boxSize = int64(binary.BigEndian.Uint64(header[8:16]))
+	}
+
+	if !validMP4BoxType(typ) || boxSize < headerSize || boxSize > size-offset {
+		return "", 0, 0, fmt.Errorf("%w: malformed box %q at %d", errInvalidMP4, typ, offset)
+	}
+
+	return typ, headerSize, boxSize, nil
+}
+
+// readMP4At fills the buffer from the offset, a reader at may return io.EOF along with a full buffer at the end of the file
+func readMP4At(r io.ReaderAt, buf []byte, offset int64) error {
+	n, err := r.ReadAt(buf, offset)
+	if n == len(buf) {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %v", errInvalidMP4, err)
+}
+
+// mp4Boxes splits the data of a container box into the child boxes
+func mp4Boxes(data []byte) ([]*mp4Box, error) {
+	boxes := make([]*mp4Box, 0)
+
+	for len(data) > 0 {
+		if len(data) < 8 {
+			return nil, fmt.Errorf("%w: truncated box", errInvalidMP4)
+		}
+
+		typ := string(data[4:8])
+		headerSize := uint64(8)
+		boxSize := uint64(binary.BigEndian.Uint32(data[:4]))
+
+		switch boxSize {
+		case 0:
+			boxSize = uint64(len(data))
+		case 1:
+			if len(data) < 16 {
+				return nil, fmt.Errorf("%w: truncated box %q", errInvalidMP4, typ)
+			}
+
+			headerSize = 16
+			boxSize = binary.BigEndian.Uint64(data[8:16])
+		}
+
+		if !validMP4BoxType(typ) || boxSize < headerSize || boxSize > uint64(len(data)) {
+			return nil, fmt.Errorf("%w: malformed box %q", errInvalidMP4, typ)
+		}
+
+		boxes = append(boxes, &mp4Box{typ: typ, data: data[headerSize:boxSize]})
+		data = data[boxSize:]
+	}
+
+	return boxes, nil
+}
+
+func parseMP4Moov(data []byte) (*mp4Info, error) {
+	boxes, err := mp4Boxes(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		timescale uint32
+		duration  uint64
+		video     *mp4Track
+	)
+
+	for _, box := range boxes {
+		switch box.typ {
+		case "mvhd":
+			if timescale, duration, err = parseMP4Duration(box.data); err != nil {
+				return nil, err
+			}
+		case "trak":
+			track, err := parseMP4Track(box.data)
+			if err != nil {
+				return nil, err
+			}
+
+			if track.handler == mp4HandlerVideo && video == nil {
+				video = track
+			}
+		}
+	}
+
+	if video == nil {
+		return nil, fmt.Errorf("%w: video track not found", errInvalidMP4)
+	}
+
+	// the movie duration covers all tracks, the video track duration is used if the movie does not tell
+	if duration == 0 || timescale == 0 {
+		timescale, duration = video.timescale, video.duration
+	}
+
+	info := &mp4Info{
+		Width:  video.width,
+		Height: video.height,
+		Codec:  video.codec,
+	}
+
+	if timescale > 0 {
+		info.Duration = float64(duration) / float64(timescale)
+	}
+
+	return info, nil
+}
+
+func parseMP4Track(data []byte) (*mp4Track, error) {
+	track := &mp4Track{}
+
+	if err := walkMP4Track(data, track); err != nil {
+		return nil, err
+	}
+
+	return track, nil
+}
+
+// walkMP4Track collects the track metadata from the track box and the nested media boxes
+func walkMP4Track(data []byte, track *mp4Track) error {
+	boxes, err := mp4Boxes(data)
+	if err != nil {
+		return err
+	}
+
+	for _, box := range boxes {
+		switch box.typ {
+		case "mdia", "minf", "stbl":
+			if err := walkMP4Track(box.data, track); err != nil {
+				return err
+			}
+		case "tkhd":
+			if err := parseMP4Tkhd(box.data, track); err != nil {
+				return err
+			}
+		case "mdhd":
+			if track.timescale, track.duration, err = parseMP4Duration(box.data); err != nil {
+				return err
+			}
+		case "hdlr":
+			// version and flags, pre-defined, then the handler type
+			if len(box.data) < 12 {
+				return fmt.Errorf("%w: truncated handler box", errInvalidMP4)
+			}
+			track.handler = string(box.data[8:12])
+		case "stsd":
+			if err := parseMP4Stsd(box.data, track); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// parseMP4Duration reads the timescale and the duration of a movie header or a media header box
+func parseMP4Duration(data []byte) (uint32, uint64, error) {
+	if len(data) < 4 {
+		return 0, 0, fmt.Errorf("%w: truncated header box", errInvalidMP4)
+	}
+
+	// version 1 has 64-bit creation time, modification time and duration
+	if data[0] == 1 {
+		if len(data) < 32 {
+			return 0, 0, fmt.Errorf("%w: truncated header box", errInvalidMP4)
+		}
+
+		return binary.BigEndian.Uint32(data[20:24]), binary.BigEndian.Uint64(data[24:32]), nil
+	}
+
+	if len(data) < 20 {
+		return 0, 0, fmt.Errorf("%w: truncated header box", errInvalidMP4)
+	}
+
+	return binary.BigEndian.Uint32(data[12:16]), uint64(binary.BigEndian.Uint32(data[16:20])), nil
+}
+
+// parseMP4Tkhd reads the presentation size of the track, which is a 16.16 fixed-point number at the end of the box
+func parseMP4Tkhd(data []byte, track *mp4Track) error {
+	offset := 76
+	if len(data) > 0 && data[0] == 1 {
+		offset = 88
+	}
+
+	if len(data) < offset+8 {
+		return fmt.Errorf("%w: truncated track header box", errInvalidMP4)
+	}
+
+	track.width = binary.BigEndian.Uint32(data[offset:offset+4]) >> 16
+	track.height = binary.BigEndian.Uint32(data[offset+4:offset+8]) >> 16
+
+	return nil
+}
+
+// parseMP4Stsd reads the codec from the first sample entry, the size of a visual sample entry
+// is used if the track header has no presentation size
+func parseMP4Stsd(data []byte, track *mp4Track) error {
+	// version and flags, entry count, then the sample entries
+	if len(data) < 8 {
+		return fmt.Errorf("%w: truncated sample description box", errInvalidMP4)
+	}
+
+	entries, err := mp4Boxes(data[8:])
+	if err != nil {
+		return err
+	}
+
+	if len(entries) == 0 {
+		return nil
+	}
+
+	entry := entries[0]
+	track.codec = entry.typ
+
+	// reserved, data reference index, pre-defined and reserved, then the width and the height
+	if track.handler == mp4HandlerVideo && (track.width == 0 || track.height == 0) && len(entry.data) >= 28 {
+		track.width = uint32(binary.BigEndian.Uint16(entry.data[24:26]))
+		track.height = uint32(binary.BigEndian.Uint16(entry.data[26:28]))
+	}
+
+	return nil
+}
+
+// validMP4BoxType reports whether the box type is four printable characters, which rejects random bytes early
+func validMP4BoxType(typ string) bool {
+	if len(typ) != 4 {
+		return false
+	}
+
+	for i := 0; i < len(typ); i++ {
+		// some box types such as `©nam` use a byte out of ASCII
+		if typ[i] < 0x20 || typ[i] == 0x7f {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"bytes"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("parseMP4", func() {
+	var (
+		file []byte
+		data []byte
+
+		info *mp4Info
+		err  error
+	)
+
+	BeforeEach(func() {
+		var rerr error
+		file, rerr = os.ReadFile("./fixtures/big_buck_bunny_240p_1mb.mp4")
+		Expect(rerr).NotTo(HaveOccurred())
+
+		data = file
+	})
+
+	JustBeforeEach(func() {
+		info, err = parseMP4(bytes.NewReader(data), int64(len(data)))
+	})
+
+	When("success", func() {
+		It("returns the metadata of the video track", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info).To(Equal(&mp4Info{
+				Width:    320,
+				Height:   240,
+				Duration: 13.696,
+				Codec:    "avc1",
+				Bitrate:  615450,
+			}))
+		})
+	})
+
+	When("file has boxes after the file type and the movie boxes", func() {
+		BeforeEach(func() {
+			// the trailing bytes are not a valid box, which fails the parsing if they are read
+			data = append(append([]byte{}, file...), 0, 0, 0)
+		})
+
+		It("returns the metadata without reading the trailing boxes", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.Width).To(Equal(uint32(320)))
+			Expect(info.Height).To(Equal(uint32(240)))
+		})
+	})
+
+	When("file is empty", func() {
+		BeforeEach(func() { data = nil })
+
+		It("returns invalid MP4 error", func() {
+			Expect(err).To(MatchError(errInvalidMP4))
+		})
+	})
+
+	When("file is not a MP4 file", func() {
+		BeforeEach(func() { data = []byte("this is a text file rather than a video") })
+
+		It("returns invalid MP4 error", func() {
+			Expect(err).To(MatchError(errInvalidMP4))
+		})
+	})
+
+	When("file is truncated before the movie box", func() {
+		BeforeEach(func() { data = file[:len(file)/2] })
+
+		It("returns invalid MP4 error", func() {
+			Expect(err).To(MatchError(errInvalidMP4))
+		})
+	})
+
+	When("file type box is missing", func() {
+		BeforeEach(func() {
+			// the fixture starts with a 32-byte file type box
+			data = file[32:]
+		})
+
+		It("returns invalid MP4 error", func() {
+			Expect(err).To(MatchError(errInvalidMP4))
+		})
+	})
+
+	When("file has no video track", func() {
+		BeforeEach(func() {
+			data = bytes.ReplaceAll(file, []byte("vide"), []byte("text"))
+		})
+
+		It("returns invalid MP4 error", func() {
+			Expect(err).To(MatchError(errInvalidMP4))
+		})
+	})
+})
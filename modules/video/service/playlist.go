@@ -0,0 +1,240 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/pb"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/storagekit"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+)
+
+const (
+	hlsPlaylistName    = "index.m3u8"
+	hlsContentType     = "application/vnd.apple.mpegurl"
+	previewContentType = "text/vtt"
+)
+
+// hlsURIAttribute matches the URI attribute of the HLS tags such as `#EXT-X-MAP:URI="init.mp4"`
+var hlsURIAttribute = regexp.MustCompile(`URI="([^"]*)"`)
+
+// manifestPath returns the path of the master playlist served by the API, the media playlists are referenced relative
+// to it, e.g. `720p/index.m3u8` is served by GetVideoPlaylist at `/v1/videos/{id}/hls/720p/index.m3u8`.
+func manifestPath(id primitive.ObjectID) string {
+	return "/v1/videos/" + id.Hex() + "/hls/master.m3u8"
+}
+
+// previewPath returns the path of the WebVTT index of the sprite sheet served by the API
+func previewPath(id primitive.ObjectID) string {
+	return "/v1/videos/" + id.Hex() + "/preview.vtt"
+}
+
+// GetVideoManifest serves the HLS master playlist of the video, the stored objects are in a private bucket
+// so the media playlists are referenced by the API as well, which references the segments by presigned URLs.
+func (s *service) GetVideoManifest(ctx context.Context, req *pb.GetVideoManifestRequest) (*httpbody.HttpBody, error) {
+	id, err := primitive.ObjectIDFromHex(req.GetId())
+	if err != nil {
+		return nil, ErrInvalidObjectID
+	}
+
+	video, err := s.getViewableVideo(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if video.ManifestObjectName == "" {
+		return nil, ErrPlaylistNotFound
+	}
+
+	variants := make(map[string]string, len(video.Playlists))
+	for variant, playlist := range video.Playlists {
+		variants[playlist.ObjectName] = variant
+	}
+
+	data, err := s.rewritePlaylist(ctx, video.ManifestObjectName, func(objectName string) (string, error) {
+		if variant, ok := variants[objectName]; ok {
+			return variant + "/" + hlsPlaylistName, nil
+		}
+
+		return s.urlBuilder.ObjectURL(ctx, objectName)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &httpbody.HttpBody{ContentType: hlsContentType, Data: data}, nil
+}
+
+// GetVideoPlaylist serves the HLS media playlist of the variant with the segments referenced by presigned URLs
+func (s *service) GetVideoPlaylist(ctx context.Context, req *pb.GetVideoPlaylistRequest) (*httpbody.HttpBody, error) {
+	id, err := primitive.ObjectIDFromHex(req.GetId())
+	if err != nil {
+		return nil, ErrInvalidObjectID
+	}
+
+	video, err := s.getViewableVideo(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	playlist, ok := video.Playlists[req.GetVariant()]
+	if !ok {
+		return nil, ErrPlaylistNotFound
+	}
+
+	data, err := s.rewritePlaylist(ctx, playlist.ObjectName, func(objectName string) (string, error) {
+		return s.urlBuilder.ObjectURL(ctx, objectName)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &httpbody.HttpBody{ContentType: hlsContentType, Data: data}, nil
+}
+
+// GetVideoPreview serves the WebVTT index of the sprite sheet with the sprite sheet referenced by a presigned URL,
+// the media fragment selecting the tile of each cue is kept.
+func (s *service) GetVideoPreview(ctx context.Context, req *pb.GetVideoPreviewRequest) (*httpbody.HttpBody, error) {
+	id, err := primitive.ObjectIDFromHex(req.GetId())
+	if err != nil {
+		return nil, ErrInvalidObjectID
+	}
+
+	video, err := s.getViewableVideo(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if video.Thumbnail == nil || video.Thumbnail.PreviewObjectName == "" {
+		return nil, ErrPreviewNotFound
+	}
+
+	previewObjectName := video.Thumbnail.PreviewObjectName
+
+	data, err := s.readObject(ctx, previewObjectName)
+	if err != nil {
+		if errors.Is(err, storagekit.ErrObjectNotFound) {
+			return nil, ErrPreviewNotFound
+		}
+
+		return nil, err
+	}
+
+	// the cues reference the sprite sheet by the same URL, so it is presigned once
+	urls := make(map[string]string)
+
+	data, err = rewriteLines(data, func(line string) (string, error) {
+		ref, fragment, ok := strings.Cut(line, "#xywh=")
+		if !ok || isAbsoluteURI(ref) {
+			return line, nil
+		}
+
+		url, ok := urls[ref]
+		if !ok {
+			if url, err = s.urlBuilder.ObjectURL(ctx, resolveObjectName(previewObjectName, ref)); err != nil {
+				return "", err
+			}
+
+			urls[ref] = url
+		}
+
+		return url + "#xywh=" + fragment, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &httpbody.HttpBody{ContentType: previewContentType, Data: data}, nil
+}
+
+// rewritePlaylist reads the playlist and rewrites the URIs, which are resolved against the playlist to object names
+func (s *service) rewritePlaylist(ctx context.Context, playlistObjectName string, rewrite func(objectName string) (string, error)) ([]byte, error) {
+	data, err := s.readObject(ctx, playlistObjectName)
+	if err != nil {
+		if errors.Is(err, storagekit.ErrObjectNotFound) {
+			return nil, ErrPlaylistNotFound
+		}
+
+		return nil, err
+	}
+
+	rewriteURI := func(uri string) (string, error) {
+		if isAbsoluteURI(uri) {
+			return uri, nil
+		}
+
+		return rewrite(resolveObjectName(playlistObjectName, uri))
+	}
+
+	return rewriteLines(data, func(line string) (string, error) {
+		if line == "" {
+			return line, nil
+		}
+
+		if !strings.HasPrefix(line, "#") {
+			return rewriteURI(line)
+		}
+
+		var rewriteErr error
+		line = hlsURIAttribute.ReplaceAllStringFunc(line, func(attr string) string {
+			uri, err := rewriteURI(hlsURIAttribute.FindStringSubmatch(attr)[1])
+			if err != nil {
+				rewriteErr = err
+				return attr
+			}
+
+			return `URI="` + uri + `"`
+		})
+
+		return line, rewriteErr
+	})
+}
+
+func (s *service) readObject(ctx context.Context, objectName string) ([]byte, error) {
+	reader, err := s.storage.GetObject(ctx, objectName, storagekit.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return io.ReadAll(reader)
+}
+
+// rewriteLines rewrites the data line by line
+func rewriteLines(data []byte, rewrite func(line string) (string, error)) ([]byte, error) {
+	var buf bytes.Buffer
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line, err := rewrite(strings.TrimSuffix(scanner.Text(), "\r"))
+		if err != nil {
+			return nil, err
+		}
+
+		buf.WriteString(line)
+		buf.WriteString("\n")
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// resolveObjectName resolves the URI referenced by the object to an object name
+func resolveObjectName(objectName string, uri string) string {
+	return path.Join(path.Dir(objectName), uri)
+}
+
+// isAbsoluteURI reports whether the URI is not relative to the referencing object, such as a URL with a scheme
+func isAbsoluteURI(uri string) bool {
+	return strings.Contains(uri, "://") || strings.HasPrefix(uri, "/") || strings.HasPrefix(uri, "data:")
+}
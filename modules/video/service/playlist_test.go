@@ -0,0 +1,270 @@
+package service
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/dao"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/mock/daomock"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/pb"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/logkit"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/storagekit"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/storagekit/mock/storagemock"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+)
+
+var _ = Describe("Playlist", func() {
+	var (
+		controller *gomock.Controller
+		videoDAO   *daomock.MockVideoDAO
+		storage    *storagemock.MockStorage
+		svc        *service
+		ctx        context.Context
+		id         primitive.ObjectID
+		video      *dao.Video
+	)
+
+	BeforeEach(func() {
+		controller = gomock.NewController(GinkgoT())
+		videoDAO = daomock.NewMockVideoDAO(controller)
+		storage = storagemock.NewMockStorage(controller)
+		urlBuilder := storagekit.NewURLBuilder(logkit.WithContext(context.Background(), logkit.NewNopLogger()), &storagekit.URLConfig{}, storage)
+		svc = NewService(videoDAO, nil, storage, urlBuilder, nil)
+		ctx = context.Background()
+
+		video = dao.NewFakeVideo()
+		id = video.ID
+	})
+
+	AfterEach(func() {
+		controller.Finish()
+	})
+
+	expectStoredPlaylist := func(objectName string, data string) {
+		storage.EXPECT().GetObject(ctx, objectName, storagekit.GetObjectOptions{}).Return(io.NopCloser(strings.NewReader(data)), nil)
+	}
+
+	Describe("GetVideoManifest", func() {
+		var (
+			req  *pb.GetVideoManifestRequest
+			resp *httpbody.HttpBody
+			err  error
+		)
+
+		BeforeEach(func() {
+			req = &pb.GetVideoManifestRequest{Id: id.Hex()}
+		})
+
+		JustBeforeEach(func() {
+			resp, err = svc.GetVideoManifest(ctx, req)
+		})
+
+		When("video is not transcoded yet", func() {
+			BeforeEach(func() {
+				video.ManifestObjectName = ""
+				videoDAO.EXPECT().Get(ctx, id).Return(video, nil)
+			})
+
+			It("returns playlist not found error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrPlaylistNotFound))
+			})
+		})
+
+		When("video is private to another user", func() {
+			BeforeEach(func() {
+				video.Visibility = dao.VideoVisibilityPrivate
+				video.OwnerID = "another user"
+				videoDAO.EXPECT().Get(ctx, id).Return(video, nil)
+			})
+
+			It("returns video not found error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrVideoNotFound))
+			})
+		})
+
+		When("playlist is not stored", func() {
+			BeforeEach(func() {
+				videoDAO.EXPECT().Get(ctx, id).Return(video, nil)
+				storage.EXPECT().GetObject(ctx, video.ManifestObjectName, gomock.Any()).Return(nil, storagekit.ErrObjectNotFound)
+			})
+
+			It("returns playlist not found error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrPlaylistNotFound))
+			})
+		})
+
+		When("success", func() {
+			BeforeEach(func() {
+				videoDAO.EXPECT().Get(ctx, id).Return(video, nil)
+				expectStoredPlaylist(video.ManifestObjectName, "#EXTM3U\n"+
+					"#EXT-X-VERSION:7\n"+
+					"#EXT-X-STREAM-INF:BANDWIDTH=5000000,RESOLUTION=1920x1080\n"+
+					"1080p/index.m3u8\n"+
+					"#EXT-X-STREAM-INF:BANDWIDTH=2800000,RESOLUTION=1280x720\n"+
+					"720p/index.m3u8\n",
+				)
+			})
+
+			It("references the media playlists served by the API", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp).To(Equal(&httpbody.HttpBody{
+					ContentType: "application/vnd.apple.mpegurl",
+					Data: []byte("#EXTM3U\n" +
+						"#EXT-X-VERSION:7\n" +
+						"#EXT-X-STREAM-INF:BANDWIDTH=5000000,RESOLUTION=1920x1080\n" +
+						"1080p/index.m3u8\n" +
+						"#EXT-X-STREAM-INF:BANDWIDTH=2800000,RESOLUTION=1280x720\n" +
+						"720p/index.m3u8\n",
+					),
+				}))
+			})
+		})
+	})
+
+	Describe("GetVideoPlaylist", func() {
+		var (
+			req  *pb.GetVideoPlaylistRequest
+			resp *httpbody.HttpBody
+			err  error
+		)
+
+		BeforeEach(func() {
+			req = &pb.GetVideoPlaylistRequest{Id: id.Hex(), Variant: "720p"}
+		})
+
+		JustBeforeEach(func() {
+			resp, err = svc.GetVideoPlaylist(ctx, req)
+		})
+
+		When("variant is not transcoded", func() {
+			BeforeEach(func() {
+				req.Variant = "480p"
+				videoDAO.EXPECT().Get(ctx, id).Return(video, nil)
+			})
+
+			It("returns playlist not found error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrPlaylistNotFound))
+			})
+		})
+
+		When("storage error", func() {
+			BeforeEach(func() {
+				videoDAO.EXPECT().Get(ctx, id).Return(video, nil)
+				expectStoredPlaylist(video.Playlists["720p"].ObjectName, "#EXTM3U\n"+
+					"#EXT-X-MAP:URI=\"init.mp4\"\n",
+				)
+				storage.EXPECT().PresignedGetObject(ctx, gomock.Any()).Return(nil, errStorageUnknown)
+			})
+
+			It("returns the error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(errStorageUnknown))
+			})
+		})
+
+		When("success", func() {
+			BeforeEach(func() {
+				videoDAO.EXPECT().Get(ctx, id).Return(video, nil)
+				expectStoredPlaylist(video.Playlists["720p"].ObjectName, "#EXTM3U\n"+
+					"#EXT-X-TARGETDURATION:4\n"+
+					"#EXT-X-MAP:URI=\"init.mp4\"\n"+
+					"#EXTINF:4.000,\n"+
+					"segment0.m4s\n"+
+					"#EXTINF:2.500,\n"+
+					"segment1.m4s\n"+
+					"#EXT-X-ENDLIST\n",
+				)
+				expectPresignedGetObject(storage)
+			})
+
+			It("references the segments by presigned URLs", func() {
+				prefix := id.Hex() + "-hls/720p/"
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp).To(Equal(&httpbody.HttpBody{
+					ContentType: "application/vnd.apple.mpegurl",
+					Data: []byte("#EXTM3U\n" +
+						"#EXT-X-TARGETDURATION:4\n" +
+						"#EXT-X-MAP:URI=\"" + presignedURL(prefix+"init.mp4") + "\"\n" +
+						"#EXTINF:4.000,\n" +
+						presignedURL(prefix+"segment0.m4s") + "\n" +
+						"#EXTINF:2.500,\n" +
+						presignedURL(prefix+"segment1.m4s") + "\n" +
+						"#EXT-X-ENDLIST\n",
+					),
+				}))
+			})
+		})
+	})
+
+	Describe("GetVideoPreview", func() {
+		var (
+			req  *pb.GetVideoPreviewRequest
+			resp *httpbody.HttpBody
+			err  error
+		)
+
+		BeforeEach(func() {
+			req = &pb.GetVideoPreviewRequest{Id: id.Hex()}
+		})
+
+		JustBeforeEach(func() {
+			resp, err = svc.GetVideoPreview(ctx, req)
+		})
+
+		When("thumbnails are not extracted yet", func() {
+			BeforeEach(func() {
+				video.Thumbnail = nil
+				videoDAO.EXPECT().Get(ctx, id).Return(video, nil)
+			})
+
+			It("returns preview not found error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrPreviewNotFound))
+			})
+		})
+
+		When("success", func() {
+			BeforeEach(func() {
+				videoDAO.EXPECT().Get(ctx, id).Return(video, nil)
+				expectStoredPlaylist(video.Thumbnail.PreviewObjectName, "WEBVTT\n"+
+					"\n"+
+					"00:00:00.000 --> 00:00:01.000\n"+
+					id.Hex()+"-sprite.jpg#xywh=0,0,160,90\n"+
+					"\n"+
+					"00:00:01.000 --> 00:00:01.500\n"+
+					id.Hex()+"-sprite.jpg#xywh=160,0,160,90\n",
+				)
+				storage.EXPECT().PresignedGetObject(ctx, video.Thumbnail.SpriteObjectName).Return(&storagekit.PresignedURL{
+					URL: presignedURL(video.Thumbnail.SpriteObjectName),
+				}, nil)
+			})
+
+			It("references the sprite sheet by a presigned URL", func() {
+				sprite := presignedURL(video.Thumbnail.SpriteObjectName)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp).To(Equal(&httpbody.HttpBody{
+					ContentType: "text/vtt",
+					Data: []byte("WEBVTT\n" +
+						"\n" +
+						"00:00:00.000 --> 00:00:01.000\n" +
+						sprite + "#xywh=0,0,160,90\n" +
+						"\n" +
+						"00:00:01.000 --> 00:00:01.500\n" +
+						sprite + "#xywh=160,0,160,90\n",
+					),
+				}))
+			})
+		})
+	})
+})
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/dao"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/storagekit"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// objectReaderAt reads the object by range requests, so parsing the container
+// metadata does not download the whole video from the storage
+type objectReaderAt struct {
+	ctx        context.Context
+	storage    storagekit.Storage
+	objectName string
+}
+
+var _ io.ReaderAt = (*objectReaderAt)(nil)
+
+func (r *objectReaderAt) ReadAt(p []byte, offset int64) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	reader, err := r.storage.GetObject(r.ctx, r.objectName, storagekit.GetObjectOptions{
+		Offset: offset,
+		Length: int64(len(p)),
+	})
+	if err != nil {
+		if errors.Is(err, storagekit.ErrInvalidRange) {
+			return 0, io.EOF
+		}
+
+		return 0, err
+	}
+	defer reader.Close()
+
+	n, err := io.ReadFull(reader, p)
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		err = io.EOF
+	}
+
+	return n, err
+}
+
+// probeVideo reads the metadata of the uploaded object and returns the video document to create,
+// ErrInvalidVideo is returned if the object is not a MP4 video
+func (s *service) probeVideo(ctx context.Context, id primitive.ObjectID, objectName string) (*dao.Video, error) {
+	object, err := s.storage.StatObject(ctx, objectName)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := parseMP4(&objectReaderAt{ctx: ctx, storage: s.storage, objectName: objectName}, object.Size)
+	if err != nil {
+		if errors.Is(err, errInvalidMP4) {
+			return nil, ErrInvalidVideo
+		}
+
+		return nil, err
+	}
+
+	return &dao.Video{
+		ID:         id,
+		Width:      info.Width,
+		Height:     info.Height,
+		Size:       uint64(object.Size),
+		Duration:   info.Duration,
+		Codec:      info.Codec,
+		Bitrate:    info.Bitrate,
+		ObjectName: objectName,
+		Status:     dao.VideoStatusUploaded,
+	}, nil
+}
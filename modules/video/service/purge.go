@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	commentpb "github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/comment/pb"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/dao"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/pb"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/kafkakit"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/logkit"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+type PurgeConfig struct {
+	Retention time.Duration `long:"retention" env:"RETENTION" description:"the duration to keep the deleted videos in the trash before they are purged" default:"720h"`
+	BatchSize int64         `long:"batch_size" env:"BATCH_SIZE" description:"the number of the deleted videos to list in a batch" default:"100"`
+}
+
+// purger purges the videos which have been in the trash longer than the retention,
+// the stored objects, the comments and then the document of a purged video are deleted.
+type purger struct {
+	videoDAO      dao.VideoDAO
+	commentClient commentpb.CommentClient
+	// videoDeletedProducer produces to the topic consumed by the stream worker to remove deleted objects
+	videoDeletedProducer kafkakit.Producer
+	retention            time.Duration
+	batchSize            int64
+}
+
+func NewPurger(videoDAO dao.VideoDAO, commentClient commentpb.CommentClient, videoDeletedProducer kafkakit.Producer, conf *PurgeConfig) *purger {
+	return &purger{
+		videoDAO:             videoDAO,
+		commentClient:        commentClient,
+		videoDeletedProducer: videoDeletedProducer,
+		retention:            conf.Retention,
+		batchSize:            conf.BatchSize,
+	}
+}
+
+// Purge purges the videos deleted before the retention and returns the number of the purged videos
+func (p *purger) Purge(ctx context.Context) (int, error) {
+	deletedBefore := time.Now().UTC().Add(-p.retention)
+
+	opts := &dao.ListVideoOptions{
+		Limit:         p.batchSize,
+		SortBy:        dao.VideoSortDeletedAt,
+		Ascending:     true,
+		Deleted:       true,
+		DeletedBefore: deletedBefore,
+	}
+
+	purged := 0
+	for {
+		page, err := p.videoDAO.List(ctx, opts)
+		if err != nil {
+			return purged, err
+		}
+
+		for _, video := range page.Videos {
+			if err := p.purgeVideo(ctx, video, deletedBefore); err != nil {
+				if errors.Is(err, dao.ErrVideoNotFound) {
+					logkit.FromContext(ctx).Info("skip the video restored during the purge", zap.String("id", video.ID.Hex()))
+					continue
+				}
+
+				return purged, err
+			}
+
+			purged++
+		}
+
+		// the page token stays valid after the videos of the page are deleted
+		if page.NextPageToken == "" {
+			return purged, nil
+		}
+
+		opts.PageToken = page.NextPageToken
+	}
+}
+
+// purgeVideo marks the video as purging first so it cannot be restored half purged, then the objects are removed
+// by the stream worker, which retries until the storage succeeds, and the comments are deleted. The document is
+// deleted last, so a failed purge is retried by the next run, which lists the purging video again.
+func (p *purger) purgeVideo(ctx context.Context, video *dao.Video, deletedBefore time.Time) error {
+	if err := p.videoDAO.StartPurge(ctx, video.ID, deletedBefore); err != nil {
+		return err
+	}
+
+	if err := p.produceVideoDeletedEvent(&pb.HandleVideoDeletedRequest{
+		Id:             video.ID.Hex(),
+		ObjectNames:    videoObjectNames(video),
+		ObjectPrefixes: videoObjectPrefixes(video),
+	}); err != nil {
+		return err
+	}
+
+	if _, err := p.commentClient.DeleteCommentByVideoID(ctx, &commentpb.DeleteCommentByVideoIDRequest{
+		VideoId: video.ID.Hex(),
+	}); err != nil {
+		return err
+	}
+
+	if err := p.videoDAO.Purge(ctx, video.ID, deletedBefore); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// videoObjectNames returns the distinct object names of the original video and all its variants
+func videoObjectNames(video *dao.Video) []string {
+	seen := make(map[string]struct{}, len(video.Variants)+1)
+	objectNames := make([]string, 0, len(video.Variants)+1)
+
+	add := func(objectName string) {
+		if _, ok := seen[objectName]; ok || objectName == "" {
+			return
+		}
+
+		seen[objectName] = struct{}{}
+		objectNames = append(objectNames, objectName)
+	}
+
+	add(video.ObjectName)
+	for _, objectName := range video.Variants {
+		add(objectName)
+	}
+
+	if thumbnail := video.Thumbnail; thumbnail != nil {
+		add(thumbnail.PosterObjectName)
+		add(thumbnail.SpriteObjectName)
+		add(thumbnail.PreviewObjectName)
+	}
+
+	sort.Strings(objectNames)
+
+	return objectNames
+}
+
+// videoObjectPrefixes returns the prefixes of the HLS playlists, which are removed with their segments.
+// The media playlists are usually under the directory of the master playlist, so the nested prefixes are dropped.
+func videoObjectPrefixes(video *dao.Video) []string {
+	dirs := make([]string, 0, len(video.Playlists)+1)
+	if video.ManifestObjectName != "" {
+		dirs = append(dirs, path.Dir(video.ManifestObjectName)+"/")
+	}
+	for _, playlist := range video.Playlists {
+		dirs = append(dirs, path.Dir(playlist.ObjectName)+"/")
+	}
+
+	sort.Strings(dirs)
+
+	prefixes := make([]string, 0, len(dirs))
+	for _, dir := range dirs {
+		// a prefix sorts before the prefixes nested in it
+		if len(prefixes) > 0 && strings.HasPrefix(dir, prefixes[len(prefixes)-1]) {
+			continue
+		}
+
+		prefixes = append(prefixes, dir)
+	}
+
+	return prefixes
+}
+
+func (p *purger) produceVideoDeletedEvent(req *pb.HandleVideoDeletedRequest) error {
+	valueBytes, err := proto.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	msgs := []*kafkakit.ProducerMessage{
+		{Value: valueBytes},
+	}
+
+	if err := p.videoDeletedProducer.SendMessages(msgs); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/comment/mock/pbmock"
+	commentpb "github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/comment/pb"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/dao"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/mock/daomock"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/pb"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/kafkakit"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/kafkakit/mock/kafkamock"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/logkit"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/proto"
+)
+
+var _ = Describe("Purger", func() {
+	var (
+		controller      *gomock.Controller
+		videoDAO        *daomock.MockVideoDAO
+		commentClient   *pbmock.MockCommentClient
+		deletedProducer *kafkamock.MockProducer
+		p               *purger
+		ctx             context.Context
+	)
+
+	BeforeEach(func() {
+		controller = gomock.NewController(GinkgoT())
+		videoDAO = daomock.NewMockVideoDAO(controller)
+		commentClient = pbmock.NewMockCommentClient(controller)
+		deletedProducer = kafkamock.NewMockProducer(controller)
+		p = NewPurger(videoDAO, commentClient, deletedProducer, &PurgeConfig{Retention: time.Hour, BatchSize: 2})
+		ctx = logkit.WithContext(context.Background(), logkit.NewNopLogger())
+	})
+
+	AfterEach(func() {
+		controller.Finish()
+	})
+
+	Describe("Purge", func() {
+		var (
+			videos []*dao.Video
+			before time.Time
+
+			purged int
+			err    error
+		)
+
+		BeforeEach(func() {
+			videos = []*dao.Video{dao.NewFakeVideo(), dao.NewFakeVideo(), dao.NewFakeVideo()}
+			before = time.Now().UTC().Add(-time.Hour)
+		})
+
+		JustBeforeEach(func() {
+			purged, err = p.Purge(ctx)
+		})
+
+		expectPurge := func(video *dao.Video) {
+			gomock.InOrder(
+				videoDAO.EXPECT().StartPurge(ctx, video.ID, gomock.Any()).Return(nil),
+				deletedProducer.EXPECT().SendMessages(gomock.Any()).Return(nil),
+				commentClient.EXPECT().DeleteCommentByVideoID(ctx, &commentpb.DeleteCommentByVideoIDRequest{
+					VideoId: video.ID.Hex(),
+				}),
+				videoDAO.EXPECT().Purge(ctx, video.ID, gomock.Any()).Return(nil),
+			)
+		}
+
+		When("DAO error", func() {
+			BeforeEach(func() {
+				videoDAO.EXPECT().List(ctx, gomock.Any()).Return(nil, errDAOUnknown)
+			})
+
+			It("returns the error", func() {
+				Expect(purged).To(BeZero())
+				Expect(err).To(MatchError(errDAOUnknown))
+			})
+		})
+
+		When("producer error", func() {
+			BeforeEach(func() {
+				videoDAO.EXPECT().List(ctx, gomock.Any()).Return(&dao.VideoPage{Videos: videos[:1]}, nil)
+				videoDAO.EXPECT().StartPurge(ctx, videos[0].ID, gomock.Any()).Return(nil)
+				deletedProducer.EXPECT().SendMessages(gomock.Any()).Return(errProducerUnknown)
+			})
+
+			It("returns the error and keeps the document for the retry", func() {
+				Expect(purged).To(BeZero())
+				Expect(err).To(MatchError(errProducerUnknown))
+			})
+		})
+
+		When("comment client error", func() {
+			BeforeEach(func() {
+				videoDAO.EXPECT().List(ctx, gomock.Any()).Return(&dao.VideoPage{Videos: videos[:1]}, nil)
+				videoDAO.EXPECT().StartPurge(ctx, videos[0].ID, gomock.Any()).Return(nil)
+				deletedProducer.EXPECT().SendMessages(gomock.Any()).Return(nil)
+				commentClient.EXPECT().DeleteCommentByVideoID(ctx, gomock.Any()).Return(nil, errCommentClientUnknown)
+			})
+
+			It("returns the error and keeps the document for the retry", func() {
+				Expect(purged).To(BeZero())
+				Expect(err).To(MatchError(errCommentClientUnknown))
+			})
+		})
+
+		When("video is restored during the purge", func() {
+			BeforeEach(func() {
+				videoDAO.EXPECT().List(ctx, gomock.Any()).Return(&dao.VideoPage{Videos: videos[:2]}, nil)
+				videoDAO.EXPECT().StartPurge(ctx, videos[0].ID, gomock.Any()).Return(dao.ErrVideoNotFound)
+				expectPurge(videos[1])
+			})
+
+			It("skips the restored video", func() {
+				Expect(purged).To(Equal(1))
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		When("success", func() {
+			var (
+				opts []*dao.ListVideoOptions
+				msgs [][]*kafkakit.ProducerMessage
+			)
+
+			BeforeEach(func() {
+				opts, msgs = nil, nil
+
+				record := func(_ context.Context, o *dao.ListVideoOptions) {
+					copied := *o
+					opts = append(opts, &copied)
+				}
+				gomock.InOrder(
+					videoDAO.EXPECT().List(ctx, gomock.Any()).Do(record).Return(&dao.VideoPage{Videos: videos[:2], NextPageToken: "next"}, nil),
+					videoDAO.EXPECT().List(ctx, gomock.Any()).Do(record).Return(&dao.VideoPage{Videos: videos[2:]}, nil),
+				)
+
+				for _, video := range videos {
+					videoDAO.EXPECT().StartPurge(ctx, video.ID, gomock.Any()).Return(nil)
+					videoDAO.EXPECT().Purge(ctx, video.ID, gomock.Any()).Return(nil)
+					commentClient.EXPECT().DeleteCommentByVideoID(ctx, &commentpb.DeleteCommentByVideoIDRequest{
+						VideoId: video.ID.Hex(),
+					})
+				}
+				deletedProducer.EXPECT().SendMessages(gomock.Any()).Times(len(videos)).DoAndReturn(func(m []*kafkakit.ProducerMessage) error {
+					msgs = append(msgs, m)
+					return nil
+				})
+			})
+
+			It("purges the videos deleted before the retention page by page", func() {
+				Expect(purged).To(Equal(len(videos)))
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(opts).To(HaveLen(2))
+				Expect(opts[0].Deleted).To(BeTrue())
+				Expect(opts[0].SortBy).To(Equal(dao.VideoSortDeletedAt))
+				Expect(opts[0].Limit).To(BeEquivalentTo(2))
+				Expect(opts[0].DeletedBefore).To(BeTemporally("~", before, time.Second))
+				Expect(opts[0].PageToken).To(BeEmpty())
+				Expect(opts[1].PageToken).To(Equal("next"))
+				Expect(opts[1].DeletedBefore).To(Equal(opts[0].DeletedBefore))
+			})
+
+			It("produces the video deleted events with all object names", func() {
+				Expect(msgs).To(HaveLen(len(videos)))
+
+				var event pb.HandleVideoDeletedRequest
+				Expect(proto.Unmarshal(msgs[0][0].Value, &event)).To(Succeed())
+				Expect(event.GetId()).To(Equal(videos[0].ID.Hex()))
+				Expect(event.GetObjectNames()).To(Equal(videoObjectNames(videos[0])))
+			})
+		})
+	})
+
+	Describe("videoObjectNames and videoObjectPrefixes", func() {
+		It("returns all the objects of the video", func() {
+			video := dao.NewFakeVideo()
+			id := video.ID.Hex()
+			video.ObjectName = id + "-video.mp4"
+			video.Variants = map[string]string{
+				"1080p": id + "-1080p.mp4",
+				"720p":  id + "-720p.mp4",
+			}
+			video.Playlists = map[string]*dao.Playlist{
+				"1080p": {ObjectName: id + "-video-hls/1080p/index.m3u8"},
+				"720p":  {ObjectName: id + "-video-hls/720p/index.m3u8"},
+			}
+			video.ManifestObjectName = id + "-video-hls/master.m3u8"
+			video.Thumbnail = &dao.Thumbnail{
+				PosterObjectName:  id + "-video-poster.jpg",
+				SpriteObjectName:  id + "-video-sprite.jpg",
+				PreviewObjectName: id + "-video-preview.vtt",
+			}
+
+			Expect(videoObjectNames(video)).To(Equal([]string{
+				id + "-1080p.mp4",
+				id + "-720p.mp4",
+				id + "-video-poster.jpg",
+				id + "-video-preview.vtt",
+				id + "-video-sprite.jpg",
+				id + "-video.mp4",
+			}))
+			Expect(videoObjectPrefixes(video)).To(Equal([]string{id + "-video-hls/"}))
+		})
+	})
+})
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/dao"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/pb"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/kafkakit"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/logkit"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type SchedulerConfig struct {
+	Interval  time.Duration `long:"interval" env:"INTERVAL" description:"the interval to publish the due scheduled videos" default:"10s"`
+	BatchSize int64         `long:"batch_size" env:"BATCH_SIZE" description:"the number of the due scheduled videos to list in a batch" default:"100"`
+}
+
+// scheduler publishes the scheduled videos once their publish time has come,
+// and notifies the other modules that the videos have gone live.
+type scheduler struct {
+	videoDAO dao.VideoDAO
+	// videoPublishedProducer produces to the topic consumed outside of the video module
+	videoPublishedProducer kafkakit.Producer
+	interval               time.Duration
+	batchSize              int64
+}
+
+func NewScheduler(videoDAO dao.VideoDAO, videoPublishedProducer kafkakit.Producer, conf *SchedulerConfig) *scheduler {
+	return &scheduler{
+		videoDAO:               videoDAO,
+		videoPublishedProducer: videoPublishedProducer,
+		interval:               conf.Interval,
+		batchSize:              conf.BatchSize,
+	}
+}
+
+// Run publishes the due videos every interval until the context is done, a failed round is logged
+// and retried in the next round since the due videos are listed again.
+func (s *scheduler) Run(ctx context.Context) error {
+	logger := logkit.FromContext(ctx)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		published, err := s.Publish(ctx)
+		if err != nil && ctx.Err() == nil {
+			logger.Error("failed to publish the scheduled videos", zap.Int("published", published), zap.Error(err))
+		} else if published > 0 {
+			logger.Info("published the scheduled videos", zap.Int("published", published))
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Publish publishes the videos scheduled to publish by now and returns the number of the published videos
+func (s *scheduler) Publish(ctx context.Context) (int, error) {
+	publishBefore := time.Now().UTC()
+
+	opts := &dao.ListVideoOptions{
+		Limit:         s.batchSize,
+		SortBy:        dao.VideoSortPublishAt,
+		Ascending:     true,
+		Scheduled:     true,
+		PublishBefore: publishBefore,
+	}
+
+	published := 0
+	for {
+		page, err := s.videoDAO.List(ctx, opts)
+		if err != nil {
+			return published, err
+		}
+
+		for _, video := range page.Videos {
+			if err := s.publishVideo(ctx, video, publishBefore); err != nil {
+				if errors.Is(err, dao.ErrVideoNotFound) {
+					logkit.FromContext(ctx).Info("skip the video rescheduled or deleted during the publishing", zap.String("id", video.ID.Hex()))
+					continue
+				}
+
+				return published, err
+			}
+
+			published++
+		}
+
+		// the page token stays valid after the videos of the page are published
+		if page.NextPageToken == "" {
+			return published, nil
+		}
+
+		opts.PageToken = page.NextPageToken
+	}
+}
+
+// publishVideo produces the event before publishing the video, so a failed production leaves the video scheduled
+// and it is published again in the next round instead of losing the event. The video is live by its publish time
+// whether it is published or not, so the consumers find the video live, and the event is produced again if the
+// publishing fails, so the consumers handle the events at least once.
+func (s *scheduler) publishVideo(ctx context.Context, video *dao.Video, publishBefore time.Time) error {
+	if err := s.produceVideoPublishedEvent(&pb.VideoPublished{
+		Id:        video.ID.Hex(),
+		OwnerId:   video.OwnerID,
+		PublishAt: timestamppb.New(video.PublishAt),
+	}); err != nil {
+		return err
+	}
+
+	if _, err := s.videoDAO.Publish(ctx, video.ID, publishBefore); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *scheduler) produceVideoPublishedEvent(event *pb.VideoPublished) error {
+	valueBytes, err := proto.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	msgs := []*kafkakit.ProducerMessage{
+		{Value: valueBytes},
+	}
+
+	if err := s.videoPublishedProducer.SendMessages(msgs); err != nil {
+		return err
+	}
+
+	return nil
+}
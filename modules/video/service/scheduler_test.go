@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/dao"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/mock/daomock"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/pb"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/kafkakit"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/kafkakit/mock/kafkamock"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/logkit"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/proto"
+)
+
+var _ = Describe("Scheduler", func() {
+	var (
+		controller        *gomock.Controller
+		videoDAO          *daomock.MockVideoDAO
+		publishedProducer *kafkamock.MockProducer
+		s                 *scheduler
+		ctx               context.Context
+	)
+
+	BeforeEach(func() {
+		controller = gomock.NewController(GinkgoT())
+		videoDAO = daomock.NewMockVideoDAO(controller)
+		publishedProducer = kafkamock.NewMockProducer(controller)
+		s = NewScheduler(videoDAO, publishedProducer, &SchedulerConfig{Interval: time.Hour, BatchSize: 2})
+		ctx = logkit.WithContext(context.Background(), logkit.NewNopLogger())
+	})
+
+	AfterEach(func() {
+		controller.Finish()
+	})
+
+	Describe("Publish", func() {
+		var (
+			videos []*dao.Video
+
+			published int
+			err       error
+		)
+
+		BeforeEach(func() {
+			videos = []*dao.Video{dao.NewFakeVideo(), dao.NewFakeVideo(), dao.NewFakeVideo()}
+			for _, video := range videos {
+				video.OwnerID = "owner"
+				video.PublishStatus = dao.VideoPublishStatusScheduled
+				video.PublishAt = time.Now().UTC().Add(-time.Minute).Truncate(time.Millisecond)
+			}
+		})
+
+		JustBeforeEach(func() {
+			published, err = s.Publish(ctx)
+		})
+
+		expectPublish := func(video *dao.Video) {
+			publishedVideo := *video
+			publishedVideo.PublishStatus = dao.VideoPublishStatusPublished
+
+			videoDAO.EXPECT().Publish(ctx, video.ID, gomock.Any()).Return(&publishedVideo, nil)
+		}
+
+		When("DAO error", func() {
+			BeforeEach(func() {
+				videoDAO.EXPECT().List(ctx, gomock.Any()).Return(nil, errDAOUnknown)
+			})
+
+			It("returns the error", func() {
+				Expect(published).To(BeZero())
+				Expect(err).To(MatchError(errDAOUnknown))
+			})
+		})
+
+		When("publishing fails after the event is produced", func() {
+			BeforeEach(func() {
+				videoDAO.EXPECT().List(ctx, gomock.Any()).Return(&dao.VideoPage{Videos: videos[:1]}, nil)
+				gomock.InOrder(
+					publishedProducer.EXPECT().SendMessages(gomock.Any()).Return(nil),
+					videoDAO.EXPECT().Publish(ctx, videos[0].ID, gomock.Any()).Return(nil, errDAOUnknown),
+				)
+			})
+
+			It("returns the error so the video is published again in the next round", func() {
+				Expect(published).To(BeZero())
+				Expect(err).To(MatchError(errDAOUnknown))
+			})
+		})
+
+		When("producer error", func() {
+			BeforeEach(func() {
+				videoDAO.EXPECT().List(ctx, gomock.Any()).Return(&dao.VideoPage{Videos: videos[:1]}, nil)
+				publishedProducer.EXPECT().SendMessages(gomock.Any()).Return(errProducerUnknown)
+			})
+
+			It("returns the error and keeps the video scheduled", func() {
+				Expect(published).To(BeZero())
+				Expect(err).To(MatchError(errProducerUnknown))
+			})
+		})
+
+		When("video is rescheduled during the publishing", func() {
+			BeforeEach(func() {
+				videoDAO.EXPECT().List(ctx, gomock.Any()).Return(&dao.VideoPage{Videos: videos[:2]}, nil)
+				videoDAO.EXPECT().Publish(ctx, videos[0].ID, gomock.Any()).Return(nil, dao.ErrVideoNotFound)
+				expectPublish(videos[1])
+				publishedProducer.EXPECT().SendMessages(gomock.Any()).Times(2).Return(nil)
+			})
+
+			It("skips the rescheduled video", func() {
+				Expect(published).To(Equal(1))
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		When("success", func() {
+			var (
+				opts []*dao.ListVideoOptions
+				msgs [][]*kafkakit.ProducerMessage
+			)
+
+			BeforeEach(func() {
+				opts, msgs = nil, nil
+
+				record := func(_ context.Context, o *dao.ListVideoOptions) {
+					copied := *o
+					opts = append(opts, &copied)
+				}
+				gomock.InOrder(
+					videoDAO.EXPECT().List(ctx, gomock.Any()).Do(record).Return(&dao.VideoPage{Videos: videos[:2], NextPageToken: "next"}, nil),
+					videoDAO.EXPECT().List(ctx, gomock.Any()).Do(record).Return(&dao.VideoPage{Videos: videos[2:]}, nil),
+				)
+
+				for _, video := range videos {
+					expectPublish(video)
+				}
+				publishedProducer.EXPECT().SendMessages(gomock.Any()).Times(len(videos)).DoAndReturn(func(m []*kafkakit.ProducerMessage) error {
+					msgs = append(msgs, m)
+					return nil
+				})
+			})
+
+			It("publishes the due videos page by page", func() {
+				Expect(published).To(Equal(len(videos)))
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(opts).To(HaveLen(2))
+				Expect(opts[0].Scheduled).To(BeTrue())
+				Expect(opts[0].SortBy).To(Equal(dao.VideoSortPublishAt))
+				Expect(opts[0].Ascending).To(BeTrue())
+				Expect(opts[0].Limit).To(BeEquivalentTo(2))
+				Expect(opts[0].PublishBefore).To(BeTemporally("~", time.Now(), time.Second))
+				Expect(opts[0].PageToken).To(BeEmpty())
+				Expect(opts[1].PageToken).To(Equal("next"))
+				Expect(opts[1].PublishBefore).To(Equal(opts[0].PublishBefore))
+			})
+
+			It("produces the video published events", func() {
+				Expect(msgs).To(HaveLen(len(videos)))
+
+				var event pb.VideoPublished
+				Expect(proto.Unmarshal(msgs[0][0].Value, &event)).To(Succeed())
+				Expect(event.GetId()).To(Equal(videos[0].ID.Hex()))
+				Expect(event.GetOwnerId()).To(Equal("owner"))
+				Expect(event.GetPublishAt().AsTime()).To(Equal(videos[0].PublishAt))
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		It("publishes the due videos until the context is done", func() {
+			ctx, cancel := context.WithCancel(ctx)
+
+			videoDAO.EXPECT().List(ctx, gomock.Any()).DoAndReturn(func(context.Context, *dao.ListVideoOptions) (*dao.VideoPage, error) {
+				cancel()
+				return &dao.VideoPage{}, nil
+			})
+
+			Expect(s.Run(ctx)).To(Succeed())
+		})
+	})
+})
@@ -0,0 +1,238 @@
+package service
+
+import (
+	"html"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/dao"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/pb"
+)
+
+const (
+	maxSearchQueryLength = 200
+
+	// a description snippet keeps the words around a matched word within the radius in characters
+	snippetRadius = 60
+	maxSnippets   = 3
+
+	highlightPreTag  = "<em>"
+	highlightPostTag = "</em>"
+	snippetEllipsis  = "…"
+)
+
+// searchVideoOptions validates the search request and fills the defaults, a query without any word to match
+// is rejected since the text search returns nothing for the negations only
+func searchVideoOptions(req *pb.SearchVideoRequest) (*dao.SearchVideoOptions, error) {
+	query := strings.TrimSpace(req.GetQuery())
+	if utf8.RuneCountInString(query) > maxSearchQueryLength || len(searchTerms(query)) == 0 {
+		return nil, ErrInvalidSearchVideo
+	}
+
+	statuses, ok := parseVideoStatuses(req.GetStatuses())
+	if !ok {
+		return nil, ErrInvalidSearchVideo
+	}
+
+	return &dao.SearchVideoOptions{
+		Query:     query,
+		Limit:     pageSize(req.GetLimit()),
+		Statuses:  statuses,
+		PageToken: req.GetPageToken(),
+	}, nil
+}
+
+// searchTerms returns the lowercased words to highlight in the query, the negated words and phrases
+// such as `-word` and `-"a phrase"` are left out since they are never matched
+func searchTerms(query string) []string {
+	var (
+		terms   []string
+		seen    = make(map[string]struct{})
+		word    []rune
+		quoted  bool
+		negated bool
+	)
+
+	flush := func() {
+		if len(word) > 0 && !negated {
+			term := strings.ToLower(string(word))
+			if _, ok := seen[term]; !ok {
+				seen[term] = struct{}{}
+				terms = append(terms, term)
+			}
+		}
+		word = word[:0]
+	}
+
+	for _, r := range query {
+		switch {
+		case isWordRune(r):
+			word = append(word, r)
+		case r == '"':
+			flush()
+			quoted = !quoted
+			if !quoted {
+				negated = false
+			}
+		case unicode.IsSpace(r):
+			flush()
+			if !quoted {
+				negated = false
+			}
+		case r == '-' && len(word) == 0 && !quoted:
+			negated = true
+		default:
+			flush()
+		}
+	}
+	flush()
+
+	return terms
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// wordSpan is the range of a word in the runes of a text
+type wordSpan struct {
+	start, end int
+}
+
+// matchedWords returns the words starting with any of the terms, the prefix covers the plurals
+// and the other suffixes of the terms since the text search matches the stems of the words
+func matchedWords(text []rune, terms []string) []wordSpan {
+	var spans []wordSpan
+
+	for i := 0; i < len(text); {
+		if !isWordRune(text[i]) {
+			i++
+			continue
+		}
+
+		start := i
+		for i < len(text) && isWordRune(text[i]) {
+			i++
+		}
+
+		word := strings.ToLower(string(text[start:i]))
+		for _, term := range terms {
+			if strings.HasPrefix(word, term) {
+				spans = append(spans, wordSpan{start: start, end: i})
+				break
+			}
+		}
+	}
+
+	return spans
+}
+
+// highlightText escapes the text between from and to, and wraps the matched words in the range
+func highlightText(text []rune, matches []wordSpan, from, to int) string {
+	var b strings.Builder
+
+	pos := from
+	for _, m := range matches {
+		if m.start < from || m.end > to {
+			continue
+		}
+
+		b.WriteString(html.EscapeString(string(text[pos:m.start])))
+		b.WriteString(highlightPreTag)
+		b.WriteString(html.EscapeString(string(text[m.start:m.end])))
+		b.WriteString(highlightPostTag)
+		pos = m.end
+	}
+	b.WriteString(html.EscapeString(string(text[pos:to])))
+
+	return b.String()
+}
+
+// highlightSnippets returns the fragments of the text around the matched words, the overlapping fragments
+// are merged and the fragments are extended to the word boundaries so no word is cut
+func highlightSnippets(text []rune, matches []wordSpan) []string {
+	var snippets []string
+
+	for i := 0; i < len(matches) && len(snippets) < maxSnippets; {
+		from := wordBoundary(text, matches[i].start-snippetRadius, -1)
+		to := wordBoundary(text, matches[i].end+snippetRadius, 1)
+
+		// merge the following matches within the fragment
+		for i++; i < len(matches) && matches[i].start-snippetRadius <= to; i++ {
+			to = wordBoundary(text, matches[i].end+snippetRadius, 1)
+		}
+
+		snippet := strings.TrimSpace(highlightText(text, matches, from, to))
+		if from > 0 {
+			snippet = snippetEllipsis + snippet
+		}
+		if to < len(text) {
+			snippet += snippetEllipsis
+		}
+
+		snippets = append(snippets, snippet)
+	}
+
+	return snippets
+}
+
+// wordBoundary clamps the position into the text and moves it in the direction out of the word it is in
+func wordBoundary(text []rune, pos int, direction int) int {
+	if pos <= 0 {
+		return 0
+	}
+	if pos >= len(text) {
+		return len(text)
+	}
+
+	if direction < 0 {
+		for pos > 0 && isWordRune(text[pos-1]) {
+			pos--
+		}
+	} else {
+		for pos < len(text) && isWordRune(text[pos]) {
+			pos++
+		}
+	}
+
+	return pos
+}
+
+// videoHighlights highlights the terms in the title, the tags and the description of the video,
+// the fields without any matched word are left out
+func videoHighlights(video *dao.Video, terms []string) []*pb.VideoHighlight {
+	var highlights []*pb.VideoHighlight
+
+	title := []rune(video.Title)
+	if matches := matchedWords(title, terms); len(matches) > 0 {
+		highlights = append(highlights, &pb.VideoHighlight{
+			Field:    dao.VideoFieldTitle,
+			Snippets: []string{highlightText(title, matches, 0, len(title))},
+		})
+	}
+
+	var tags []string
+	for _, tag := range video.Tags {
+		text := []rune(tag)
+		if matches := matchedWords(text, terms); len(matches) > 0 {
+			tags = append(tags, highlightText(text, matches, 0, len(text)))
+		}
+	}
+	if len(tags) > 0 {
+		highlights = append(highlights, &pb.VideoHighlight{
+			Field:    dao.VideoFieldTags,
+			Snippets: tags,
+		})
+	}
+
+	description := []rune(video.Description)
+	if matches := matchedWords(description, terms); len(matches) > 0 {
+		highlights = append(highlights, &pb.VideoHighlight{
+			Field:    dao.VideoFieldDescription,
+			Snippets: highlightSnippets(description, matches),
+		})
+	}
+
+	return highlights
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/dao"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/pb"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Search", func() {
+	Describe("searchTerms", func() {
+		It("returns the lowercased words without duplicates", func() {
+			Expect(searchTerms("Bunny  big BUNNY")).To(Equal([]string{"bunny", "big"}))
+		})
+
+		It("keeps the words of the phrases", func() {
+			Expect(searchTerms(`"big buck" bunny`)).To(Equal([]string{"big", "buck", "bunny"}))
+		})
+
+		It("leaves out the negated words and phrases", func() {
+			Expect(searchTerms(`bunny -rabbit -"big buck" short-film`)).To(Equal([]string{"bunny", "short", "film"}))
+		})
+
+		It("returns nothing for the negations only", func() {
+			Expect(searchTerms(`-rabbit -"big buck"`)).To(BeEmpty())
+		})
+	})
+
+	Describe("searchVideoOptions", func() {
+		It("fills the defaults", func() {
+			Expect(searchVideoOptions(&pb.SearchVideoRequest{Query: " bunny "})).To(Equal(&dao.SearchVideoOptions{
+				Query:    "bunny",
+				Limit:    defaultPageSize,
+				Statuses: []dao.VideoStatus{},
+			}))
+		})
+
+		It("rejects a query without any word", func() {
+			_, err := searchVideoOptions(&pb.SearchVideoRequest{Query: " -bunny "})
+			Expect(err).To(MatchError(ErrInvalidSearchVideo))
+		})
+
+		It("rejects a long query", func() {
+			_, err := searchVideoOptions(&pb.SearchVideoRequest{Query: strings.Repeat("a", maxSearchQueryLength+1)})
+			Expect(err).To(MatchError(ErrInvalidSearchVideo))
+		})
+
+		It("rejects an unknown status", func() {
+			_, err := searchVideoOptions(&pb.SearchVideoRequest{Query: "bunny", Statuses: []string{"unknown"}})
+			Expect(err).To(MatchError(ErrInvalidSearchVideo))
+		})
+	})
+
+	Describe("videoHighlights", func() {
+		var video *dao.Video
+
+		BeforeEach(func() {
+			video = dao.NewFakeVideo()
+			video.Title = "Big Buck Bunny & friends"
+			video.Tags = []string{"animation", "bunnies", "comedy"}
+			video.Description = "A giant rabbit meets three bullying rodents."
+		})
+
+		It("highlights the matched words and escapes the text", func() {
+			Expect(videoHighlights(video, []string{"bunn"})).To(Equal([]*pb.VideoHighlight{
+				{Field: dao.VideoFieldTitle, Snippets: []string{"Big Buck <em>Bunny</em> &amp; friends"}},
+				{Field: dao.VideoFieldTags, Snippets: []string{"<em>bunnies</em>"}},
+			}))
+		})
+
+		It("leaves out the fields without any matched word", func() {
+			Expect(videoHighlights(video, []string{"elephant"})).To(BeEmpty())
+		})
+
+		It("cuts the description into snippets around the matched words", func() {
+			video.Description = strings.Repeat("lorem ipsum ", 20) + "giant rabbit" + strings.Repeat(" dolor sit", 20)
+
+			highlights := videoHighlights(video, []string{"rabbit"})
+			Expect(highlights).To(HaveLen(1))
+			Expect(highlights[0].GetField()).To(Equal(dao.VideoFieldDescription))
+			Expect(highlights[0].GetSnippets()).To(HaveLen(1))
+
+			snippet := highlights[0].GetSnippets()[0]
+			Expect(snippet).To(HavePrefix(snippetEllipsis + "ipsum"))
+			Expect(snippet).To(ContainSubstring("giant <em>rabbit</em> dolor"))
+			Expect(snippet).To(HaveSuffix("sit" + snippetEllipsis))
+		})
+
+		It("merges the close matched words into a snippet", func() {
+			Expect(videoHighlights(video, []string{"rabbit", "rodent"})).To(Equal([]*pb.VideoHighlight{
+				{Field: dao.VideoFieldDescription, Snippets: []string{"A giant <em>rabbit</em> meets three bullying <em>rodents</em>."}},
+			}))
+		})
+	})
+})
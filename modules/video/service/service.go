@@ -1,37 +1,45 @@
 package service
 
 import (
-	"bufio"
-	"bytes"
 	"context"
 	"errors"
 	"io"
-	"path"
+	"strings"
+	"time"
 
-	commentpb "github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/comment/pb"
 	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/dao"
 	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/pb"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/grpckit"
 	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/kafkakit"
 	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/storagekit"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type service struct {
 	pb.UnimplementedVideoServer
 
-	videoDAO      dao.VideoDAO
-	storage       storagekit.Storage
-	commentClient commentpb.CommentClient
-	producer      kafkakit.Producer
+	videoDAO         dao.VideoDAO
+	uploadSessionDAO dao.UploadSessionDAO
+	storage          storagekit.Storage
+	urlBuilder       *storagekit.URLBuilder
+	producer         kafkakit.Producer
 }
 
-func NewService(videoDAO dao.VideoDAO, storage storagekit.Storage, commentClient commentpb.CommentClient, producer kafkakit.Producer) *service {
+func NewService(
+	videoDAO dao.VideoDAO,
+	uploadSessionDAO dao.UploadSessionDAO,
+	storage storagekit.Storage,
+	urlBuilder *storagekit.URLBuilder,
+	producer kafkakit.Producer,
+) *service {
 	return &service{
-		videoDAO:      videoDAO,
-		storage:       storage,
-		commentClient: commentClient,
-		producer:      producer,
+		videoDAO:         videoDAO,
+		uploadSessionDAO: uploadSessionDAO,
+		storage:          storage,
+		urlBuilder:       urlBuilder,
+		producer:         producer,
 	}
 }
 
@@ -45,6 +53,21 @@ func (s *service) GetVideo(ctx context.Context, req *pb.GetVideoRequest) (*pb.Ge
 		return nil, ErrInvalidObjectID
 	}
 
+	video, err := s.getViewableVideo(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := s.videoInfo(ctx, video)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetVideoResponse{Video: info}, nil
+}
+
+// getViewableVideo gets the video the caller can view, a private video of another user is not found
+func (s *service) getViewableVideo(ctx context.Context, id primitive.ObjectID) (*dao.Video, error) {
 	video, err := s.videoDAO.Get(ctx, id)
 	if err != nil {
 		if errors.Is(err, dao.ErrVideoNotFound) {
@@ -54,21 +77,87 @@ func (s *service) GetVideo(ctx context.Context, req *pb.GetVideoRequest) (*pb.Ge
 		return nil, err
 	}
 
-	return &pb.GetVideoResponse{Video: video.ToProto()}, nil
+	if !video.Viewable(grpckit.UserIDFromContext(ctx)) {
+		return nil, ErrVideoNotFound
+	}
+
+	return video, nil
+}
+
+// getOwnedVideo gets the video owned by the caller, a video without an owner cannot be modified by anyone
+func (s *service) getOwnedVideo(ctx context.Context, id primitive.ObjectID) (*dao.Video, error) {
+	video, err := s.getViewableVideo(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if userID := grpckit.UserIDFromContext(ctx); userID == "" || userID != video.OwnerID {
+		return nil, ErrNotVideoOwner
+	}
+
+	return video, nil
 }
 
 func (s *service) ListVideo(ctx context.Context, req *pb.ListVideoRequest) (*pb.ListVideoResponse, error) {
-	videos, err := s.videoDAO.List(ctx, req.GetLimit(), req.GetSkip())
+	opts, err := listVideoOptions(req)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := s.videoDAO.List(ctx, opts)
+	if err != nil {
+		if errors.Is(err, dao.ErrInvalidPageToken) {
+			return nil, ErrInvalidPageToken
+		}
+
+		return nil, err
+	}
+
+	pbVideos := make([]*pb.VideoInfo, 0, len(page.Videos))
+	for _, video := range page.Videos {
+		info, err := s.videoInfo(ctx, video)
+		if err != nil {
+			return nil, err
+		}
+
+		pbVideos = append(pbVideos, info)
+	}
+
+	return &pb.ListVideoResponse{Videos: pbVideos, NextPageToken: page.NextPageToken}, nil
+}
+
+func (s *service) SearchVideo(ctx context.Context, req *pb.SearchVideoRequest) (*pb.SearchVideoResponse, error) {
+	opts, err := searchVideoOptions(req)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := s.videoDAO.Search(ctx, opts)
 	if err != nil {
+		if errors.Is(err, dao.ErrInvalidPageToken) {
+			return nil, ErrInvalidPageToken
+		}
+
 		return nil, err
 	}
 
-	pbVideos := make([]*pb.VideoInfo, 0, len(videos))
-	for _, video := range videos {
-		pbVideos = append(pbVideos, video.ToProto())
+	terms := searchTerms(opts.Query)
+
+	results := make([]*pb.SearchVideoResult, 0, len(page.Results))
+	for _, result := range page.Results {
+		info, err := s.videoInfo(ctx, result.Video)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, &pb.SearchVideoResult{
+			Video:      info,
+			Score:      result.Score,
+			Highlights: videoHighlights(result.Video, terms),
+		})
 	}
 
-	return &pb.ListVideoResponse{Videos: pbVideos}, nil
+	return &pb.SearchVideoResponse{Results: results, NextPageToken: page.NextPageToken}, nil
 }
 
 func (s *service) UploadVideo(stream pb.Video_UploadVideoServer) error {
@@ -79,86 +168,331 @@ func (s *service) UploadVideo(stream pb.Video_UploadVideoServer) error {
 		return err
 	}
 
-	filename := req.GetHeader().GetFilename()
-	size := req.GetHeader().GetSize()
+	header := req.GetHeader()
+	filename := header.GetFilename()
+	size := header.GetSize()
 
-	var buf bytes.Buffer
+	title := header.GetTitle()
+	if strings.TrimSpace(title) == "" {
+		title = defaultTitle(filename)
+	}
 
-	for {
+	metadata, err := newVideoMetadata(title, header.GetDescription(), header.GetTags(), header.GetLanguage(), header.GetVisibility(), []string{
+		dao.VideoFieldTitle, dao.VideoFieldDescription, dao.VideoFieldTags, dao.VideoFieldLanguage, dao.VideoFieldVisibility,
+	})
+	if err != nil {
+		return err
+	}
+
+	if metadata.Visibility == dao.VideoVisibilityPrivate && grpckit.UserIDFromContext(ctx) == "" {
+		return ErrInvalidVisibility
+	}
+
+	id := primitive.NewObjectID()
+	objectName := id.Hex() + "-" + filename
+
+	// the size in header is only a hint, let the storage upload by parts if it is unknown
+	objectSize := int64(size)
+	if objectSize == 0 {
+		objectSize = -1
+	}
+
+	recv := func() ([]byte, error) {
 		req, err := stream.Recv()
-		if err != nil {
-			if errors.Is(err, io.EOF) {
-				break
-			}
+		return req.GetChunkData(), err
+	}
 
-			return err
+	if err := pipeChunks(recv, func(reader io.Reader) error {
+		return s.storage.PutObject(ctx, objectName, reader, objectSize, storagekit.PutObjectOptions{
+			ContentType: "application/octet-stream",
+		})
+	}); err != nil {
+		if errors.Is(err, ErrVideoSizeMismatch) {
+			_ = s.storage.RemoveObject(ctx, objectName)
 		}
 
-		chunk := req.GetChunkData()
-		if _, err := buf.Write(chunk); err != nil {
-			return err
+		return err
+	}
+
+	video, err := s.probeVideo(ctx, id, objectName)
+	if err != nil {
+		if errors.Is(err, ErrInvalidVideo) {
+			_ = s.storage.RemoveObject(ctx, objectName)
 		}
+
+		return err
 	}
 
-	id := primitive.NewObjectID()
-	objectName := id.Hex() + "-" + filename
+	video.OwnerID = grpckit.UserIDFromContext(ctx)
+	video.VideoMetadata = *metadata
+	schedulePublish(video, header.GetPublishAt(), time.Now())
+
+	if err := s.createVideo(ctx, video); err != nil {
+		return err
+	}
 
-	if err := s.storage.PutObject(ctx, objectName, bufio.NewReader(&buf), int64(size), storagekit.PutObjectOptions{
-		ContentType: "application/octet-stream",
+	if err := stream.SendAndClose(&pb.UploadVideoResponse{
+		Id: id.Hex(),
 	}); err != nil {
 		return err
 	}
 
-	video := &dao.Video{
-		ID:     id,
-		Size:   size,
-		URL:    path.Join(s.storage.Endpoint(), s.storage.Bucket(), objectName),
-		Status: dao.VideoStatusUploaded,
+	return nil
+}
+
+// schedulePublish schedules the video to publish at the time if the time is in the future
+func schedulePublish(video *dao.Video, publishAt *timestamppb.Timestamp, now time.Time) {
+	if publishAt == nil || !publishAt.AsTime().After(now) {
+		return
 	}
 
+	video.PublishStatus = dao.VideoPublishStatusScheduled
+	video.PublishAt = publishAt.AsTime().UTC().Truncate(time.Millisecond)
+}
+
+// createVideo creates the video document of a probed upload and notifies the stream worker
+func (s *service) createVideo(ctx context.Context, video *dao.Video) error {
 	if err := s.videoDAO.Create(ctx, video); err != nil {
 		return err
 	}
 
 	if err := s.produceVideoCreatedEvent(&pb.HandleVideoCreatedRequest{
-		Id:  id.Hex(),
-		Url: path.Join(s.storage.Endpoint(), s.storage.Bucket(), objectName),
+		Id:         video.ID.Hex(),
+		ObjectName: video.ObjectName,
 	}); err != nil {
 		return err
 	}
 
-	if err := stream.SendAndClose(&pb.UploadVideoResponse{
-		Id: id.Hex(),
-	}); err != nil {
+	return nil
+}
+
+// pipeChunks pipes the chunks returned by recv into upload until recv returns io.EOF
+func pipeChunks(recv func() ([]byte, error), upload func(reader io.Reader) error) error {
+	pr, pw := io.Pipe()
+
+	recvErrCh := make(chan error, 1)
+	go func() {
+		err := writeChunks(recv, pw)
+		_ = pw.CloseWithError(err)
+		recvErrCh <- err
+	}()
+
+	if err := upload(pr); err != nil {
+		_ = pr.CloseWithError(err)
+
+		if recvErr := <-recvErrCh; recvErr != nil {
+			return recvErr
+		}
+
+		return err
+	}
+
+	_ = pr.Close()
+	if err := <-recvErrCh; err != nil {
+		// the storage stopped reading at the hinted size before all the chunks were received
+		if errors.Is(err, io.ErrClosedPipe) {
+			return ErrVideoSizeMismatch
+		}
+
 		return err
 	}
 
 	return nil
 }
 
-func (s *service) DeleteVideo(ctx context.Context, req *pb.DeleteVideoRequest) (*pb.DeleteVideoResponse, error) {
+// writeChunks writes the chunks returned by recv into w until recv returns io.EOF
+func writeChunks(recv func() ([]byte, error), w io.Writer) error {
+	for {
+		chunk, err := recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			return err
+		}
+
+		if _, err := w.Write(chunk); err != nil {
+			return err
+		}
+	}
+}
+
+// UpdateVideo updates the metadata fields in the update mask of a video owned by the caller since updated_at
+func (s *service) UpdateVideo(ctx context.Context, req *pb.UpdateVideoRequest) (*pb.UpdateVideoResponse, error) {
 	id, err := primitive.ObjectIDFromHex(req.GetId())
 	if err != nil {
 		return nil, ErrInvalidObjectID
 	}
 
-	if err := s.videoDAO.Delete(ctx, id); err != nil {
+	fields, err := updateMaskFields(req.GetUpdateMask().GetPaths())
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetUpdatedAt() == nil {
+		return nil, ErrUpdatedAtRequired
+	}
+
+	info := req.GetVideo()
+	metadata, err := newVideoMetadata(info.GetTitle(), info.GetDescription(), info.GetTags(), info.GetLanguage(), info.GetVisibility(), fields)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.getOwnedVideo(ctx, id); err != nil {
+		return nil, err
+	}
+
+	video, err := s.videoDAO.UpdateMetadata(ctx, id, metadata, fields, req.GetUpdatedAt().AsTime())
+	if err != nil {
 		if errors.Is(err, dao.ErrVideoNotFound) {
 			return nil, ErrVideoNotFound
 		}
 
+		if errors.Is(err, dao.ErrVideoUpdateConflict) {
+			return nil, ErrVideoUpdateConflict
+		}
+
 		return nil, err
 	}
 
-	if _, err := s.commentClient.DeleteCommentByVideoID(ctx, &commentpb.DeleteCommentByVideoIDRequest{
-		VideoId: id.Hex(),
-	}); err != nil {
+	updated, err := s.videoInfo(ctx, video)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.UpdateVideoResponse{Video: updated}, nil
+}
+
+// DeleteVideo moves the video owned by the caller to the trash until it is restored or purged
+func (s *service) DeleteVideo(ctx context.Context, req *pb.DeleteVideoRequest) (*pb.DeleteVideoResponse, error) {
+	id, err := primitive.ObjectIDFromHex(req.GetId())
+	if err != nil {
+		return nil, ErrInvalidObjectID
+	}
+
+	if _, err := s.getOwnedVideo(ctx, id); err != nil {
+		return nil, err
+	}
+
+	if err := s.videoDAO.SoftDelete(ctx, id); err != nil {
+		if errors.Is(err, dao.ErrVideoNotFound) {
+			return nil, ErrVideoNotFound
+		}
+
 		return nil, err
 	}
 
 	return &pb.DeleteVideoResponse{}, nil
 }
 
+// RestoreVideo moves the video of the caller out of the trash, the videos of the other users are not found
+func (s *service) RestoreVideo(ctx context.Context, req *pb.RestoreVideoRequest) (*pb.RestoreVideoResponse, error) {
+	id, err := primitive.ObjectIDFromHex(req.GetId())
+	if err != nil {
+		return nil, ErrInvalidObjectID
+	}
+
+	userID := grpckit.UserIDFromContext(ctx)
+	if userID == "" {
+		return nil, ErrSignInRequired
+	}
+
+	video, err := s.videoDAO.Restore(ctx, id, userID)
+	if err != nil {
+		if errors.Is(err, dao.ErrVideoNotFound) {
+			return nil, ErrDeletedVideoNotFound
+		}
+
+		return nil, err
+	}
+
+	info, err := s.videoInfo(ctx, video)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.RestoreVideoResponse{Video: info}, nil
+}
+
+// ListDeletedVideos lists the videos of the caller in the trash
+func (s *service) ListDeletedVideos(ctx context.Context, req *pb.ListDeletedVideosRequest) (*pb.ListDeletedVideosResponse, error) {
+	userID := grpckit.UserIDFromContext(ctx)
+	if userID == "" {
+		return nil, ErrSignInRequired
+	}
+
+	page, err := s.videoDAO.List(ctx, &dao.ListVideoOptions{
+		Limit:     pageSize(req.GetLimit()),
+		SortBy:    dao.VideoSortDeletedAt,
+		Ascending: true,
+		Deleted:   true,
+		OwnerID:   userID,
+		PageToken: req.GetPageToken(),
+	})
+	if err != nil {
+		if errors.Is(err, dao.ErrInvalidPageToken) {
+			return nil, ErrInvalidPageToken
+		}
+
+		return nil, err
+	}
+
+	pbVideos := make([]*pb.VideoInfo, 0, len(page.Videos))
+	for _, video := range page.Videos {
+		info, err := s.videoInfo(ctx, video)
+		if err != nil {
+			return nil, err
+		}
+
+		pbVideos = append(pbVideos, info)
+	}
+
+	return &pb.ListDeletedVideosResponse{Videos: pbVideos, NextPageToken: page.NextPageToken}, nil
+}
+
+// videoInfo converts the video to the protobuf message with the URLs derived from the object names
+func (s *service) videoInfo(ctx context.Context, video *dao.Video) (*pb.VideoInfo, error) {
+	info := video.ToProto()
+
+	url, err := s.urlBuilder.ObjectURL(ctx, video.ObjectName)
+	if err != nil {
+		return nil, err
+	}
+
+	info.Url = url
+
+	if video.ManifestObjectName != "" {
+		info.ManifestUrl = manifestPath(video.ID)
+	}
+
+	if thumbnail := video.Thumbnail; thumbnail != nil {
+		if info.ThumbnailUrl, err = s.urlBuilder.ObjectURL(ctx, thumbnail.PosterObjectName); err != nil {
+			return nil, err
+		}
+
+		if thumbnail.PreviewObjectName != "" {
+			info.PreviewUrl = previewPath(video.ID)
+		}
+	}
+
+	if len(video.Variants) > 0 {
+		info.Variants = make(map[string]string, len(video.Variants))
+	}
+
+	for variant, objectName := range video.Variants {
+		url, err := s.urlBuilder.ObjectURL(ctx, objectName)
+		if err != nil {
+			return nil, err
+		}
+
+		info.Variants[variant] = url
+	}
+
+	return info, nil
+}
+
 func (s *service) produceVideoCreatedEvent(req *pb.HandleVideoCreatedRequest) error {
 	valueBytes, err := proto.Marshal(req)
 	if err != nil {
@@ -1,24 +1,30 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"io"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
-	commentpbmock "github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/comment/mock/pbmock"
-	commentpb "github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/comment/pb"
 	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/dao"
 	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/mock/daomock"
 	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/mock/pbmock"
 	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/pb"
 	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/kafkakit/mock/kafkamock"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/logkit"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/storagekit"
 	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/storagekit/mock/storagemock"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func TestService(t *testing.T) {
@@ -27,27 +33,32 @@ func TestService(t *testing.T) {
 }
 
 var (
-	errDAOUnknown = errors.New("unknown DAO error")
+	errDAOUnknown           = errors.New("unknown DAO error")
+	errStorageUnknown       = errors.New("unknown storage error")
+	errStreamUnknown        = errors.New("unknown stream error")
+	errProducerUnknown      = errors.New("unknown producer error")
+	errCommentClientUnknown = errors.New("unknown comment client error")
 )
 
 var _ = Describe("Service", func() {
 	var (
-		controller    *gomock.Controller
-		videoDAO      *daomock.MockVideoDAO
-		storage       *storagemock.MockStorage
-		commentClient *commentpbmock.MockCommentClient
-		producer      *kafkamock.MockProducer
-		svc           *service
-		ctx           context.Context
+		controller       *gomock.Controller
+		videoDAO         *daomock.MockVideoDAO
+		uploadSessionDAO *daomock.MockUploadSessionDAO
+		storage          *storagemock.MockStorage
+		producer         *kafkamock.MockProducer
+		svc              *service
+		ctx              context.Context
 	)
 
 	BeforeEach(func() {
 		controller = gomock.NewController(GinkgoT())
 		videoDAO = daomock.NewMockVideoDAO(controller)
+		uploadSessionDAO = daomock.NewMockUploadSessionDAO(controller)
 		storage = storagemock.NewMockStorage(controller)
-		commentClient = commentpbmock.NewMockCommentClient(controller)
 		producer = kafkamock.NewMockProducer(controller)
-		svc = NewService(videoDAO, storage, commentClient, producer)
+		urlBuilder := storagekit.NewURLBuilder(logkit.WithContext(context.Background(), logkit.NewNopLogger()), &storagekit.URLConfig{}, storage)
+		svc = NewService(videoDAO, uploadSessionDAO, storage, urlBuilder, producer)
 		ctx = context.Background()
 	})
 
@@ -66,6 +77,9 @@ var _ = Describe("Service", func() {
 		BeforeEach(func() {
 			id = primitive.NewObjectID()
 			req = &pb.GetVideoRequest{Id: id.Hex()}
+
+			storage.EXPECT().Endpoint().AnyTimes().Return("play.min.io")
+			storage.EXPECT().Bucket().AnyTimes().Return("videos")
 		})
 
 		JustBeforeEach(func() {
@@ -94,17 +108,161 @@ var _ = Describe("Service", func() {
 			})
 		})
 
+		When("video is private to another user", func() {
+			BeforeEach(func() {
+				video := dao.NewFakeVideo()
+				video.Visibility = dao.VideoVisibilityPrivate
+				video.OwnerID = "owner"
+
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-user-id", "another user"))
+				videoDAO.EXPECT().Get(ctx, id).Return(video, nil)
+			})
+
+			It("returns video not found error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrVideoNotFound))
+			})
+		})
+
+		When("video is private to an anonymous user", func() {
+			BeforeEach(func() {
+				video := dao.NewFakeVideo()
+				video.Visibility = dao.VideoVisibilityPrivate
+				video.OwnerID = "owner"
+
+				videoDAO.EXPECT().Get(ctx, id).Return(video, nil)
+			})
+
+			It("returns video not found error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrVideoNotFound))
+			})
+		})
+
+		When("video is private to the owner", func() {
+			var video *dao.Video
+
+			BeforeEach(func() {
+				video = dao.NewFakeVideo()
+				video.Visibility = dao.VideoVisibilityPrivate
+				video.OwnerID = "owner"
+
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-user-id", "owner"))
+				videoDAO.EXPECT().Get(ctx, id).Return(video, nil)
+				expectPresignedGetObject(storage)
+			})
+
+			It("returns the video", func() {
+				Expect(resp).To(Equal(&pb.GetVideoResponse{
+					Video: presignedVideoInfo(video),
+				}))
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		When("video is scheduled", func() {
+			BeforeEach(func() {
+				video := dao.NewFakeVideo()
+				video.PublishStatus = dao.VideoPublishStatusScheduled
+				video.PublishAt = time.Now().Add(time.Hour)
+
+				videoDAO.EXPECT().Get(ctx, id).Return(video, nil)
+			})
+
+			It("returns video not found error until the publish time", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrVideoNotFound))
+			})
+		})
+
+		When("video is scheduled and the publish time has come", func() {
+			var video *dao.Video
+
+			BeforeEach(func() {
+				video = dao.NewFakeVideo()
+				video.PublishStatus = dao.VideoPublishStatusScheduled
+				video.PublishAt = time.Now().Add(-time.Second)
+
+				videoDAO.EXPECT().Get(ctx, id).Return(video, nil)
+				expectPresignedGetObject(storage)
+			})
+
+			It("returns the video before the scheduler publishes it", func() {
+				Expect(resp).To(Equal(&pb.GetVideoResponse{
+					Video: presignedVideoInfo(video),
+				}))
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		When("video is unlisted", func() {
+			var video *dao.Video
+
+			BeforeEach(func() {
+				video = dao.NewFakeVideo()
+				video.Visibility = dao.VideoVisibilityUnlisted
+
+				videoDAO.EXPECT().Get(ctx, id).Return(video, nil)
+				expectPresignedGetObject(storage)
+			})
+
+			It("returns the video to anyone with the ID", func() {
+				Expect(resp).To(Equal(&pb.GetVideoResponse{
+					Video: presignedVideoInfo(video),
+				}))
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		When("storage error", func() {
+			BeforeEach(func() {
+				videoDAO.EXPECT().Get(ctx, id).Return(dao.NewFakeVideo(), nil)
+				storage.EXPECT().PresignedGetObject(ctx, gomock.Any()).Return(nil, errStorageUnknown)
+			})
+
+			It("returns the error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(errStorageUnknown))
+			})
+		})
+
+		When("storage does not support presigned URL", func() {
+			var video *dao.Video
+
+			BeforeEach(func() {
+				video = dao.NewFakeVideo()
+				videoDAO.EXPECT().Get(ctx, id).Return(video, nil)
+				storage.EXPECT().PresignedGetObject(ctx, gomock.Any()).AnyTimes().Return(nil, storagekit.ErrPresignNotSupported)
+			})
+
+			It("returns the video with the public URLs", func() {
+				info := video.ToProto()
+				info.Url = "https://play.min.io/videos/" + video.ObjectName
+				info.ManifestUrl = "/v1/videos/" + video.ID.Hex() + "/hls/master.m3u8"
+				info.ThumbnailUrl = "https://play.min.io/videos/" + video.Thumbnail.PosterObjectName
+				info.PreviewUrl = "/v1/videos/" + video.ID.Hex() + "/preview.vtt"
+				info.Variants = map[string]string{
+					"1080p": "https://play.min.io/videos/" + video.Variants["1080p"],
+					"720p":  "https://play.min.io/videos/" + video.Variants["720p"],
+				}
+
+				Expect(resp).To(Equal(&pb.GetVideoResponse{Video: info}))
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
 		When("success", func() {
 			var video *dao.Video
 
 			BeforeEach(func() {
 				video = dao.NewFakeVideo()
 				videoDAO.EXPECT().Get(ctx, id).Return(video, nil)
+				expectPresignedGetObject(storage)
 			})
 
-			It("returns the video with no error", func() {
+			It("returns the video with presigned URLs", func() {
 				Expect(resp).To(Equal(&pb.GetVideoResponse{
-					Video: video.ToProto(),
+					Video: presignedVideoInfo(video),
 				}))
 				Expect(err).NotTo(HaveOccurred())
 			})
@@ -114,21 +272,74 @@ var _ = Describe("Service", func() {
 	Describe("ListVideo", func() {
 		var (
 			req  *pb.ListVideoRequest
+			opts *dao.ListVideoOptions
 			resp *pb.ListVideoResponse
 			err  error
 		)
 
 		BeforeEach(func() {
 			req = &pb.ListVideoRequest{Limit: 10, Skip: 0}
+			opts = &dao.ListVideoOptions{
+				Limit:    10,
+				SortBy:   dao.VideoSortCreatedAt,
+				Statuses: []dao.VideoStatus{},
+				Tags:     []string{},
+			}
+
+			storage.EXPECT().Endpoint().AnyTimes().Return("play.min.io")
+			storage.EXPECT().Bucket().AnyTimes().Return("videos")
 		})
 
 		JustBeforeEach(func() {
 			resp, err = svc.ListVideo(ctx, req)
 		})
 
+		When("status is unknown", func() {
+			BeforeEach(func() { req.Statuses = []string{"unknown"} })
+
+			It("returns invalid list video error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrInvalidListVideo))
+			})
+		})
+
+		When("tag is too long", func() {
+			BeforeEach(func() { req.Tags = []string{strings.Repeat("a", maxTagLength+1)} })
+
+			It("returns invalid tags error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrInvalidTags))
+			})
+		})
+
+		When("skip is combined with a page token", func() {
+			BeforeEach(func() {
+				req.Skip = 20
+				req.PageToken = "token"
+			})
+
+			It("returns invalid skip error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrInvalidSkip))
+			})
+		})
+
+		When("page token is invalid", func() {
+			BeforeEach(func() {
+				req.PageToken = "invalid"
+				opts.PageToken = "invalid"
+				videoDAO.EXPECT().List(ctx, opts).Return(nil, dao.ErrInvalidPageToken)
+			})
+
+			It("returns invalid page token error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrInvalidPageToken))
+			})
+		})
+
 		When("DAO error", func() {
 			BeforeEach(func() {
-				videoDAO.EXPECT().List(ctx, req.GetLimit(), req.GetSkip()).Return(nil, errDAOUnknown)
+				videoDAO.EXPECT().List(ctx, opts).Return(nil, errDAOUnknown)
 			})
 
 			It("returns the error", func() {
@@ -137,140 +348,1631 @@ var _ = Describe("Service", func() {
 			})
 		})
 
+		When("limit exceeds the maximum", func() {
+			BeforeEach(func() {
+				req.Limit = maxPageSize + 1
+				opts.Limit = maxPageSize
+				videoDAO.EXPECT().List(ctx, opts).Return(&dao.VideoPage{}, nil)
+			})
+
+			It("lists the maximum number of videos", func() {
+				Expect(resp).To(Equal(&pb.ListVideoResponse{Videos: []*pb.VideoInfo{}}))
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		When("sorting and filters are given", func() {
+			BeforeEach(func() {
+				req.SortBy = pb.VideoSortField_VIDEO_SORT_FIELD_SIZE
+				req.Order = pb.SortOrder_SORT_ORDER_ASC
+				req.Statuses = []string{dao.VideoStatusSuccess.String()}
+				req.Tags = []string{" Comedy ", "comedy"}
+				opts.SortBy = dao.VideoSortSize
+				opts.Ascending = true
+				opts.Statuses = []dao.VideoStatus{dao.VideoStatusSuccess}
+				opts.Tags = []string{"comedy"}
+				videoDAO.EXPECT().List(ctx, opts).Return(&dao.VideoPage{}, nil)
+			})
+
+			It("lists the videos with the options", func() {
+				Expect(resp).To(Equal(&pb.ListVideoResponse{Videos: []*pb.VideoInfo{}}))
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
 		When("success", func() {
 			var videos []*dao.Video
 
 			BeforeEach(func() {
 				videos = []*dao.Video{dao.NewFakeVideo(), dao.NewFakeVideo()}
-				videoDAO.EXPECT().List(ctx, req.GetLimit(), req.GetSkip()).Return(videos, nil)
+				videoDAO.EXPECT().List(ctx, opts).Return(&dao.VideoPage{Videos: videos, NextPageToken: "next"}, nil)
+				expectPresignedGetObject(storage)
 			})
 
-			It("returns videos with no error", func() {
+			It("returns videos with presigned URLs and the next page token", func() {
 				Expect(resp).To(Equal(&pb.ListVideoResponse{
 					Videos: []*pb.VideoInfo{
-						videos[0].ToProto(),
-						videos[1].ToProto(),
+						presignedVideoInfo(videos[0]),
+						presignedVideoInfo(videos[1]),
 					},
+					NextPageToken: "next",
 				}))
 				Expect(err).NotTo(HaveOccurred())
 			})
 		})
 	})
 
-	Describe("UploadVideo", func() {
-		var stream *pbmock.MockVideo_UploadVideoServer
-		var err error
+	Describe("SearchVideo", func() {
+		var (
+			req  *pb.SearchVideoRequest
+			opts *dao.SearchVideoOptions
+			resp *pb.SearchVideoResponse
+			err  error
+		)
 
 		BeforeEach(func() {
-			stream = pbmock.NewMockVideo_UploadVideoServer(controller)
-			stream.EXPECT().Context().Return(ctx)
+			req = &pb.SearchVideoRequest{Query: "bunny", Limit: 10}
+			opts = &dao.SearchVideoOptions{
+				Query:    "bunny",
+				Limit:    10,
+				Statuses: []dao.VideoStatus{},
+			}
+
+			storage.EXPECT().Endpoint().AnyTimes().Return("play.min.io")
+			storage.EXPECT().Bucket().AnyTimes().Return("videos")
 		})
 
 		JustBeforeEach(func() {
-			err = svc.UploadVideo(stream)
+			resp, err = svc.SearchVideo(ctx, req)
 		})
 
-		When("success", func() {
-			BeforeEach(func() {
-				file, rerr := os.ReadFile("./fixtures/big_buck_bunny_240p_1mb.mp4")
-				Expect(rerr).NotTo(HaveOccurred())
+		When("query is empty", func() {
+			BeforeEach(func() { req.Query = " " })
 
-				requests := []*pb.UploadVideoRequest{
-					{
-						Data: &pb.UploadVideoRequest_Header{
-							Header: &pb.VideoHeader{
-								Filename: "big_buck_bunny_240p_1mb.mp4",
-								Size:     1053651,
-							},
-						},
-					},
-					{
-						Data: &pb.UploadVideoRequest_ChunkData{
-							ChunkData: file,
-						},
-					},
-				}
+			It("returns invalid search video error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrInvalidSearchVideo))
+			})
+		})
 
-				for _, req := range requests {
-					stream.EXPECT().Recv().Return(req, nil)
-				}
-				stream.EXPECT().Recv().Return(nil, io.EOF)
+		When("page token is invalid", func() {
+			BeforeEach(func() {
+				req.PageToken = "invalid"
+				opts.PageToken = "invalid"
+				videoDAO.EXPECT().Search(ctx, opts).Return(nil, dao.ErrInvalidPageToken)
+			})
+
+			It("returns invalid page token error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrInvalidPageToken))
+			})
+		})
 
-				storage.EXPECT().PutObject(
-					ctx,
-					gomock.Any(),
-					gomock.Any(),
-					int64(requests[0].GetHeader().GetSize()),
-					gomock.Any(),
-				).Return(nil)
+		When("DAO error", func() {
+			BeforeEach(func() {
+				videoDAO.EXPECT().Search(ctx, opts).Return(nil, errDAOUnknown)
+			})
 
-				storage.EXPECT().Endpoint().AnyTimes().Return("https://play.min.io")
-				storage.EXPECT().Bucket().AnyTimes().Return("videos")
+			It("returns the error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(errDAOUnknown))
+			})
+		})
 
-				videoDAO.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+		When("success", func() {
+			var video *dao.Video
 
-				producer.EXPECT().SendMessages(gomock.Any()).Return(nil)
+			BeforeEach(func() {
+				req.Statuses = []string{dao.VideoStatusSuccess.String()}
+				opts.Statuses = []dao.VideoStatus{dao.VideoStatusSuccess}
 
-				stream.EXPECT().SendAndClose(gomock.Any()).Return(nil)
+				video = dao.NewFakeVideo()
+				videoDAO.EXPECT().Search(ctx, opts).Return(&dao.VideoSearchPage{
+					Results:       []*dao.VideoSearchResult{{Video: video, Score: 10.5}},
+					NextPageToken: "next",
+				}, nil)
+				expectPresignedGetObject(storage)
 			})
 
-			It("returns no error", func() {
+			It("returns the videos with the scores and the highlights", func() {
+				Expect(resp).To(Equal(&pb.SearchVideoResponse{
+					Results: []*pb.SearchVideoResult{{
+						Video: presignedVideoInfo(video),
+						Score: 10.5,
+						Highlights: []*pb.VideoHighlight{
+							{Field: dao.VideoFieldTitle, Snippets: []string{"Big Buck <em>Bunny</em>"}},
+						},
+					}},
+					NextPageToken: "next",
+				}))
 				Expect(err).NotTo(HaveOccurred())
 			})
 		})
 	})
 
-	Describe("DeleteVideo", func() {
+	Describe("UploadVideo", func() {
 		var (
-			req  *pb.DeleteVideoRequest
-			id   primitive.ObjectID
-			resp *pb.DeleteVideoResponse
-			err  error
+			stream *pbmock.MockVideo_UploadVideoServer
+			header *pb.VideoHeader
+			file   []byte
+			size   uint64
+			err    error
 		)
 
 		BeforeEach(func() {
-			id = primitive.NewObjectID()
-			req = &pb.DeleteVideoRequest{Id: id.Hex()}
+			stream = pbmock.NewMockVideo_UploadVideoServer(controller)
+			stream.EXPECT().Context().Return(ctx)
+
+			file = readFixture()
+			size = 1053651
+			header = &pb.VideoHeader{Filename: "big_buck_bunny_240p_1mb.mp4"}
 		})
 
 		JustBeforeEach(func() {
-			resp, err = svc.DeleteVideo(ctx, req)
+			err = svc.UploadVideo(stream)
 		})
 
-		When("DAO error", func() {
+		expectHeader := func() {
+			stream.EXPECT().Recv().Return(&pb.UploadVideoRequest{
+				Data: &pb.UploadVideoRequest_Header{
+					Header: &pb.VideoHeader{
+						Filename:    header.GetFilename(),
+						Size:        size,
+						Title:       header.GetTitle(),
+						Description: header.GetDescription(),
+						Tags:        header.GetTags(),
+						Language:    header.GetLanguage(),
+						Visibility:  header.GetVisibility(),
+						PublishAt:   header.GetPublishAt(),
+					},
+				},
+			}, nil)
+		}
+
+		expectChunks := func(chunks ...[]byte) {
+			for _, chunk := range chunks {
+				stream.EXPECT().Recv().Return(&pb.UploadVideoRequest{
+					Data: &pb.UploadVideoRequest_ChunkData{
+						ChunkData: chunk,
+					},
+				}, nil)
+			}
+		}
+
+		var created *dao.Video
+
+		expectVideoCreated := func() {
+			created = nil
+
+			expectStoredObject(storage, gomock.Any(), file)
+
+			videoDAO.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, video *dao.Video) error {
+				created = video
+				return nil
+			})
+
+			producer.EXPECT().SendMessages(gomock.Any()).Return(nil)
+
+			stream.EXPECT().SendAndClose(gomock.Any()).Return(nil)
+		}
+
+		When("stream broken", func() {
 			BeforeEach(func() {
-				videoDAO.EXPECT().Delete(ctx, id).Return(errDAOUnknown)
+				expectHeader()
+				expectChunks(file[:1024])
+				stream.EXPECT().Recv().Return(nil, errStreamUnknown)
+
+				storage.EXPECT().PutObject(ctx, gomock.Any(), gomock.Any(), int64(size), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, reader io.Reader, _ int64, _ storagekit.PutObjectOptions) error {
+						_, err := io.ReadAll(reader)
+						return err
+					})
 			})
 
-			It("returns the error", func() {
-				Expect(resp).To(BeNil())
-				Expect(err).To(MatchError(errDAOUnknown))
+			It("returns the stream error", func() {
+				Expect(err).To(MatchError(errStreamUnknown))
 			})
 		})
 
-		When("video not found", func() {
+		When("stream has more chunks than the size", func() {
+			var objectName string
+
 			BeforeEach(func() {
-				videoDAO.EXPECT().Delete(ctx, id).Return(dao.ErrVideoNotFound)
+				size = 1024
+
+				expectHeader()
+				expectChunks(file[:1024], file[1024:2048])
+
+				storage.EXPECT().PutObject(ctx, gomock.Any(), gomock.Any(), int64(size), gomock.Any()).
+					DoAndReturn(func(_ context.Context, name string, reader io.Reader, size int64, _ storagekit.PutObjectOptions) error {
+						objectName = name
+						_, err := io.CopyN(io.Discard, reader, size)
+						return err
+					})
+				storage.EXPECT().RemoveObject(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, name string) error {
+					Expect(name).To(Equal(objectName))
+					return nil
+				})
 			})
 
-			It("returns video not found error", func() {
-				Expect(resp).To(BeNil())
-				Expect(err).To(MatchError(ErrVideoNotFound))
+			It("removes the stored object and returns video size mismatch error", func() {
+				Expect(err).To(MatchError(ErrVideoSizeMismatch))
+			})
+		})
+
+		When("storage error", func() {
+			BeforeEach(func() {
+				expectHeader()
+				stream.EXPECT().Recv().AnyTimes().Return(&pb.UploadVideoRequest{
+					Data: &pb.UploadVideoRequest_ChunkData{
+						ChunkData: file[:1024],
+					},
+				}, nil)
+
+				storage.EXPECT().PutObject(ctx, gomock.Any(), gomock.Any(), int64(size), gomock.Any()).Return(errStorageUnknown)
+			})
+
+			It("returns the storage error", func() {
+				Expect(err).To(MatchError(errStorageUnknown))
 			})
 		})
 
 		When("success", func() {
+			var uploaded []byte
+
 			BeforeEach(func() {
-				videoDAO.EXPECT().Delete(ctx, id).Return(nil)
-				commentClient.EXPECT().DeleteCommentByVideoID(ctx, &commentpb.DeleteCommentByVideoIDRequest{
-					VideoId: id.Hex(),
-				})
+				expectHeader()
+				expectChunks(file[:size/2], file[size/2:])
+				stream.EXPECT().Recv().Return(nil, io.EOF)
+
+				storage.EXPECT().PutObject(ctx, gomock.Any(), gomock.Any(), int64(size), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, reader io.Reader, _ int64, _ storagekit.PutObjectOptions) error {
+						var rerr error
+						uploaded, rerr = io.ReadAll(reader)
+						return rerr
+					})
+
+				expectVideoCreated()
 			})
 
 			It("returns no error", func() {
-				Expect(resp).To(Equal(&pb.DeleteVideoResponse{}))
 				Expect(err).NotTo(HaveOccurred())
 			})
+
+			It("streams the whole video to the storage", func() {
+				Expect(uploaded).To(Equal(file))
+			})
+
+			It("creates the video with the probed metadata", func() {
+				Expect(created.Width).To(Equal(uint32(320)))
+				Expect(created.Height).To(Equal(uint32(240)))
+				Expect(created.Size).To(Equal(size))
+				Expect(created.Duration).To(Equal(13.696))
+				Expect(created.Codec).To(Equal("avc1"))
+				Expect(created.Bitrate).To(Equal(uint64(615450)))
+				Expect(created.Status).To(Equal(dao.VideoStatusUploaded))
+			})
+
+			It("titles the video by the filename", func() {
+				Expect(created.Title).To(Equal("big_buck_bunny_240p_1mb"))
+			})
 		})
-	})
-})
+
+		When("success with metadata", func() {
+			BeforeEach(func() {
+				header.Title = "  Big Buck Bunny "
+				header.Description = "A short computer-animated comedy film"
+				header.Tags = []string{"Animation", "comedy", "animation", " "}
+				header.Language = "zh-tw"
+
+				expectHeader()
+				expectChunks(file)
+				stream.EXPECT().Recv().Return(nil, io.EOF)
+
+				storage.EXPECT().PutObject(ctx, gomock.Any(), gomock.Any(), int64(size), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, reader io.Reader, _ int64, _ storagekit.PutObjectOptions) error {
+						_, rerr := io.Copy(io.Discard, reader)
+						return rerr
+					})
+
+				expectVideoCreated()
+			})
+
+			It("creates the video with the normalized metadata", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(created.VideoMetadata).To(Equal(dao.VideoMetadata{
+					Title:       "Big Buck Bunny",
+					Description: "A short computer-animated comedy film",
+					Tags:        []string{"animation", "comedy"},
+					Language:    "zh-TW",
+					Visibility:  dao.VideoVisibilityPublic,
+				}))
+				Expect(created.OwnerID).To(BeEmpty())
+			})
+		})
+
+		When("video is scheduled", func() {
+			var publishAt time.Time
+
+			BeforeEach(func() {
+				publishAt = time.Now().UTC().Add(time.Hour).Truncate(time.Millisecond)
+				header.PublishAt = timestamppb.New(publishAt)
+
+				expectHeader()
+				expectChunks(file)
+				stream.EXPECT().Recv().Return(nil, io.EOF)
+
+				storage.EXPECT().PutObject(ctx, gomock.Any(), gomock.Any(), int64(size), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, reader io.Reader, _ int64, _ storagekit.PutObjectOptions) error {
+						_, rerr := io.Copy(io.Discard, reader)
+						return rerr
+					})
+
+				expectVideoCreated()
+			})
+
+			It("creates the scheduled video", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(created.PublishStatus).To(Equal(dao.VideoPublishStatusScheduled))
+				Expect(created.PublishAt).To(Equal(publishAt))
+			})
+		})
+
+		When("video is private and the user is anonymous", func() {
+			BeforeEach(func() {
+				header.Visibility = "private"
+
+				expectHeader()
+			})
+
+			It("returns invalid visibility error before uploading", func() {
+				Expect(err).To(MatchError(ErrInvalidVisibility))
+			})
+		})
+
+		When("metadata is invalid", func() {
+			BeforeEach(func() {
+				header.Language = "not a language"
+
+				expectHeader()
+			})
+
+			It("returns invalid language error before uploading", func() {
+				Expect(err).To(MatchError(ErrInvalidLanguage))
+			})
+		})
+
+		When("file is not a video", func() {
+			BeforeEach(func() {
+				file = []byte("this is a text file rather than a video")
+				size = uint64(len(file))
+
+				expectHeader()
+				expectChunks(file)
+				stream.EXPECT().Recv().Return(nil, io.EOF)
+
+				storage.EXPECT().PutObject(ctx, gomock.Any(), gomock.Any(), int64(size), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, reader io.Reader, _ int64, _ storagekit.PutObjectOptions) error {
+						_, rerr := io.Copy(io.Discard, reader)
+						return rerr
+					})
+				expectStoredObject(storage, gomock.Any(), file)
+				storage.EXPECT().RemoveObject(ctx, gomock.Any()).Return(nil)
+			})
+
+			It("removes the object and returns invalid video error", func() {
+				Expect(err).To(MatchError(ErrInvalidVideo))
+			})
+		})
+
+		When("success with unknown size", func() {
+			BeforeEach(func() {
+				size = 0
+
+				expectHeader()
+				expectChunks(file)
+				stream.EXPECT().Recv().Return(nil, io.EOF)
+
+				storage.EXPECT().PutObject(ctx, gomock.Any(), gomock.Any(), int64(-1), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, reader io.Reader, _ int64, _ storagekit.PutObjectOptions) error {
+						_, rerr := io.Copy(io.Discard, reader)
+						return rerr
+					})
+
+				expectVideoCreated()
+			})
+
+			It("returns no error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("UpdateVideo", func() {
+		var (
+			req       *pb.UpdateVideoRequest
+			id        primitive.ObjectID
+			updatedAt time.Time
+			resp      *pb.UpdateVideoResponse
+			err       error
+		)
+
+		BeforeEach(func() {
+			id = primitive.NewObjectID()
+			updatedAt = time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC)
+			req = &pb.UpdateVideoRequest{
+				Id: id.Hex(),
+				Video: &pb.VideoInfo{
+					Title:    " New Title ",
+					Tags:     []string{"Tag"},
+					Language: "en",
+				},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"title", "tags"}},
+				UpdatedAt:  timestamppb.New(updatedAt),
+			}
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-user-id", "owner"))
+		})
+
+		JustBeforeEach(func() {
+			resp, err = svc.UpdateVideo(ctx, req)
+		})
+
+		When("id is invalid", func() {
+			BeforeEach(func() { req.Id = "invalid" })
+
+			It("returns invalid object ID error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrInvalidObjectID))
+			})
+		})
+
+		When("update mask is empty", func() {
+			BeforeEach(func() { req.UpdateMask = nil })
+
+			It("returns invalid update mask error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrInvalidUpdateMask))
+			})
+		})
+
+		When("update mask has a field not updatable", func() {
+			BeforeEach(func() { req.UpdateMask.Paths = append(req.UpdateMask.Paths, "status") })
+
+			It("returns invalid update mask error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrInvalidUpdateMask))
+			})
+		})
+
+		When("updated_at is missing", func() {
+			BeforeEach(func() { req.UpdatedAt = nil })
+
+			It("returns updated_at required error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrUpdatedAtRequired))
+			})
+		})
+
+		When("title is too long", func() {
+			BeforeEach(func() { req.Video.Title = strings.Repeat("a", 101) })
+
+			It("returns invalid title error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrInvalidTitle))
+			})
+		})
+
+		When("visibility is invalid", func() {
+			BeforeEach(func() {
+				req.Video.Visibility = "friends"
+				req.UpdateMask.Paths = append(req.UpdateMask.Paths, "visibility")
+			})
+
+			It("returns invalid visibility error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrInvalidVisibility))
+			})
+		})
+
+		When("video not found", func() {
+			BeforeEach(func() {
+				videoDAO.EXPECT().Get(ctx, id).Return(nil, dao.ErrVideoNotFound)
+			})
+
+			It("returns video not found error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrVideoNotFound))
+			})
+		})
+
+		When("video is private to another user", func() {
+			BeforeEach(func() {
+				video := dao.NewFakeVideo()
+				video.Visibility = dao.VideoVisibilityPrivate
+				video.OwnerID = "another user"
+
+				videoDAO.EXPECT().Get(ctx, id).Return(video, nil)
+			})
+
+			It("returns video not found error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrVideoNotFound))
+			})
+		})
+
+		When("video is owned by another user", func() {
+			BeforeEach(func() {
+				video := dao.NewFakeVideo()
+				video.OwnerID = "another user"
+
+				videoDAO.EXPECT().Get(ctx, id).Return(video, nil)
+			})
+
+			It("returns not video owner error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrNotVideoOwner))
+			})
+		})
+
+		When("video has no owner", func() {
+			BeforeEach(func() {
+				videoDAO.EXPECT().Get(ctx, id).Return(dao.NewFakeVideo(), nil)
+			})
+
+			It("returns not video owner error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrNotVideoOwner))
+			})
+		})
+
+		When("video is deleted while updating", func() {
+			BeforeEach(func() {
+				videoDAO.EXPECT().Get(ctx, id).Return(newOwnedFakeVideo(), nil)
+				videoDAO.EXPECT().UpdateMetadata(ctx, id, gomock.Any(), gomock.Any(), updatedAt).Return(nil, dao.ErrVideoNotFound)
+			})
+
+			It("returns video not found error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrVideoNotFound))
+			})
+		})
+
+		When("video has been updated", func() {
+			BeforeEach(func() {
+				videoDAO.EXPECT().Get(ctx, id).Return(newOwnedFakeVideo(), nil)
+				videoDAO.EXPECT().UpdateMetadata(ctx, id, gomock.Any(), gomock.Any(), updatedAt).Return(nil, dao.ErrVideoUpdateConflict)
+			})
+
+			It("returns video update conflict error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrVideoUpdateConflict))
+			})
+		})
+
+		When("success", func() {
+			var video *dao.Video
+
+			BeforeEach(func() {
+				video = dao.NewFakeVideo()
+				video.ID = id
+				video.Title = "New Title"
+				video.Tags = []string{"tag"}
+
+				videoDAO.EXPECT().Get(ctx, id).Return(newOwnedFakeVideo(), nil)
+				videoDAO.EXPECT().UpdateMetadata(ctx, id, &dao.VideoMetadata{
+					Title: "New Title",
+					Tags:  []string{"tag"},
+				}, []string{dao.VideoFieldTitle, dao.VideoFieldTags}, updatedAt).Return(video, nil)
+				expectPresignedGetObject(storage)
+			})
+
+			It("updates the fields in the mask and returns the video", func() {
+				Expect(resp).To(Equal(&pb.UpdateVideoResponse{Video: presignedVideoInfo(video)}))
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("DeleteVideo", func() {
+		var (
+			req  *pb.DeleteVideoRequest
+			id   primitive.ObjectID
+			resp *pb.DeleteVideoResponse
+			err  error
+		)
+
+		BeforeEach(func() {
+			id = primitive.NewObjectID()
+			req = &pb.DeleteVideoRequest{Id: id.Hex()}
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-user-id", "owner"))
+		})
+
+		JustBeforeEach(func() {
+			resp, err = svc.DeleteVideo(ctx, req)
+		})
+
+		When("id is invalid", func() {
+			BeforeEach(func() { req.Id = "invalid" })
+
+			It("returns invalid object ID error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrInvalidObjectID))
+			})
+		})
+
+		When("video not found", func() {
+			BeforeEach(func() {
+				videoDAO.EXPECT().Get(ctx, id).Return(nil, dao.ErrVideoNotFound)
+			})
+
+			It("returns video not found error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrVideoNotFound))
+			})
+		})
+
+		When("video is private to another user", func() {
+			BeforeEach(func() {
+				video := dao.NewFakeVideo()
+				video.Visibility = dao.VideoVisibilityPrivate
+				video.OwnerID = "another user"
+
+				videoDAO.EXPECT().Get(ctx, id).Return(video, nil)
+			})
+
+			It("returns video not found error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrVideoNotFound))
+			})
+		})
+
+		When("video is owned by another user", func() {
+			BeforeEach(func() {
+				video := dao.NewFakeVideo()
+				video.OwnerID = "another user"
+
+				videoDAO.EXPECT().Get(ctx, id).Return(video, nil)
+			})
+
+			It("returns not video owner error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrNotVideoOwner))
+			})
+		})
+
+		When("user is anonymous", func() {
+			BeforeEach(func() {
+				ctx = context.Background()
+				videoDAO.EXPECT().Get(ctx, id).Return(dao.NewFakeVideo(), nil)
+			})
+
+			It("returns not video owner error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrNotVideoOwner))
+			})
+		})
+
+		When("video is deleted by another request", func() {
+			BeforeEach(func() {
+				videoDAO.EXPECT().Get(ctx, id).Return(newOwnedFakeVideo(), nil)
+				videoDAO.EXPECT().SoftDelete(ctx, id).Return(dao.ErrVideoNotFound)
+			})
+
+			It("returns video not found error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrVideoNotFound))
+			})
+		})
+
+		When("DAO error", func() {
+			BeforeEach(func() {
+				videoDAO.EXPECT().Get(ctx, id).Return(newOwnedFakeVideo(), nil)
+				videoDAO.EXPECT().SoftDelete(ctx, id).Return(errDAOUnknown)
+			})
+
+			It("returns the error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(errDAOUnknown))
+			})
+		})
+
+		When("success", func() {
+			BeforeEach(func() {
+				videoDAO.EXPECT().Get(ctx, id).Return(newOwnedFakeVideo(), nil)
+				videoDAO.EXPECT().SoftDelete(ctx, id).Return(nil)
+			})
+
+			It("moves the video to the trash without removing the objects and the comments", func() {
+				Expect(resp).To(Equal(&pb.DeleteVideoResponse{}))
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("RestoreVideo", func() {
+		var (
+			req  *pb.RestoreVideoRequest
+			id   primitive.ObjectID
+			resp *pb.RestoreVideoResponse
+			err  error
+		)
+
+		BeforeEach(func() {
+			id = primitive.NewObjectID()
+			req = &pb.RestoreVideoRequest{Id: id.Hex()}
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-user-id", "owner"))
+
+			storage.EXPECT().Endpoint().AnyTimes().Return("play.min.io")
+			storage.EXPECT().Bucket().AnyTimes().Return("videos")
+		})
+
+		JustBeforeEach(func() {
+			resp, err = svc.RestoreVideo(ctx, req)
+		})
+
+		When("id is invalid", func() {
+			BeforeEach(func() { req.Id = "invalid" })
+
+			It("returns invalid object ID error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrInvalidObjectID))
+			})
+		})
+
+		When("user is anonymous", func() {
+			BeforeEach(func() { ctx = context.Background() })
+
+			It("returns sign-in required error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrSignInRequired))
+			})
+		})
+
+		When("video not found in the trash of the user", func() {
+			BeforeEach(func() {
+				videoDAO.EXPECT().Restore(ctx, id, "owner").Return(nil, dao.ErrVideoNotFound)
+			})
+
+			It("returns deleted video not found error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrDeletedVideoNotFound))
+			})
+		})
+
+		When("DAO error", func() {
+			BeforeEach(func() {
+				videoDAO.EXPECT().Restore(ctx, id, "owner").Return(nil, errDAOUnknown)
+			})
+
+			It("returns the error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(errDAOUnknown))
+			})
+		})
+
+		When("success", func() {
+			var video *dao.Video
+
+			BeforeEach(func() {
+				video = dao.NewFakeVideo()
+				video.ID = id
+				video.OwnerID = "owner"
+				videoDAO.EXPECT().Restore(ctx, id, "owner").Return(video, nil)
+				expectPresignedGetObject(storage)
+			})
+
+			It("returns the restored video", func() {
+				Expect(resp).To(Equal(&pb.RestoreVideoResponse{Video: presignedVideoInfo(video)}))
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("ListDeletedVideos", func() {
+		var (
+			req  *pb.ListDeletedVideosRequest
+			opts *dao.ListVideoOptions
+			resp *pb.ListDeletedVideosResponse
+			err  error
+		)
+
+		BeforeEach(func() {
+			req = &pb.ListDeletedVideosRequest{PageToken: "token"}
+			opts = &dao.ListVideoOptions{
+				Limit:     defaultPageSize,
+				SortBy:    dao.VideoSortDeletedAt,
+				Ascending: true,
+				Deleted:   true,
+				OwnerID:   "owner",
+				PageToken: "token",
+			}
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-user-id", "owner"))
+
+			storage.EXPECT().Endpoint().AnyTimes().Return("play.min.io")
+			storage.EXPECT().Bucket().AnyTimes().Return("videos")
+		})
+
+		JustBeforeEach(func() {
+			resp, err = svc.ListDeletedVideos(ctx, req)
+		})
+
+		When("user is anonymous", func() {
+			BeforeEach(func() { ctx = context.Background() })
+
+			It("returns sign-in required error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrSignInRequired))
+			})
+		})
+
+		When("page token is invalid", func() {
+			BeforeEach(func() {
+				videoDAO.EXPECT().List(ctx, opts).Return(nil, dao.ErrInvalidPageToken)
+			})
+
+			It("returns invalid page token error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrInvalidPageToken))
+			})
+		})
+
+		When("success", func() {
+			var video *dao.Video
+
+			BeforeEach(func() {
+				video = dao.NewFakeVideo()
+				video.DeletedAt = time.Now().UTC().Truncate(time.Millisecond)
+				videoDAO.EXPECT().List(ctx, opts).Return(&dao.VideoPage{Videos: []*dao.Video{video}}, nil)
+				expectPresignedGetObject(storage)
+			})
+
+			It("returns the deleted videos of the user", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp).To(Equal(&pb.ListDeletedVideosResponse{Videos: []*pb.VideoInfo{presignedVideoInfo(video)}}))
+				Expect(resp.GetVideos()[0].GetDeletedAt().AsTime()).To(Equal(video.DeletedAt))
+			})
+		})
+	})
+
+	Describe("CreateUploadSession", func() {
+		var (
+			req  *pb.CreateUploadSessionRequest
+			resp *pb.CreateUploadSessionResponse
+			err  error
+		)
+
+		BeforeEach(func() {
+			req = &pb.CreateUploadSessionRequest{Filename: "video.mp4", Size: 12 << 20}
+		})
+
+		JustBeforeEach(func() {
+			resp, err = svc.CreateUploadSession(ctx, req)
+		})
+
+		When("size is zero", func() {
+			BeforeEach(func() { req.Size = 0 })
+
+			It("returns invalid upload size error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrInvalidUploadSize))
+			})
+		})
+
+		When("storage error", func() {
+			BeforeEach(func() {
+				storage.EXPECT().NewMultipartUpload(ctx, gomock.Any(), gomock.Any()).Return("", errStorageUnknown)
+			})
+
+			It("returns the error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(errStorageUnknown))
+			})
+		})
+
+		When("video is private and the user is anonymous", func() {
+			BeforeEach(func() { req.Visibility = "private" })
+
+			It("returns invalid visibility error before creating the session", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrInvalidVisibility))
+			})
+		})
+
+		When("metadata is invalid", func() {
+			BeforeEach(func() { req.Language = "not a language" })
+
+			It("returns invalid language error before creating the session", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrInvalidLanguage))
+			})
+		})
+
+		When("success", func() {
+			var created *dao.UploadSession
+
+			BeforeEach(func() {
+				storage.EXPECT().NewMultipartUpload(ctx, gomock.Any(), gomock.Any()).Return("fake-upload-id", nil)
+				uploadSessionDAO.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, session *dao.UploadSession) error {
+					created = session
+					return nil
+				})
+			})
+
+			It("returns the active session with no error", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.GetSession().GetSize()).To(Equal(req.GetSize()))
+				Expect(resp.GetSession().GetOffset()).To(BeZero())
+				Expect(resp.GetSession().GetStatus()).To(Equal(dao.UploadSessionStatusActive.String()))
+				Expect(resp.GetUploadUrl()).To(BeEmpty())
+			})
+
+			It("titles the video by the filename", func() {
+				Expect(created.VideoMetadata.Title).To(Equal("video"))
+				Expect(created.VideoMetadata.Visibility).To(Equal(dao.VideoVisibilityPublic))
+				Expect(created.PublishAt).To(BeZero())
+			})
+		})
+
+		When("success with metadata", func() {
+			var (
+				created   *dao.UploadSession
+				publishAt time.Time
+			)
+
+			BeforeEach(func() {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-user-id", "owner"))
+				publishAt = time.Now().UTC().Add(time.Hour).Truncate(time.Millisecond)
+
+				req.Title = "  Big Buck Bunny "
+				req.Tags = []string{"Animation", "comedy", "animation", " "}
+				req.Language = "zh-tw"
+				req.Visibility = "private"
+				req.PublishAt = timestamppb.New(publishAt)
+
+				storage.EXPECT().NewMultipartUpload(ctx, gomock.Any(), gomock.Any()).Return("fake-upload-id", nil)
+				uploadSessionDAO.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, session *dao.UploadSession) error {
+					created = session
+					return nil
+				})
+			})
+
+			It("stores the normalized metadata and the owner on the session", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(created.OwnerID).To(Equal("owner"))
+				Expect(created.VideoMetadata).To(Equal(dao.VideoMetadata{
+					Title:      "Big Buck Bunny",
+					Tags:       []string{"animation", "comedy"},
+					Language:   "zh-TW",
+					Visibility: dao.VideoVisibilityPrivate,
+				}))
+				Expect(created.PublishAt).To(Equal(publishAt))
+			})
+		})
+
+		When("presigned but storage does not support presigned URL", func() {
+			BeforeEach(func() {
+				req.Presigned = true
+				storage.EXPECT().PresignedPutObject(ctx, gomock.Any()).Return(nil, storagekit.ErrPresignNotSupported)
+			})
+
+			It("returns presign not supported error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrPresignNotSupported))
+			})
+		})
+
+		When("presigned success", func() {
+			var expiresAt time.Time
+
+			BeforeEach(func() {
+				req.Presigned = true
+				expiresAt = time.Now().Add(15 * time.Minute)
+
+				storage.EXPECT().PresignedPutObject(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, objectName string) (*storagekit.PresignedURL, error) {
+					return &storagekit.PresignedURL{URL: presignedURL(objectName), ExpiresAt: expiresAt}, nil
+				})
+				uploadSessionDAO.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, session *dao.UploadSession) error {
+					Expect(session.Presigned).To(BeTrue())
+					Expect(session.UploadID).To(BeEmpty())
+					return nil
+				})
+			})
+
+			It("returns the presigned session and the upload URL with no error", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.GetSession().GetPresigned()).To(BeTrue())
+				Expect(resp.GetUploadUrl()).To(HavePrefix("https://play.min.io/videos/"))
+				Expect(resp.GetUploadUrlExpiresAt().AsTime()).To(BeTemporally("==", expiresAt))
+			})
+		})
+	})
+
+	Describe("GetUploadSession", func() {
+		var (
+			req     *pb.GetUploadSessionRequest
+			session *dao.UploadSession
+			resp    *pb.GetUploadSessionResponse
+			err     error
+		)
+
+		BeforeEach(func() {
+			session = dao.NewFakeUploadSession()
+			req = &pb.GetUploadSessionRequest{Id: session.ID.Hex()}
+		})
+
+		JustBeforeEach(func() {
+			resp, err = svc.GetUploadSession(ctx, req)
+		})
+
+		When("invalid id", func() {
+			BeforeEach(func() { req.Id = "invalid" })
+
+			It("returns invalid object id error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrInvalidObjectID))
+			})
+		})
+
+		When("session not found", func() {
+			BeforeEach(func() {
+				uploadSessionDAO.EXPECT().Get(ctx, session.ID).Return(nil, dao.ErrUploadSessionNotFound)
+			})
+
+			It("returns upload session not found error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrUploadSessionNotFound))
+			})
+		})
+
+		When("session is owned by another user", func() {
+			BeforeEach(func() {
+				session.OwnerID = "owner"
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-user-id", "another user"))
+				uploadSessionDAO.EXPECT().Get(ctx, session.ID).Return(session, nil)
+			})
+
+			It("returns upload session not found error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrUploadSessionNotFound))
+			})
+		})
+
+		When("session is owned by the caller", func() {
+			BeforeEach(func() {
+				session.OwnerID = "owner"
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-user-id", "owner"))
+				uploadSessionDAO.EXPECT().Get(ctx, session.ID).Return(session, nil)
+			})
+
+			It("returns the session with no error", func() {
+				Expect(resp).To(Equal(&pb.GetUploadSessionResponse{Session: session.ToProto()}))
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		When("success", func() {
+			BeforeEach(func() {
+				uploadSessionDAO.EXPECT().Get(ctx, session.ID).Return(session, nil)
+			})
+
+			It("returns the session with no error", func() {
+				Expect(resp).To(Equal(&pb.GetUploadSessionResponse{Session: session.ToProto()}))
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("UploadPart", func() {
+		var (
+			stream  *pbmock.MockVideo_UploadPartServer
+			session *dao.UploadSession
+			offset  uint64
+			size    uint64
+			err     error
+		)
+
+		BeforeEach(func() {
+			stream = pbmock.NewMockVideo_UploadPartServer(controller)
+			stream.EXPECT().Context().Return(ctx)
+
+			session = dao.NewFakeUploadSession()
+			offset, size = 0, minUploadPartSize
+
+			// the header is evaluated lazily since each case overrides the offset and size
+			stream.EXPECT().Recv().DoAndReturn(func() (*pb.UploadPartRequest, error) {
+				return &pb.UploadPartRequest{
+					Data: &pb.UploadPartRequest_Header{
+						Header: &pb.UploadPartHeader{
+							SessionId: session.ID.Hex(),
+							Offset:    offset,
+							Size:      size,
+						},
+					},
+				}, nil
+			})
+		})
+
+		JustBeforeEach(func() {
+			err = svc.UploadPart(stream)
+		})
+
+		When("session is owned by another user", func() {
+			BeforeEach(func() {
+				session.OwnerID = "owner"
+				uploadSessionDAO.EXPECT().Get(ctx, session.ID).Return(session, nil)
+			})
+
+			It("returns upload session not found error without uploading the part", func() {
+				Expect(err).To(MatchError(ErrUploadSessionNotFound))
+			})
+		})
+
+		When("offset mismatch", func() {
+			BeforeEach(func() {
+				offset = 1024
+				uploadSessionDAO.EXPECT().Get(ctx, session.ID).Return(session, nil)
+			})
+
+			It("returns upload offset mismatch error", func() {
+				Expect(err).To(MatchError(ErrUploadOffsetMismatch))
+			})
+		})
+
+		When("part is too small", func() {
+			BeforeEach(func() {
+				size = 1024
+				uploadSessionDAO.EXPECT().Get(ctx, session.ID).Return(session, nil)
+			})
+
+			It("returns invalid upload size error", func() {
+				Expect(err).To(MatchError(ErrInvalidUploadSize))
+			})
+		})
+
+		When("session is not active", func() {
+			BeforeEach(func() {
+				session.Status = dao.UploadSessionStatusAborted
+				uploadSessionDAO.EXPECT().Get(ctx, session.ID).Return(session, nil)
+			})
+
+			It("returns upload session not active error", func() {
+				Expect(err).To(MatchError(ErrUploadSessionNotActive))
+			})
+		})
+
+		When("session is presigned", func() {
+			BeforeEach(func() {
+				session.Presigned = true
+				uploadSessionDAO.EXPECT().Get(ctx, session.ID).Return(session, nil)
+			})
+
+			It("returns upload session presigned error", func() {
+				Expect(err).To(MatchError(ErrUploadSessionPresigned))
+			})
+		})
+
+		When("offset moved before reserving the part number", func() {
+			BeforeEach(func() {
+				uploadSessionDAO.EXPECT().Get(ctx, session.ID).Return(session, nil)
+				uploadSessionDAO.EXPECT().ReservePartNumber(ctx, session.ID, offset).Return(0, dao.ErrUploadSessionConflict)
+			})
+
+			It("returns upload offset mismatch error without uploading", func() {
+				Expect(err).To(MatchError(ErrUploadOffsetMismatch))
+			})
+		})
+
+		When("offset moved concurrently", func() {
+			BeforeEach(func() {
+				uploadSessionDAO.EXPECT().Get(ctx, session.ID).Return(session, nil)
+				// the concurrent upload has reserved the first part number
+				uploadSessionDAO.EXPECT().ReservePartNumber(ctx, session.ID, offset).Return(2, nil)
+				stream.EXPECT().Recv().Return(&pb.UploadPartRequest{
+					Data: &pb.UploadPartRequest_ChunkData{ChunkData: make([]byte, size)},
+				}, nil)
+				stream.EXPECT().Recv().Return(nil, io.EOF)
+
+				storage.EXPECT().PutObjectPart(ctx, session.ObjectName, session.UploadID, 2, gomock.Any(), int64(size)).
+					DoAndReturn(func(_ context.Context, _, _ string, partNumber int, reader io.Reader, _ int64) (*storagekit.ObjectPart, error) {
+						n, rerr := io.Copy(io.Discard, reader)
+						return &storagekit.ObjectPart{PartNumber: partNumber, ETag: "etag", Size: n}, rerr
+					})
+
+				uploadSessionDAO.EXPECT().AppendPart(ctx, session.ID, offset, gomock.Any()).Return(dao.ErrUploadSessionConflict)
+			})
+
+			It("returns upload offset mismatch error", func() {
+				Expect(err).To(MatchError(ErrUploadOffsetMismatch))
+			})
+		})
+
+		When("success", func() {
+			BeforeEach(func() {
+				uploadSessionDAO.EXPECT().Get(ctx, session.ID).Return(session, nil).Times(2)
+				uploadSessionDAO.EXPECT().ReservePartNumber(ctx, session.ID, offset).Return(1, nil)
+				stream.EXPECT().Recv().Return(&pb.UploadPartRequest{
+					Data: &pb.UploadPartRequest_ChunkData{ChunkData: make([]byte, size)},
+				}, nil)
+				stream.EXPECT().Recv().Return(nil, io.EOF)
+
+				storage.EXPECT().PutObjectPart(ctx, session.ObjectName, session.UploadID, 1, gomock.Any(), int64(size)).
+					DoAndReturn(func(_ context.Context, _, _ string, partNumber int, reader io.Reader, _ int64) (*storagekit.ObjectPart, error) {
+						n, rerr := io.Copy(io.Discard, reader)
+						return &storagekit.ObjectPart{PartNumber: partNumber, ETag: "etag", Size: n}, rerr
+					})
+
+				uploadSessionDAO.EXPECT().AppendPart(ctx, session.ID, offset, &dao.UploadPart{
+					Number: 1,
+					ETag:   "etag",
+					Size:   size,
+				}).Return(nil)
+
+				stream.EXPECT().SendAndClose(gomock.Any()).Return(nil)
+			})
+
+			It("returns no error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("CompleteUploadSession", func() {
+		var (
+			req     *pb.CompleteUploadSessionRequest
+			session *dao.UploadSession
+			resp    *pb.CompleteUploadSessionResponse
+			created *dao.Video
+			err     error
+		)
+
+		BeforeEach(func() {
+			session = dao.NewFakeUploadSession()
+			req = &pb.CompleteUploadSessionRequest{Id: session.ID.Hex()}
+		})
+
+		JustBeforeEach(func() {
+			resp, err = svc.CompleteUploadSession(ctx, req)
+		})
+
+		When("session is owned by another user", func() {
+			BeforeEach(func() {
+				session.OwnerID = "owner"
+				session.Offset = session.Size
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-user-id", "another user"))
+				uploadSessionDAO.EXPECT().Get(ctx, session.ID).Return(session, nil)
+			})
+
+			It("returns upload session not found error without completing the upload", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrUploadSessionNotFound))
+			})
+		})
+
+		When("upload is incomplete", func() {
+			BeforeEach(func() {
+				uploadSessionDAO.EXPECT().Get(ctx, session.ID).Return(session, nil)
+			})
+
+			It("returns upload incomplete error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrUploadIncomplete))
+			})
+		})
+
+		When("success", func() {
+			BeforeEach(func() {
+				session.Offset = session.Size
+				session.Parts = []*dao.UploadPart{
+					{Number: 1, ETag: "etag-1", Size: session.Size / 2},
+					{Number: 2, ETag: "etag-2", Size: session.Size / 2},
+				}
+
+				uploadSessionDAO.EXPECT().Get(ctx, session.ID).Return(session, nil)
+				storage.EXPECT().CompleteMultipartUpload(ctx, session.ObjectName, session.UploadID, []*storagekit.ObjectPart{
+					{PartNumber: 1, ETag: "etag-1", Size: int64(session.Size / 2)},
+					{PartNumber: 2, ETag: "etag-2", Size: int64(session.Size / 2)},
+				}).Return(nil)
+				expectStoredObject(storage, session.ObjectName, readFixture())
+				uploadSessionDAO.EXPECT().UpdateStatus(ctx, session.ID, dao.UploadSessionStatusActive, dao.UploadSessionStatusCompleted).Return(nil)
+
+				videoDAO.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, video *dao.Video) error {
+					created = video
+					return nil
+				})
+				producer.EXPECT().SendMessages(gomock.Any()).Return(nil)
+			})
+
+			It("returns the video id with no error", func() {
+				Expect(resp).To(Equal(&pb.CompleteUploadSessionResponse{VideoId: session.VideoID.Hex()}))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("creates the video with the metadata of the session", func() {
+				Expect(created.ID).To(Equal(session.VideoID))
+				Expect(created.VideoMetadata).To(Equal(session.VideoMetadata))
+				Expect(created.PublishStatus).To(BeEmpty())
+			})
+		})
+
+		When("session is scheduled and owned", func() {
+			var publishAt time.Time
+
+			BeforeEach(func() {
+				publishAt = time.Now().UTC().Add(time.Hour).Truncate(time.Millisecond)
+				session.Presigned = true
+				session.OwnerID = "owner"
+				session.PublishAt = publishAt
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-user-id", "owner"))
+
+				uploadSessionDAO.EXPECT().Get(ctx, session.ID).Return(session, nil)
+				storage.EXPECT().StatObject(ctx, session.ObjectName).Return(&storagekit.ObjectInfo{
+					Name: session.ObjectName,
+					Size: int64(session.Size),
+				}, nil)
+				expectStoredObject(storage, session.ObjectName, readFixture())
+				uploadSessionDAO.EXPECT().UpdateStatus(ctx, session.ID, dao.UploadSessionStatusActive, dao.UploadSessionStatusCompleted).Return(nil)
+
+				videoDAO.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, video *dao.Video) error {
+					created = video
+					return nil
+				})
+				producer.EXPECT().SendMessages(gomock.Any()).Return(nil)
+			})
+
+			It("creates the scheduled video owned by the creator of the session", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(created.OwnerID).To(Equal("owner"))
+				Expect(created.PublishStatus).To(Equal(dao.VideoPublishStatusScheduled))
+				Expect(created.PublishAt).To(Equal(publishAt))
+			})
+		})
+
+		When("video cannot be created", func() {
+			BeforeEach(func() {
+				session.Presigned = true
+				uploadSessionDAO.EXPECT().Get(ctx, session.ID).Return(session, nil)
+				storage.EXPECT().StatObject(ctx, session.ObjectName).Return(&storagekit.ObjectInfo{
+					Name: session.ObjectName,
+					Size: int64(session.Size),
+				}, nil)
+				expectStoredObject(storage, session.ObjectName, readFixture())
+				uploadSessionDAO.EXPECT().UpdateStatus(ctx, session.ID, dao.UploadSessionStatusActive, dao.UploadSessionStatusCompleted).Return(nil)
+				videoDAO.EXPECT().Create(ctx, gomock.Any()).Return(errDAOUnknown)
+				uploadSessionDAO.EXPECT().UpdateStatus(ctx, session.ID, dao.UploadSessionStatusCompleted, dao.UploadSessionStatusActive).Return(nil)
+			})
+
+			It("reopens the session and returns the error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(errDAOUnknown))
+			})
+		})
+
+		When("multipart upload has been completed by a previous request", func() {
+			BeforeEach(func() {
+				session.Offset = session.Size
+				session.Parts = []*dao.UploadPart{
+					{Number: 1, ETag: "etag-1", Size: session.Size},
+				}
+
+				uploadSessionDAO.EXPECT().Get(ctx, session.ID).Return(session, nil)
+				storage.EXPECT().CompleteMultipartUpload(ctx, session.ObjectName, session.UploadID, gomock.Any()).Return(storagekit.ErrUploadNotFound)
+				storage.EXPECT().StatObject(ctx, session.ObjectName).Return(&storagekit.ObjectInfo{
+					Name: session.ObjectName,
+					Size: int64(session.Size),
+				}, nil)
+				expectStoredObject(storage, session.ObjectName, readFixture())
+				uploadSessionDAO.EXPECT().UpdateStatus(ctx, session.ID, dao.UploadSessionStatusActive, dao.UploadSessionStatusCompleted).Return(nil)
+
+				videoDAO.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+				producer.EXPECT().SendMessages(gomock.Any()).Return(nil)
+			})
+
+			It("creates the video from the completed object", func() {
+				Expect(resp).To(Equal(&pb.CompleteUploadSessionResponse{VideoId: session.VideoID.Hex()}))
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		When("upload is not a video", func() {
+			BeforeEach(func() {
+				session.Offset = session.Size
+				session.Parts = []*dao.UploadPart{
+					{Number: 1, ETag: "etag-1", Size: session.Size},
+				}
+
+				uploadSessionDAO.EXPECT().Get(ctx, session.ID).Return(session, nil)
+				storage.EXPECT().CompleteMultipartUpload(ctx, session.ObjectName, session.UploadID, gomock.Any()).Return(nil)
+				expectStoredObject(storage, session.ObjectName, []byte("this is a text file rather than a video"))
+				uploadSessionDAO.EXPECT().UpdateStatus(ctx, session.ID, dao.UploadSessionStatusActive, dao.UploadSessionStatusAborted).Return(nil)
+				storage.EXPECT().RemoveObject(ctx, session.ObjectName).Return(nil)
+			})
+
+			It("aborts the session and returns invalid video error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrInvalidVideo))
+			})
+		})
+
+		When("presigned object is not uploaded", func() {
+			BeforeEach(func() {
+				session.Presigned = true
+				uploadSessionDAO.EXPECT().Get(ctx, session.ID).Return(session, nil)
+				storage.EXPECT().StatObject(ctx, session.ObjectName).Return(nil, storagekit.ErrObjectNotFound)
+			})
+
+			It("returns upload incomplete error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrUploadIncomplete))
+			})
+		})
+
+		When("presigned object size mismatches", func() {
+			BeforeEach(func() {
+				session.Presigned = true
+				uploadSessionDAO.EXPECT().Get(ctx, session.ID).Return(session, nil)
+				storage.EXPECT().StatObject(ctx, session.ObjectName).Return(&storagekit.ObjectInfo{
+					Name: session.ObjectName,
+					Size: int64(session.Size) - 1,
+				}, nil)
+			})
+
+			It("returns video size mismatch error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrVideoSizeMismatch))
+			})
+		})
+
+		When("presigned success", func() {
+			BeforeEach(func() {
+				session.Presigned = true
+				uploadSessionDAO.EXPECT().Get(ctx, session.ID).Return(session, nil)
+				storage.EXPECT().StatObject(ctx, session.ObjectName).Return(&storagekit.ObjectInfo{
+					Name: session.ObjectName,
+					Size: int64(session.Size),
+				}, nil)
+				expectStoredObject(storage, session.ObjectName, readFixture())
+				uploadSessionDAO.EXPECT().UpdateStatus(ctx, session.ID, dao.UploadSessionStatusActive, dao.UploadSessionStatusCompleted).Return(nil)
+
+				videoDAO.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+				producer.EXPECT().SendMessages(gomock.Any()).Return(nil)
+			})
+
+			It("returns the video id with no error", func() {
+				Expect(resp).To(Equal(&pb.CompleteUploadSessionResponse{VideoId: session.VideoID.Hex()}))
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("AbortUploadSession", func() {
+		var (
+			req     *pb.AbortUploadSessionRequest
+			session *dao.UploadSession
+			resp    *pb.AbortUploadSessionResponse
+			err     error
+		)
+
+		BeforeEach(func() {
+			session = dao.NewFakeUploadSession()
+			req = &pb.AbortUploadSessionRequest{Id: session.ID.Hex()}
+		})
+
+		JustBeforeEach(func() {
+			resp, err = svc.AbortUploadSession(ctx, req)
+		})
+
+		When("session is owned by another user", func() {
+			BeforeEach(func() {
+				session.OwnerID = "owner"
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-user-id", "another user"))
+				uploadSessionDAO.EXPECT().Get(ctx, session.ID).Return(session, nil)
+			})
+
+			It("returns upload session not found error without aborting the upload", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrUploadSessionNotFound))
+			})
+		})
+
+		When("session is not active", func() {
+			BeforeEach(func() {
+				session.Status = dao.UploadSessionStatusCompleted
+				uploadSessionDAO.EXPECT().Get(ctx, session.ID).Return(session, nil)
+			})
+
+			It("returns upload session not active error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(MatchError(ErrUploadSessionNotActive))
+			})
+		})
+
+		When("success", func() {
+			BeforeEach(func() {
+				uploadSessionDAO.EXPECT().Get(ctx, session.ID).Return(session, nil)
+				storage.EXPECT().AbortMultipartUpload(ctx, session.ObjectName, session.UploadID).Return(nil)
+				uploadSessionDAO.EXPECT().UpdateStatus(ctx, session.ID, dao.UploadSessionStatusActive, dao.UploadSessionStatusAborted).Return(nil)
+			})
+
+			It("returns no error", func() {
+				Expect(resp).To(Equal(&pb.AbortUploadSessionResponse{}))
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		When("presigned success", func() {
+			BeforeEach(func() {
+				session.Presigned = true
+				uploadSessionDAO.EXPECT().Get(ctx, session.ID).Return(session, nil)
+				storage.EXPECT().RemoveObject(ctx, session.ObjectName).Return(nil)
+				uploadSessionDAO.EXPECT().UpdateStatus(ctx, session.ID, dao.UploadSessionStatusActive, dao.UploadSessionStatusAborted).Return(nil)
+			})
+
+			It("removes the uploaded object and returns no error", func() {
+				Expect(resp).To(Equal(&pb.AbortUploadSessionResponse{}))
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+	})
+})
+
+func expectPresignedGetObject(storage *storagemock.MockStorage) {
+	storage.EXPECT().PresignedGetObject(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(_ context.Context, objectName string) (*storagekit.PresignedURL, error) {
+			return &storagekit.PresignedURL{URL: presignedURL(objectName)}, nil
+		},
+	)
+}
+
+func presignedURL(objectName string) string {
+	return "https://play.min.io/videos/" + objectName + "?X-Amz-Signature=signature"
+}
+
+func presignedVideoInfo(video *dao.Video) *pb.VideoInfo {
+	info := video.ToProto()
+	info.Url = presignedURL(video.ObjectName)
+	if video.ManifestObjectName != "" {
+		info.ManifestUrl = "/v1/videos/" + video.ID.Hex() + "/hls/master.m3u8"
+	}
+	if video.Thumbnail != nil {
+		info.ThumbnailUrl = presignedURL(video.Thumbnail.PosterObjectName)
+		info.PreviewUrl = "/v1/videos/" + video.ID.Hex() + "/preview.vtt"
+	}
+	info.Variants = make(map[string]string, len(video.Variants))
+	for variant, objectName := range video.Variants {
+		info.Variants[variant] = presignedURL(objectName)
+	}
+
+	return info
+}
+
+func readFixture() []byte {
+	file, err := os.ReadFile("./fixtures/big_buck_bunny_240p_1mb.mp4")
+	Expect(err).NotTo(HaveOccurred())
+
+	return file
+}
+
+// expectStoredObject serves the object from the data for probing the uploaded video
+func expectStoredObject(storage *storagemock.MockStorage, objectName interface{}, data []byte) {
+	storage.EXPECT().StatObject(gomock.Any(), objectName).AnyTimes().Return(&storagekit.ObjectInfo{
+		Size: int64(len(data)),
+	}, nil)
+	storage.EXPECT().GetObject(gomock.Any(), objectName, gomock.Any()).AnyTimes().DoAndReturn(
+		func(_ context.Context, _ string, opts storagekit.GetObjectOptions) (io.ReadCloser, error) {
+			end := int64(len(data))
+			if opts.Length > 0 && opts.Offset+opts.Length < end {
+				end = opts.Offset + opts.Length
+			}
+
+			return io.NopCloser(bytes.NewReader(data[opts.Offset:end])), nil
+		},
+	)
+}
+
+// newOwnedFakeVideo returns a fake video owned by the user of the tests modifying videos
+func newOwnedFakeVideo() *dao.Video {
+	video := dao.NewFakeVideo()
+	video.OwnerID = "owner"
+
+	return video
+}
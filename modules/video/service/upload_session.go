@@ -0,0 +1,362 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/dao"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/pb"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/grpckit"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/storagekit"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// minUploadPartSize is the minimum size of a part except the last one,
+// which is the limitation of the S3 multipart upload.
+const minUploadPartSize = 5 << 20
+
+func (s *service) CreateUploadSession(ctx context.Context, req *pb.CreateUploadSessionRequest) (*pb.CreateUploadSessionResponse, error) {
+	if req.GetSize() == 0 {
+		return nil, ErrInvalidUploadSize
+	}
+
+	title := req.GetTitle()
+	if strings.TrimSpace(title) == "" {
+		title = defaultTitle(req.GetFilename())
+	}
+
+	// the metadata is validated before the upload as UploadVideo does
+	metadata, err := newVideoMetadata(title, req.GetDescription(), req.GetTags(), req.GetLanguage(), req.GetVisibility(), []string{
+		dao.VideoFieldTitle, dao.VideoFieldDescription, dao.VideoFieldTags, dao.VideoFieldLanguage, dao.VideoFieldVisibility,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ownerID := grpckit.UserIDFromContext(ctx)
+
+	// nobody could view a private video without an owner
+	if metadata.Visibility == dao.VideoVisibilityPrivate && ownerID == "" {
+		return nil, ErrInvalidVisibility
+	}
+
+	videoID := primitive.NewObjectID()
+	objectName := videoID.Hex() + "-" + req.GetFilename()
+
+	session := &dao.UploadSession{
+		VideoID:       videoID,
+		Filename:      req.GetFilename(),
+		ObjectName:    objectName,
+		Size:          req.GetSize(),
+		Status:        dao.UploadSessionStatusActive,
+		Presigned:     req.GetPresigned(),
+		OwnerID:       ownerID,
+		VideoMetadata: *metadata,
+	}
+
+	if publishAt := req.GetPublishAt(); publishAt != nil {
+		session.PublishAt = publishAt.AsTime().UTC().Truncate(time.Millisecond)
+	}
+
+	resp := &pb.CreateUploadSessionResponse{}
+
+	if req.GetPresigned() {
+		presignedURL, err := s.storage.PresignedPutObject(ctx, objectName)
+		if err != nil {
+			if errors.Is(err, storagekit.ErrPresignNotSupported) {
+				return nil, ErrPresignNotSupported
+			}
+
+			return nil, err
+		}
+
+		resp.UploadUrl = presignedURL.URL
+		resp.UploadUrlExpiresAt = timestamppb.New(presignedURL.ExpiresAt)
+	} else {
+		uploadID, err := s.storage.NewMultipartUpload(ctx, objectName, storagekit.PutObjectOptions{
+			ContentType: "application/octet-stream",
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		session.UploadID = uploadID
+	}
+
+	if err := s.uploadSessionDAO.Create(ctx, session); err != nil {
+		return nil, err
+	}
+
+	resp.Session = session.ToProto()
+
+	return resp, nil
+}
+
+func (s *service) GetUploadSession(ctx context.Context, req *pb.GetUploadSessionRequest) (*pb.GetUploadSessionResponse, error) {
+	session, err := s.getUploadSession(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetUploadSessionResponse{Session: session.ToProto()}, nil
+}
+
+// UploadPart uploads the chunks between the header offset and offset + size as the next part of the session,
+// the offset must be equal to the received offset of the session, so a client can resume the upload by
+// querying the offset with `GetUploadSession` and continue from there.
+func (s *service) UploadPart(stream pb.Video_UploadPartServer) error {
+	ctx := stream.Context()
+
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	header := req.GetHeader()
+
+	session, err := s.getUploadSession(ctx, header.GetSessionId())
+	if err != nil {
+		return err
+	}
+
+	if session.Status != dao.UploadSessionStatusActive {
+		return ErrUploadSessionNotActive
+	}
+
+	if session.Presigned {
+		return ErrUploadSessionPresigned
+	}
+
+	if header.GetOffset() != session.Offset {
+		return ErrUploadOffsetMismatch
+	}
+
+	end := header.GetOffset() + header.GetSize()
+	if header.GetSize() == 0 || end > session.Size || (end < session.Size && header.GetSize() < minUploadPartSize) {
+		return ErrInvalidUploadSize
+	}
+
+	recv := func() ([]byte, error) {
+		req, err := stream.Recv()
+		return req.GetChunkData(), err
+	}
+
+	// a concurrent upload at the same offset gets another part number, so it cannot overwrite this part in the storage
+	partNumber, err := s.uploadSessionDAO.ReservePartNumber(ctx, session.ID, session.Offset)
+	if err != nil {
+		if errors.Is(err, dao.ErrUploadSessionConflict) {
+			return ErrUploadOffsetMismatch
+		}
+
+		return err
+	}
+
+	var part *storagekit.ObjectPart
+	if err := pipeChunks(recv, func(reader io.Reader) error {
+		var err error
+		part, err = s.storage.PutObjectPart(ctx, session.ObjectName, session.UploadID, partNumber, reader, int64(header.GetSize()))
+		return err
+	}); err != nil {
+		return err
+	}
+
+	// the part is only committed if no other request has moved the offset in the meantime
+	if err := s.uploadSessionDAO.AppendPart(ctx, session.ID, session.Offset, &dao.UploadPart{
+		Number: part.PartNumber,
+		ETag:   part.ETag,
+		Size:   header.GetSize(),
+	}); err != nil {
+		if errors.Is(err, dao.ErrUploadSessionConflict) {
+			return ErrUploadOffsetMismatch
+		}
+
+		return err
+	}
+
+	session, err = s.getUploadSession(ctx, header.GetSessionId())
+	if err != nil {
+		return err
+	}
+
+	if err := stream.SendAndClose(&pb.UploadPartResponse{
+		Session: session.ToProto(),
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *service) CompleteUploadSession(ctx context.Context, req *pb.CompleteUploadSessionRequest) (*pb.CompleteUploadSessionResponse, error) {
+	session, err := s.getUploadSession(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	if session.Status != dao.UploadSessionStatusActive {
+		return nil, ErrUploadSessionNotActive
+	}
+
+	if session.Presigned {
+		if err := s.checkUploadedObject(ctx, session); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := s.completeMultipartUpload(ctx, session); err != nil {
+			return nil, err
+		}
+	}
+
+	video, err := s.probeVideo(ctx, session.VideoID, session.ObjectName)
+	if err != nil {
+		if errors.Is(err, ErrInvalidVideo) {
+			s.rejectUploadSession(ctx, session)
+		}
+
+		return nil, err
+	}
+
+	// the session is completed before the video is created so concurrent requests do not create the video twice
+	if err := s.uploadSessionDAO.UpdateStatus(ctx, session.ID, dao.UploadSessionStatusActive, dao.UploadSessionStatusCompleted); err != nil {
+		if errors.Is(err, dao.ErrUploadSessionConflict) {
+			return nil, ErrUploadSessionNotActive
+		}
+
+		return nil, err
+	}
+
+	video.OwnerID = session.OwnerID
+	video.VideoMetadata = session.VideoMetadata
+	schedulePublish(video, timestamppb.New(session.PublishAt), time.Now())
+
+	if err := s.videoDAO.Create(ctx, video); err != nil {
+		// reopen the session so the client can complete it again, the session is left completed
+		// if it cannot be reopened, which the client can tell by getting the session
+		_ = s.uploadSessionDAO.UpdateStatus(ctx, session.ID, dao.UploadSessionStatusCompleted, dao.UploadSessionStatusActive)
+
+		return nil, err
+	}
+
+	if err := s.produceVideoCreatedEvent(&pb.HandleVideoCreatedRequest{
+		Id:         video.ID.Hex(),
+		ObjectName: video.ObjectName,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &pb.CompleteUploadSessionResponse{VideoId: session.VideoID.Hex()}, nil
+}
+
+func (s *service) AbortUploadSession(ctx context.Context, req *pb.AbortUploadSessionRequest) (*pb.AbortUploadSessionResponse, error) {
+	session, err := s.getUploadSession(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	if session.Status != dao.UploadSessionStatusActive {
+		return nil, ErrUploadSessionNotActive
+	}
+
+	if session.Presigned {
+		// the client may have uploaded the object already
+		if err := s.storage.RemoveObject(ctx, session.ObjectName); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := s.storage.AbortMultipartUpload(ctx, session.ObjectName, session.UploadID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.uploadSessionDAO.UpdateStatus(ctx, session.ID, dao.UploadSessionStatusActive, dao.UploadSessionStatusAborted); err != nil {
+		if errors.Is(err, dao.ErrUploadSessionConflict) {
+			return nil, ErrUploadSessionNotActive
+		}
+
+		return nil, err
+	}
+
+	return &pb.AbortUploadSessionResponse{}, nil
+}
+
+func (s *service) completeMultipartUpload(ctx context.Context, session *dao.UploadSession) error {
+	if session.Offset != session.Size {
+		return ErrUploadIncomplete
+	}
+
+	parts := make([]*storagekit.ObjectPart, 0, len(session.Parts))
+	for _, part := range session.Parts {
+		parts = append(parts, &storagekit.ObjectPart{
+			PartNumber: part.Number,
+			ETag:       part.ETag,
+			Size:       int64(part.Size),
+		})
+	}
+
+	if err := s.storage.CompleteMultipartUpload(ctx, session.ObjectName, session.UploadID, parts); err != nil {
+		// the upload has been completed by a previous request of the reopened session
+		if errors.Is(err, storagekit.ErrUploadNotFound) {
+			return s.checkUploadedObject(ctx, session)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// checkUploadedObject checks the object uploaded by the client with the presigned URL
+// or by a completed multipart upload
+func (s *service) checkUploadedObject(ctx context.Context, session *dao.UploadSession) error {
+	info, err := s.storage.StatObject(ctx, session.ObjectName)
+	if err != nil {
+		if errors.Is(err, storagekit.ErrObjectNotFound) {
+			return ErrUploadIncomplete
+		}
+
+		return err
+	}
+
+	if uint64(info.Size) != session.Size {
+		return ErrVideoSizeMismatch
+	}
+
+	return nil
+}
+
+func (s *service) getUploadSession(ctx context.Context, hexID string) (*dao.UploadSession, error) {
+	id, err := primitive.ObjectIDFromHex(hexID)
+	if err != nil {
+		return nil, ErrInvalidObjectID
+	}
+
+	session, err := s.uploadSessionDAO.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, dao.ErrUploadSessionNotFound) {
+			return nil, ErrUploadSessionNotFound
+		}
+
+		return nil, err
+	}
+
+	// a session of another user is not found, so its ID cannot be probed
+	if session.OwnerID != grpckit.UserIDFromContext(ctx) {
+		return nil, ErrUploadSessionNotFound
+	}
+
+	return session, nil
+}
+
+// rejectUploadSession aborts the session whose upload is not a valid video and removes the uploaded object,
+// the errors are ignored since the client is told the video is invalid anyway
+func (s *service) rejectUploadSession(ctx context.Context, session *dao.UploadSession) {
+	if err := s.uploadSessionDAO.UpdateStatus(ctx, session.ID, dao.UploadSessionStatusActive, dao.UploadSessionStatusAborted); err != nil {
+		return
+	}
+
+	_ = s.storage.RemoveObject(ctx, session.ObjectName)
+}
@@ -0,0 +1,95 @@
+package stream
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/dao"
+)
+
+const (
+	hlsPlaylistName       = "index.m3u8"
+	hlsMasterPlaylistName = "master.m3u8"
+	hlsContentType        = "application/vnd.apple.mpegurl"
+)
+
+// hlsPrefix returns the prefix of the HLS objects of the source video,
+// e.g. the playlists of `id-video.mov` are under `id-video-hls/`.
+func hlsPrefix(sourceObjectName string) string {
+	return strings.TrimSuffix(sourceObjectName, path.Ext(sourceObjectName)) + "-hls"
+}
+
+// hlsVariantPrefix returns the prefix of the media playlist and the segments of the variant
+func hlsVariantPrefix(sourceObjectName string, variant string) string {
+	return hlsPrefix(sourceObjectName) + "/" + variant
+}
+
+// hlsMasterObjectName returns the object name of the master playlist of the source video
+func hlsMasterObjectName(sourceObjectName string) string {
+	return hlsPrefix(sourceObjectName) + "/" + hlsMasterPlaylistName
+}
+
+// masterPlaylist renders the master playlist referencing the media playlists from the highest bandwidth,
+// the media playlists are referenced relative to the master playlist so the playlists can be moved together.
+func masterPlaylist(masterObjectName string, playlists map[string]*dao.Playlist) []byte {
+	variants := make([]string, 0, len(playlists))
+	for variant := range playlists {
+		variants = append(variants, variant)
+	}
+
+	sort.Slice(variants, func(i, j int) bool {
+		a, b := playlists[variants[i]], playlists[variants[j]]
+		if a.Bandwidth != b.Bandwidth {
+			return a.Bandwidth > b.Bandwidth
+		}
+		return variants[i] < variants[j]
+	})
+
+	var buf bytes.Buffer
+
+	buf.WriteString("#EXTM3U\n")
+	// fragmented MP4 segments require version 7
+	buf.WriteString("#EXT-X-VERSION:7\n")
+	buf.WriteString("#EXT-X-INDEPENDENT-SEGMENTS\n")
+
+	dir := path.Dir(masterObjectName) + "/"
+
+	for _, variant := range variants {
+		playlist := playlists[variant]
+
+		fmt.Fprintf(&buf, "#EXT-X-STREAM-INF:BANDWIDTH=%d", playlist.Bandwidth)
+		if playlist.Width > 0 && playlist.Height > 0 {
+			fmt.Fprintf(&buf, ",RESOLUTION=%dx%d", playlist.Width, playlist.Height)
+		}
+		buf.WriteString("\n")
+
+		buf.WriteString(strings.TrimPrefix(playlist.ObjectName, dir))
+		buf.WriteString("\n")
+	}
+
+	return buf.Bytes()
+}
+
+// renditionBandwidth returns the average bitrate of the rendition in bits per second,
+// the bitrate of the profile is used if the duration of the rendition is unknown
+func renditionBandwidth(rendition *Rendition, profile *dao.Profile) uint64 {
+	if rendition.Duration > 0 {
+		return uint64(float64(rendition.Size*8) / rendition.Duration)
+	}
+
+	return uint64(profile.Bitrate) * 1000
+}
+
+func hlsObjectContentType(objectName string) string {
+	switch path.Ext(objectName) {
+	case ".m3u8":
+		return hlsContentType
+	case ".m4s":
+		return "video/iso.segment"
+	default:
+		return "video/mp4"
+	}
+}
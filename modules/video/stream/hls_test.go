@@ -0,0 +1,53 @@
+package stream
+
+import (
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/dao"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HLS", func() {
+	Describe("hlsVariantPrefix", func() {
+		It("puts the variants under the prefix of the source", func() {
+			Expect(hlsVariantPrefix("id-video.mov", "720p")).To(Equal("id-video-hls/720p"))
+			Expect(hlsMasterObjectName("id-video.mov")).To(Equal("id-video-hls/master.m3u8"))
+		})
+	})
+
+	Describe("masterPlaylist", func() {
+		When("no playlist", func() {
+			It("returns the header only", func() {
+				Expect(string(masterPlaylist("id-video-hls/master.m3u8", nil))).To(Equal("#EXTM3U\n" +
+					"#EXT-X-VERSION:7\n" +
+					"#EXT-X-INDEPENDENT-SEGMENTS\n",
+				))
+			})
+		})
+
+		When("success", func() {
+			It("references the playlists relatively from the highest bandwidth", func() {
+				Expect(string(masterPlaylist("id-video-hls/master.m3u8", map[string]*dao.Playlist{
+					"480p":  {ObjectName: "id-video-hls/480p/index.m3u8", Bandwidth: 1920000},
+					"1080p": {ObjectName: "id-video-hls/1080p/index.m3u8", Width: 1920, Height: 1080, Bandwidth: 4320000},
+				}))).To(Equal("#EXTM3U\n" +
+					"#EXT-X-VERSION:7\n" +
+					"#EXT-X-INDEPENDENT-SEGMENTS\n" +
+					"#EXT-X-STREAM-INF:BANDWIDTH=4320000,RESOLUTION=1920x1080\n" +
+					"1080p/index.m3u8\n" +
+					"#EXT-X-STREAM-INF:BANDWIDTH=1920000\n" +
+					"480p/index.m3u8\n",
+				))
+			})
+		})
+	})
+
+	Describe("renditionBandwidth", func() {
+		It("returns the average bitrate of the rendition", func() {
+			Expect(renditionBandwidth(&Rendition{Size: 1000000, Duration: 10}, dao.NewFakeProfile("720p", 720))).To(Equal(uint64(800000)))
+		})
+
+		It("falls back to the bitrate of the profile", func() {
+			Expect(renditionBandwidth(&Rendition{Size: 1000000}, dao.NewFakeProfile("720p", 720))).To(Equal(uint64(2880000)))
+		})
+	})
+})
@@ -0,0 +1,180 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/dao"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/logkit"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+const (
+	ProfileSourceFlag  = "flag"
+	ProfileSourceMongo = "mongo"
+)
+
+type ProfileConfig struct {
+	Source   string   `long:"source" env:"SOURCE" description:"where the transcoding profiles are read from, the mongo source reads the profiles collection" choice:"flag" choice:"mongo" default:"flag"`
+	Profiles []string `long:"profile" env:"PROFILES" env-delim:";" description:"the transcoding profile in the form of id=720p,height=720,bitrate=2800,codec=h264,container=mp4,skip_if_source_smaller=true" default:"id=1080p,height=1080,bitrate=5000,skip_if_source_smaller=true" default:"id=720p,height=720,bitrate=2800,skip_if_source_smaller=true" default:"id=480p,height=480,bitrate=1400,skip_if_source_smaller=true" default:"id=320p,height=320,bitrate=700"`
+}
+
+// staticProfileDAO serves the profiles parsed from the flags
+type staticProfileDAO struct {
+	profiles []*dao.Profile
+}
+
+var _ dao.ProfileDAO = (*staticProfileDAO)(nil)
+
+func NewStaticProfileDAO(profiles []*dao.Profile) *staticProfileDAO {
+	sorted := append([]*dao.Profile(nil), profiles...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Height > sorted[j].Height })
+
+	return &staticProfileDAO{
+		profiles: sorted,
+	}
+}
+
+func (d *staticProfileDAO) List(ctx context.Context) ([]*dao.Profile, error) {
+	return d.profiles, nil
+}
+
+func (d *staticProfileDAO) Get(ctx context.Context, id string) (*dao.Profile, error) {
+	for _, profile := range d.profiles {
+		if profile.ID == id {
+			return profile, nil
+		}
+	}
+
+	return nil, dao.ErrProfileNotFound
+}
+
+// parseProfile parses the profile from the comma separated key-value pairs,
+// the codec and the container are H.264 and MP4 if they are omitted
+func parseProfile(s string) (*dao.Profile, error) {
+	profile := &dao.Profile{
+		Codec:     dao.ProfileCodecH264,
+		Container: dao.ProfileContainerMP4,
+	}
+
+	for _, pair := range strings.Split(s, ",") {
+		kv := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid profile field %q", pair)
+		}
+
+		key, value := kv[0], kv[1]
+
+		switch key {
+		case "id":
+			profile.ID = value
+		case "height":
+			height, err := strconv.ParseUint(value, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid profile height %q: %w", value, err)
+			}
+			profile.Height = uint32(height)
+		case "bitrate":
+			bitrate, err := strconv.ParseUint(strings.TrimSuffix(value, "k"), 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid profile bitrate %q: %w", value, err)
+			}
+			profile.Bitrate = uint32(bitrate)
+		case "codec":
+			profile.Codec = value
+		case "container":
+			profile.Container = value
+		case "skip_if_source_smaller":
+			skip, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid profile skip_if_source_smaller %q: %w", value, err)
+			}
+			profile.SkipIfSourceSmaller = skip
+		default:
+			return nil, fmt.Errorf("unknown profile field %q", key)
+		}
+	}
+
+	if err := profile.Validate(); err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+// selectProfiles returns the profiles to transcode a source of the height, the profiles that would upscale
+// the source are skipped if they ask to, but the lowest profile is kept so every video has a variant
+func selectProfiles(profiles []*dao.Profile, sourceHeight uint32) []*dao.Profile {
+	selected := make([]*dao.Profile, 0, len(profiles))
+
+	var lowest *dao.Profile
+	for _, profile := range profiles {
+		if lowest == nil || profile.Height < lowest.Height {
+			lowest = profile
+		}
+
+		if profile.SkipIfSourceSmaller && sourceHeight > 0 && sourceHeight < profile.Height {
+			continue
+		}
+
+		selected = append(selected, profile)
+	}
+
+	if len(selected) == 0 && lowest != nil {
+		selected = append(selected, lowest)
+	}
+
+	return selected
+}
+
+// NewProfileDAO creates the profile DAO of the configured source
+func NewProfileDAO(ctx context.Context, conf *ProfileConfig, collection *mongo.Collection) dao.ProfileDAO {
+	logger := logkit.FromContext(ctx).With(zap.String("source", conf.Source))
+
+	if conf.Source == ProfileSourceMongo {
+		profileDAO := dao.NewMongoProfileDAO(collection)
+
+		// the profiles are read again for every video, they are validated on start so a misconfiguration fails fast
+		profiles, err := profileDAO.List(ctx)
+		if err != nil {
+			logger.Fatal("failed to read transcoding profiles from mongo", zap.Error(err))
+		}
+
+		if len(profiles) == 0 {
+			logger.Fatal("no transcoding profile is configured")
+		}
+
+		logger.Info("read transcoding profiles from mongo", zap.Int("count", len(profiles)))
+
+		return profileDAO
+	}
+
+	profiles := make([]*dao.Profile, 0, len(conf.Profiles))
+	ids := make(map[string]struct{}, len(conf.Profiles))
+
+	for _, s := range conf.Profiles {
+		profile, err := parseProfile(s)
+		if err != nil {
+			logger.Fatal("invalid transcoding profile", zap.String("profile", s), zap.Error(err))
+		}
+
+		if _, ok := ids[profile.ID]; ok {
+			logger.Fatal("duplicate transcoding profile", zap.String("id", profile.ID))
+		}
+		ids[profile.ID] = struct{}{}
+
+		profiles = append(profiles, profile)
+	}
+
+	if len(profiles) == 0 {
+		logger.Fatal("no transcoding profile is configured")
+	}
+
+	logger.Info("read transcoding profiles from flags", zap.Int("count", len(profiles)))
+
+	return NewStaticProfileDAO(profiles)
+}
@@ -0,0 +1,109 @@
+package stream
+
+import (
+	"context"
+
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/dao"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Profile", func() {
+	Describe("parseProfile", func() {
+		When("the codec and the container are omitted", func() {
+			It("defaults to H.264 in MP4", func() {
+				Expect(parseProfile("id=720p,height=720,bitrate=2800k,skip_if_source_smaller=true")).To(Equal(&dao.Profile{
+					ID:                  "720p",
+					Height:              720,
+					Bitrate:             2800,
+					Codec:               dao.ProfileCodecH264,
+					Container:           dao.ProfileContainerMP4,
+					SkipIfSourceSmaller: true,
+				}))
+			})
+		})
+
+		When("all fields are present", func() {
+			It("returns the profile", func() {
+				Expect(parseProfile("id=480p-vp9, height=480, bitrate=1000, codec=vp9, container=webm")).To(Equal(&dao.Profile{
+					ID:        "480p-vp9",
+					Height:    480,
+					Bitrate:   1000,
+					Codec:     dao.ProfileCodecVP9,
+					Container: dao.ProfileContainerWebM,
+				}))
+			})
+		})
+
+		DescribeTable("invalid profiles",
+			func(s string) {
+				_, err := parseProfile(s)
+				Expect(err).To(HaveOccurred())
+			},
+			Entry("missing id", "height=720"),
+			Entry("missing height", "id=720p"),
+			Entry("invalid height", "id=720p,height=high"),
+			Entry("unknown field", "id=720p,height=720,fps=30"),
+			Entry("malformed field", "id=720p,height"),
+			Entry("unknown codec", "id=720p,height=720,codec=av1"),
+			Entry("codec not in container", "id=720p,height=720,codec=h264,container=webm"),
+		)
+	})
+
+	Describe("selectProfiles", func() {
+		var profiles []*dao.Profile
+
+		BeforeEach(func() {
+			profiles = []*dao.Profile{
+				dao.NewFakeProfile("1080p", 1080),
+				dao.NewFakeProfile("720p", 720),
+				dao.NewFakeProfile("480p", 480),
+			}
+		})
+
+		It("skips the profiles larger than the source", func() {
+			Expect(selectProfiles(profiles, 720)).To(Equal(profiles[1:]))
+		})
+
+		It("keeps the profiles not asking to skip", func() {
+			profiles[0].SkipIfSourceSmaller = false
+
+			Expect(selectProfiles(profiles, 720)).To(Equal(profiles))
+		})
+
+		It("keeps the lowest profile if the source is smaller than all profiles", func() {
+			Expect(selectProfiles(profiles, 240)).To(Equal(profiles[2:]))
+		})
+
+		It("keeps all profiles if the source height is unknown", func() {
+			Expect(selectProfiles(profiles, 0)).To(Equal(profiles))
+		})
+	})
+
+	Describe("staticProfileDAO", func() {
+		var profileDAO *staticProfileDAO
+
+		BeforeEach(func() {
+			profileDAO = NewStaticProfileDAO([]*dao.Profile{
+				dao.NewFakeProfile("480p", 480),
+				dao.NewFakeProfile("1080p", 1080),
+			})
+		})
+
+		It("lists the profiles from the highest", func() {
+			profiles, err := profileDAO.List(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(profiles).To(Equal([]*dao.Profile{
+				dao.NewFakeProfile("1080p", 1080),
+				dao.NewFakeProfile("480p", 480),
+			}))
+		})
+
+		It("gets the profile by ID", func() {
+			Expect(profileDAO.Get(context.Background(), "480p")).To(Equal(dao.NewFakeProfile("480p", 480)))
+
+			_, err := profileDAO.Get(context.Background(), "720p")
+			Expect(err).To(MatchError(dao.ErrProfileNotFound))
+		})
+	})
+})
@@ -1,76 +1,380 @@
 package stream
 
 import (
+	"bytes"
 	"context"
-	"strconv"
-	"time"
+	"errors"
+	"fmt"
 
 	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/dao"
 	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/pb"
 	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/kafkakit"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/storagekit"
 	"github.com/justin0u0/protoc-gen-grpc-sarama/pkg/saramakit"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
+type RetryConfig struct {
+	MaxAttempts int32 `long:"max_attempts" env:"MAX_ATTEMPTS" description:"the maximum number of attempts to transcode a variant before the video is marked as failed" default:"3"`
+}
+
 type stream struct {
 	pb.UnimplementedVideoStreamServer
 
-	videoDAO dao.VideoDAO
-	producer kafkakit.Producer
+	videoDAO    dao.VideoDAO
+	profileDAO  dao.ProfileDAO
+	storage     storagekit.Storage
+	transcoder  Transcoder
+	producer    kafkakit.Producer
+	maxAttempts int32
 }
 
-func NewStream(videoDAO dao.VideoDAO, producer kafkakit.Producer) *stream {
+func NewStream(videoDAO dao.VideoDAO, profileDAO dao.ProfileDAO, storage storagekit.Storage, transcoder Transcoder, producer kafkakit.Producer, retryConf *RetryConfig) *stream {
 	return &stream{
-		videoDAO: videoDAO,
-		producer: producer,
+		videoDAO:    videoDAO,
+		profileDAO:  profileDAO,
+		storage:     storage,
+		transcoder:  transcoder,
+		producer:    producer,
+		maxAttempts: retryConf.MaxAttempts,
 	}
 }
 
+var errNoProfile = errors.New("no transcoding profile is configured")
+
+// HandleVideoCreated fans out a message for each profile and a message for the thumbnails of the video
 func (s *stream) HandleVideoCreated(ctx context.Context, req *pb.HandleVideoCreatedRequest) (*emptypb.Empty, error) {
 	id, err := primitive.ObjectIDFromHex(req.GetId())
 	if err != nil {
-		return nil, &saramakit.HandlerError{Retry: false, Err: err}
+		return nil, saramakit.HandlerError{Retry: false, Err: err}
 	}
 
-	if req.GetScale() != 0 {
-		variant := strconv.Itoa(int(req.GetScale()))
+	if req.GetThumbnail() {
+		return s.handleVideoThumbnail(ctx, id, req)
+	}
 
-		if err := s.handleVideoWithVariant(ctx, id, variant, req.GetUrl()); err != nil {
-			return nil, &saramakit.HandlerError{Retry: true, Err: err}
+	if req.GetProfileId() != "" {
+		return s.handleVideoVariant(ctx, id, req)
+	}
+
+	profiles, err := s.profileDAO.List(ctx)
+	if err != nil {
+		if errors.Is(err, dao.ErrInvalidProfile) {
+			return s.failVideo(ctx, id, dao.VideoStatusUploaded, err)
 		}
 
-		return &emptypb.Empty{}, nil
+		return nil, saramakit.HandlerError{Retry: true, Err: err}
+	}
+
+	// the deprecated scale is only set by the variant messages produced before the profiles
+	if scale := req.GetScale(); scale != 0 {
+		return s.handleLegacyVideoVariant(ctx, id, req, profiles, scale)
+	}
+
+	height, err := s.sourceHeight(ctx, id, req.GetObjectName())
+	if err != nil {
+		if errors.Is(err, dao.ErrVideoNotFound) {
+			return nil, saramakit.HandlerError{Retry: false, Err: err}
+		}
+
+		if errors.Is(err, ErrInvalidSource) {
+			return s.failVideo(ctx, id, dao.VideoStatusUploaded, err)
+		}
+
+		return nil, saramakit.HandlerError{Retry: true, Err: err}
+	}
+
+	profiles = selectProfiles(profiles, height)
+	if len(profiles) == 0 {
+		return s.failVideo(ctx, id, dao.VideoStatusUploaded, errNoProfile)
+	}
+
+	variants := make([]string, 0, len(profiles))
+	for _, profile := range profiles {
+		variants = append(variants, profile.ID)
+	}
+
+	if err := s.videoDAO.StartEncoding(ctx, id, variants); err != nil {
+		if errors.Is(err, dao.ErrVideoNotFound) || errors.Is(err, dao.ErrVideoStatusConflict) {
+			return nil, saramakit.HandlerError{Retry: false, Err: err}
+		}
+
+		return nil, saramakit.HandlerError{Retry: true, Err: err}
 	}
 
 	// fanout create events to each variant
-	variants := []int32{1080, 720, 480, 320}
-	for _, scale := range variants {
-		if err := s.produceVideoCreatedWithScaleEvent(&pb.HandleVideoCreatedRequest{
-			Id:    req.GetId(),
-			Url:   req.GetUrl(),
-			Scale: scale,
+	for _, profile := range profiles {
+		if err := s.produceVideoVariantEvent(&pb.HandleVideoCreatedRequest{
+			Id:         req.GetId(),
+			ObjectName: req.GetObjectName(),
+			ProfileId:  profile.ID,
 		}); err != nil {
-			return nil, &saramakit.HandlerError{Retry: true, Err: err}
+			return nil, saramakit.HandlerError{Retry: true, Err: err}
+		}
+	}
+
+	if err := s.produceVideoVariantEvent(&pb.HandleVideoCreatedRequest{
+		Id:         req.GetId(),
+		ObjectName: req.GetObjectName(),
+		Thumbnail:  true,
+	}); err != nil {
+		return nil, saramakit.HandlerError{Retry: true, Err: err}
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// sourceHeight returns the height stored at the upload, the source is only probed for a video stored without it
+func (s *stream) sourceHeight(ctx context.Context, id primitive.ObjectID, objectName string) (uint32, error) {
+	video, err := s.videoDAO.GetIncludingDeleted(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	if video.Height != 0 {
+		return video.Height, nil
+	}
+
+	source, err := s.transcoder.Probe(ctx, objectName)
+	if err != nil {
+		return 0, err
+	}
+
+	return source.Height, nil
+}
+
+// handleLegacyVideoVariant transcodes the variant of a message produced before the profiles without fanning out again
+func (s *stream) handleLegacyVideoVariant(ctx context.Context, id primitive.ObjectID, req *pb.HandleVideoCreatedRequest, profiles []*dao.Profile, scale int32) (*emptypb.Empty, error) {
+	for _, profile := range profiles {
+		if profile.Height == uint32(scale) {
+			variant := proto.Clone(req).(*pb.HandleVideoCreatedRequest)
+			variant.ProfileId = profile.ID
+			variant.Scale = 0
+
+			return s.handleVideoVariant(ctx, id, variant)
+		}
+	}
+
+	return s.failVideo(ctx, id, dao.VideoStatusEncoding, fmt.Errorf("variant of scale %d: %w", scale, dao.ErrProfileNotFound))
+}
+
+// handleVideoVariant transcodes a variant of the video, which fails the video once the attempts run out
+func (s *stream) handleVideoVariant(ctx context.Context, id primitive.ObjectID, req *pb.HandleVideoCreatedRequest) (*emptypb.Empty, error) {
+	variant := req.GetProfileId()
+
+	err := s.handleVideoWithVariant(ctx, id, variant, req.GetObjectName())
+	if err == nil {
+		return &emptypb.Empty{}, nil
+	}
+
+	if errors.Is(err, dao.ErrVideoNotFound) || errors.Is(err, dao.ErrVideoStatusConflict) {
+		return nil, saramakit.HandlerError{Retry: false, Err: err}
+	}
+
+	if errors.Is(err, dao.ErrProfileNotFound) || errors.Is(err, dao.ErrInvalidProfile) {
+		return s.failVideo(ctx, id, dao.VideoStatusEncoding, fmt.Errorf("variant %s: %w", variant, err))
+	}
+
+	if retried, produceErr := s.retryVideoEvent(req); produceErr != nil {
+		return nil, saramakit.HandlerError{Retry: true, Err: produceErr}
+	} else if retried {
+		return nil, saramakit.HandlerError{Retry: false, Err: fmt.Errorf("variant %s attempt %d failed and is retried: %w", variant, req.GetAttempt()+1, err)}
+	}
+
+	return s.failVideo(ctx, id, dao.VideoStatusEncoding, fmt.Errorf("variant %s failed after %d attempts: %w", variant, s.maxAttempts, err))
+}
+
+// handleVideoThumbnail generates the thumbnails of the video, which never fails the video
+func (s *stream) handleVideoThumbnail(ctx context.Context, id primitive.ObjectID, req *pb.HandleVideoCreatedRequest) (*emptypb.Empty, error) {
+	err := s.handleVideoWithThumbnail(ctx, id, req.GetObjectName())
+	if err == nil {
+		return &emptypb.Empty{}, nil
+	}
+
+	if errors.Is(err, dao.ErrVideoNotFound) || errors.Is(err, ErrInvalidSource) {
+		return nil, saramakit.HandlerError{Retry: false, Err: err}
+	}
+
+	if retried, produceErr := s.retryVideoEvent(req); produceErr != nil {
+		return nil, saramakit.HandlerError{Retry: true, Err: produceErr}
+	} else if retried {
+		return nil, saramakit.HandlerError{Retry: false, Err: fmt.Errorf("thumbnail attempt %d failed and is retried: %w", req.GetAttempt()+1, err)}
+	}
+
+	return nil, saramakit.HandlerError{Retry: false, Err: fmt.Errorf("thumbnail failed after %d attempts: %w", s.maxAttempts, err)}
+}
+
+// retryVideoEvent produces the message again with the attempt increased, false is returned if the attempts run out
+func (s *stream) retryVideoEvent(req *pb.HandleVideoCreatedRequest) (bool, error) {
+	attempt := req.GetAttempt() + 1
+	if attempt >= s.maxAttempts {
+		return false, nil
+	}
+
+	retry := proto.Clone(req).(*pb.HandleVideoCreatedRequest)
+	retry.Attempt = attempt
+
+	if err := s.produceVideoVariantEvent(retry); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// failVideo marks the video as failed if it is still in the `from` status, and returns the cause as an unretryable error
+func (s *stream) failVideo(ctx context.Context, id primitive.ObjectID, from dao.VideoStatus, cause error) (*emptypb.Empty, error) {
+	if err := s.videoDAO.UpdateStatus(ctx, id, from, dao.VideoStatusFailed); err != nil &&
+		!errors.Is(err, dao.ErrVideoNotFound) && !errors.Is(err, dao.ErrVideoStatusConflict) {
+		return nil, saramakit.HandlerError{Retry: true, Err: err}
+	}
+
+	return nil, saramakit.HandlerError{Retry: false, Err: cause}
+}
+
+// HandleVideoDeleted removes the stored objects of a deleted video until the storage removes all of them
+func (s *stream) HandleVideoDeleted(ctx context.Context, req *pb.HandleVideoDeletedRequest) (*emptypb.Empty, error) {
+	objectNames := req.GetObjectNames()
+
+	for _, prefix := range req.GetObjectPrefixes() {
+		objects, err := s.storage.ListObjects(ctx, storagekit.ListObjectsOptions{
+			Prefix:    prefix,
+			Recursive: true,
+		})
+		if err != nil {
+			return nil, saramakit.HandlerError{Retry: true, Err: err}
 		}
+
+		for _, object := range objects {
+			objectNames = append(objectNames, object.Name)
+		}
+	}
+
+	if len(objectNames) == 0 {
+		return &emptypb.Empty{}, nil
+	}
+
+	if err := s.storage.RemoveObjects(ctx, objectNames); err != nil {
+		return nil, saramakit.HandlerError{Retry: true, Err: err}
 	}
 
 	return &emptypb.Empty{}, nil
 }
 
-func (s *stream) handleVideoWithVariant(ctx context.Context, id primitive.ObjectID, variant string, url string) error {
-	// we mock the video transcoding only
-	time.Sleep(3 * time.Second)
+func (s *stream) handleVideoWithVariant(ctx context.Context, id primitive.ObjectID, variant string, sourceObjectName string) error {
+	video, err := s.videoDAO.GetIncludingDeleted(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if video.Status == dao.VideoStatusFailed {
+		return dao.ErrVideoStatusConflict
+	}
+
+	// a duplicate message only writes the master playlist again
+	if _, ok := video.Playlists[variant]; ok {
+		return s.writeManifest(ctx, video)
+	}
 
-	if err := s.videoDAO.UpdateVariant(ctx, id, variant, url); err != nil {
+	profile, err := s.profileDAO.Get(ctx, variant)
+	if err != nil {
 		return err
 	}
 
-	return nil
+	rendition, err := s.transcoder.Transcode(ctx, &TranscodeRequest{
+		SourceObjectName: sourceObjectName,
+		ObjectName:       variantObjectName(sourceObjectName, profile.ID, profile.Container),
+		Profile:          profile,
+	})
+	if err != nil {
+		return err
+	}
+
+	playlistObjectName, err := s.transcoder.Package(ctx, &PackageRequest{
+		ObjectName: rendition.ObjectName,
+		Prefix:     hlsVariantPrefix(sourceObjectName, variant),
+	})
+	if err != nil {
+		return err
+	}
+
+	video, err = s.videoDAO.UpdateVariant(ctx, id, variant, rendition.ObjectName, &dao.Playlist{
+		ObjectName: playlistObjectName,
+		Width:      rendition.Width,
+		Height:     rendition.Height,
+		Bandwidth:  renditionBandwidth(rendition, profile),
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.writeManifest(ctx, video)
+}
+
+// writeManifest writes the master playlist again until it covers the playlists of the latest video
+func (s *stream) writeManifest(ctx context.Context, video *dao.Video) error {
+	masterObjectName := hlsMasterObjectName(video.ObjectName)
+
+	for {
+		data := masterPlaylist(masterObjectName, video.Playlists)
+
+		if err := s.storage.PutObject(ctx, masterObjectName, bytes.NewReader(data), int64(len(data)), storagekit.PutObjectOptions{
+			ContentType: hlsContentType,
+		}); err != nil {
+			return err
+		}
+
+		latest, err := s.videoDAO.GetIncludingDeleted(ctx, video.ID)
+		if err != nil {
+			return err
+		}
+
+		if len(latest.Playlists) <= len(video.Playlists) {
+			break
+		}
+
+		video = latest
+	}
+
+	if video.ManifestObjectName == masterObjectName {
+		return nil
+	}
+
+	return s.videoDAO.UpdateManifest(ctx, video.ID, masterObjectName)
+}
+
+// handleVideoWithThumbnail extracts the poster frame, the sprite sheet and its WebVTT index beside the source video
+func (s *stream) handleVideoWithThumbnail(ctx context.Context, id primitive.ObjectID, sourceObjectName string) error {
+	source, err := s.transcoder.Probe(ctx, sourceObjectName)
+	if err != nil {
+		return err
+	}
+
+	thumbnail := thumbnailObjects(sourceObjectName)
+	layout := newSpriteLayout(source)
+
+	if err := s.transcoder.Thumbnail(ctx, &ThumbnailRequest{
+		SourceObjectName: sourceObjectName,
+		PosterObjectName: thumbnail.PosterObjectName,
+		PosterTime:       posterTime(source.Duration),
+		SpriteObjectName: thumbnail.SpriteObjectName,
+		Sprite:           layout,
+	}); err != nil {
+		return err
+	}
+
+	data := previewVTT(thumbnail.PreviewObjectName, thumbnail.SpriteObjectName, layout, source.Duration)
+	if err := s.storage.PutObject(ctx, thumbnail.PreviewObjectName, bytes.NewReader(data), int64(len(data)), storagekit.PutObjectOptions{
+		ContentType: previewContentType,
+	}); err != nil {
+		return err
+	}
+
+	return s.videoDAO.UpdateThumbnail(ctx, id, thumbnail)
 }
 
-func (s *stream) produceVideoCreatedWithScaleEvent(req *pb.HandleVideoCreatedRequest) error {
+func (s *stream) produceVideoVariantEvent(req *pb.HandleVideoCreatedRequest) error {
 	valueBytes, err := proto.Marshal(req)
 	if err != nil {
 		return err
@@ -3,18 +3,26 @@ package stream
 import (
 	"context"
 	"errors"
-	"strconv"
+	"fmt"
+	"io"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/dao"
 	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/mock/daomock"
 	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/pb"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/kafkakit"
 	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/kafkakit/mock/kafkamock"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/logkit"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/storagekit"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/storagekit/mock/storagemock"
 	"github.com/golang/mock/gomock"
 	"github.com/justin0u0/protoc-gen-grpc-sarama/pkg/saramakit"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -25,6 +33,8 @@ func TestStream(t *testing.T) {
 
 var (
 	errSendMessagesUnknown = errors.New("unknown send messages error")
+	errStorageUnknown      = errors.New("unknown storage error")
+	errDAOUnknown          = errors.New("unknown dao error")
 )
 
 var _ = Describe("Stream", func() {
@@ -32,6 +42,9 @@ var _ = Describe("Stream", func() {
 		ctx        context.Context
 		controller *gomock.Controller
 		videoDAO   *daomock.MockVideoDAO
+		profileDAO *daomock.MockProfileDAO
+		storage    *storagemock.MockStorage
+		memStorage *storagekit.MemoryStorage
 		producer   *kafkamock.MockProducer
 		stream     *stream
 	)
@@ -40,8 +53,11 @@ var _ = Describe("Stream", func() {
 		ctx = context.Background()
 		controller = gomock.NewController(GinkgoT())
 		videoDAO = daomock.NewMockVideoDAO(controller)
+		profileDAO = daomock.NewMockProfileDAO(controller)
+		storage = storagemock.NewMockStorage(controller)
 		producer = kafkamock.NewMockProducer(controller)
-		stream = NewStream(videoDAO, producer)
+		memStorage = storagekit.NewMemoryStorage(logkit.WithContext(ctx, logkit.NewNopLogger()), &storagekit.MemoryConfig{Bucket: "videos"})
+		stream = NewStream(videoDAO, profileDAO, storage, NewFakeTranscoder(memStorage), producer, &RetryConfig{MaxAttempts: 3})
 	})
 
 	AfterEach(func() {
@@ -50,76 +66,811 @@ var _ = Describe("Stream", func() {
 
 	Describe("HandleVideoCreated", func() {
 		var (
-			id    primitive.ObjectID
-			url   string
-			resp  *emptypb.Empty
-			err   error
-			scale int32
+			id         primitive.ObjectID
+			objectName string
+			resp       *emptypb.Empty
+			err        error
+			profileID  string
+			scale      int32
+			attempt    int32
 		)
 
 		BeforeEach(func() {
 			id = primitive.NewObjectID()
-			url = "https://www.test.com"
+			objectName = id.Hex() + "-video.mp4"
+			scale = 0
+			attempt = 0
 		})
 
 		JustBeforeEach(func() {
 			resp, err = stream.HandleVideoCreated(ctx, &pb.HandleVideoCreatedRequest{
-				Id:    id.Hex(),
-				Url:   url,
-				Scale: scale,
+				Id:         id.Hex(),
+				ProfileId:  profileID,
+				ObjectName: objectName,
+				Scale:      scale,
+				Attempt:    attempt,
 			})
 		})
 
-		Context("scale is not presenting", func() {
-			BeforeEach(func() { scale = 0 })
+		Context("profile is not presenting", func() {
+			var (
+				profiles []*dao.Profile
+				variants []string
+			)
+
+			BeforeEach(func() {
+				profileID = ""
+				profiles = []*dao.Profile{
+					dao.NewFakeProfile("2160p", 2160),
+					dao.NewFakeProfile("1080p", 1080),
+					dao.NewFakeProfile("720p", 720),
+				}
+				variants = []string{"1080p", "720p"}
+			})
+
+			When("list profiles error", func() {
+				BeforeEach(func() {
+					profileDAO.EXPECT().List(ctx).Return(nil, errDAOUnknown)
+				})
+
+				It("returns retryable error", func() {
+					Expect(resp).To(BeNil())
+					Expect(err).To(Equal(saramakit.HandlerError{Retry: true, Err: errDAOUnknown}))
+				})
+			})
+
+			When("a profile is invalid", func() {
+				var invalidErr error
+
+				BeforeEach(func() {
+					invalidErr = fmt.Errorf("%w: profile 720p: height is required", dao.ErrInvalidProfile)
+					profileDAO.EXPECT().List(ctx).Return(nil, invalidErr)
+					videoDAO.EXPECT().UpdateStatus(ctx, id, dao.VideoStatusUploaded, dao.VideoStatusFailed).Return(nil)
+				})
+
+				It("marks the video as failed and returns unretryable error", func() {
+					Expect(resp).To(BeNil())
+					expectHandlerError(err, false, dao.ErrInvalidProfile)
+				})
+			})
+
+			When("message is a variant produced before the profiles", func() {
+				var produced *pb.HandleVideoCreatedRequest
+
+				BeforeEach(func() {
+					scale = 720
+					produced = nil
+
+					profileDAO.EXPECT().List(ctx).Return(profiles, nil)
+					videoDAO.EXPECT().GetIncludingDeleted(ctx, id).Return(newEncodingVideo(id, objectName, nil), nil)
+					profileDAO.EXPECT().Get(ctx, "720p").Return(profiles[2], nil)
+					producer.EXPECT().SendMessages(gomock.Any()).DoAndReturn(func(msgs []*kafkakit.ProducerMessage) error {
+						produced = unmarshalVideoCreated(msgs)
+						return nil
+					})
+				})
+
+				It("transcodes the variant of the profile of the height without fanning out again", func() {
+					Expect(resp).To(BeNil())
+					expectHandlerError(err, false, storagekit.ErrObjectNotFound)
+
+					// the source is not found so the variant is retried by the profile
+					Expect(produced.GetProfileId()).To(Equal("720p"))
+					Expect(produced.GetScale()).To(BeZero())
+					Expect(produced.GetAttempt()).To(Equal(int32(1)))
+				})
+			})
+
+			When("message is a variant produced before the profiles without a profile of the height", func() {
+				BeforeEach(func() {
+					scale = 360
+					profileDAO.EXPECT().List(ctx).Return(profiles, nil)
+					videoDAO.EXPECT().UpdateStatus(ctx, id, dao.VideoStatusEncoding, dao.VideoStatusFailed).Return(nil)
+				})
+
+				It("marks the video as failed and returns unretryable error", func() {
+					Expect(resp).To(BeNil())
+					expectHandlerError(err, false, dao.ErrProfileNotFound)
+				})
+			})
+
+			When("video not found", func() {
+				BeforeEach(func() {
+					profileDAO.EXPECT().List(ctx).Return(profiles, nil)
+					videoDAO.EXPECT().GetIncludingDeleted(ctx, id).Return(nil, dao.ErrVideoNotFound)
+				})
+
+				It("returns unretryable error", func() {
+					Expect(resp).To(BeNil())
+					Expect(err).To(Equal(saramakit.HandlerError{Retry: false, Err: dao.ErrVideoNotFound}))
+				})
+			})
+
+			When("height is stored at the upload", func() {
+				BeforeEach(func() {
+					// the source is not put, so probing it would fail
+					profileDAO.EXPECT().List(ctx).Return(profiles, nil)
+					videoDAO.EXPECT().GetIncludingDeleted(ctx, id).Return(newUploadedVideo(id, objectName, 720), nil)
+					videoDAO.EXPECT().StartEncoding(ctx, id, []string{"720p"}).Return(nil)
+					producer.EXPECT().SendMessages(gomock.Any()).Times(2).Return(nil)
+				})
+
+				It("fans out the profiles by the stored height without probing the source", func() {
+					Expect(resp).To(Equal(&emptypb.Empty{}))
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+
+			When("source not found", func() {
+				BeforeEach(func() {
+					profileDAO.EXPECT().List(ctx).Return(profiles, nil)
+					videoDAO.EXPECT().GetIncludingDeleted(ctx, id).Return(newUploadedVideo(id, objectName, 0), nil)
+				})
+
+				It("returns retryable error", func() {
+					Expect(resp).To(BeNil())
+					Expect(err).To(Equal(saramakit.HandlerError{Retry: true, Err: storagekit.ErrObjectNotFound}))
+				})
+			})
+
+			When("source is not a video", func() {
+				BeforeEach(func() {
+					Expect(memStorage.PutObject(ctx, objectName, strings.NewReader(""), 0, storagekit.PutObjectOptions{})).To(Succeed())
+					profileDAO.EXPECT().List(ctx).Return(profiles, nil)
+					videoDAO.EXPECT().GetIncludingDeleted(ctx, id).Return(newUploadedVideo(id, objectName, 0), nil)
+					videoDAO.EXPECT().UpdateStatus(ctx, id, dao.VideoStatusUploaded, dao.VideoStatusFailed).Return(nil)
+				})
+
+				It("marks the video as failed and returns unretryable error", func() {
+					Expect(resp).To(BeNil())
+					Expect(err).To(Equal(saramakit.HandlerError{Retry: false, Err: ErrInvalidSource}))
+				})
+			})
+
+			When("video is already transcoded", func() {
+				BeforeEach(func() {
+					putSourceObject(ctx, memStorage, objectName)
+					profileDAO.EXPECT().List(ctx).Return(profiles, nil)
+					videoDAO.EXPECT().GetIncludingDeleted(ctx, id).Return(newUploadedVideo(id, objectName, 0), nil)
+					videoDAO.EXPECT().StartEncoding(ctx, id, variants).Return(dao.ErrVideoStatusConflict)
+				})
+
+				It("returns unretryable error", func() {
+					Expect(resp).To(BeNil())
+					Expect(err).To(Equal(saramakit.HandlerError{Retry: false, Err: dao.ErrVideoStatusConflict}))
+				})
+			})
+
+			When("start encoding error", func() {
+				BeforeEach(func() {
+					putSourceObject(ctx, memStorage, objectName)
+					profileDAO.EXPECT().List(ctx).Return(profiles, nil)
+					videoDAO.EXPECT().GetIncludingDeleted(ctx, id).Return(newUploadedVideo(id, objectName, 0), nil)
+					videoDAO.EXPECT().StartEncoding(ctx, id, variants).Return(errDAOUnknown)
+				})
+
+				It("returns retryable error", func() {
+					Expect(resp).To(BeNil())
+					Expect(err).To(Equal(saramakit.HandlerError{Retry: true, Err: errDAOUnknown}))
+				})
+			})
 
 			When("producer send messages error", func() {
 				BeforeEach(func() {
+					putSourceObject(ctx, memStorage, objectName)
+					profileDAO.EXPECT().List(ctx).Return(profiles, nil)
+					videoDAO.EXPECT().GetIncludingDeleted(ctx, id).Return(newUploadedVideo(id, objectName, 0), nil)
+					videoDAO.EXPECT().StartEncoding(ctx, id, variants).Return(nil)
 					producer.EXPECT().SendMessages(gomock.Any()).Return(errSendMessagesUnknown)
 				})
 
 				It("returns the error", func() {
 					Expect(resp).To(BeNil())
-					Expect(err).To(Equal(&saramakit.HandlerError{Retry: true, Err: errSendMessagesUnknown}))
+					Expect(err).To(Equal(saramakit.HandlerError{Retry: true, Err: errSendMessagesUnknown}))
 				})
 			})
 
 			When("success", func() {
+				var produced []*pb.HandleVideoCreatedRequest
+
 				BeforeEach(func() {
-					producer.EXPECT().SendMessages(gomock.Any()).Times(4).Return(nil)
+					produced = nil
+
+					putSourceObject(ctx, memStorage, objectName)
+					profileDAO.EXPECT().List(ctx).Return(profiles, nil)
+					videoDAO.EXPECT().GetIncludingDeleted(ctx, id).Return(newUploadedVideo(id, objectName, 0), nil)
+					videoDAO.EXPECT().StartEncoding(ctx, id, variants).Return(nil)
+					producer.EXPECT().SendMessages(gomock.Any()).Times(3).DoAndReturn(func(msgs []*kafkakit.ProducerMessage) error {
+						produced = append(produced, unmarshalVideoCreated(msgs))
+						return nil
+					})
 				})
 
 				It("returns with no error", func() {
 					Expect(resp).To(Equal(&emptypb.Empty{}))
 					Expect(err).NotTo(HaveOccurred())
 				})
+
+				It("fans out the profiles not larger than the source", func() {
+					Expect(produced).To(HaveLen(3))
+					Expect(produced[0].GetProfileId()).To(Equal(variants[0]))
+					Expect(produced[1].GetProfileId()).To(Equal(variants[1]))
+				})
+
+				It("produces the thumbnail event", func() {
+					Expect(produced).To(HaveLen(3))
+					Expect(produced[2].GetThumbnail()).To(BeTrue())
+					Expect(produced[2].GetProfileId()).To(BeEmpty())
+					Expect(produced[2].GetObjectName()).To(Equal(objectName))
+				})
 			})
 		})
 
-		Context("scale is presenting", func() {
-			BeforeEach(func() { scale = 720 })
+		Context("profile is presenting", func() {
+			var (
+				profile  *dao.Profile
+				playlist *dao.Playlist
+			)
+
+			BeforeEach(func() {
+				profile = dao.NewFakeProfile("720p", 720)
+				profileID = profile.ID
+				playlist = &dao.Playlist{
+					ObjectName: id.Hex() + "-video-hls/720p/index.m3u8",
+					Width:      1280,
+					Height:     720,
+					Bandwidth:  uint64(profile.Bitrate) * 1000,
+				}
+			})
+
+			When("video not found before transcoding", func() {
+				BeforeEach(func() {
+					videoDAO.EXPECT().GetIncludingDeleted(ctx, id).Return(nil, dao.ErrVideoNotFound)
+				})
+
+				It("returns unretryable error", func() {
+					Expect(resp).To(BeNil())
+					Expect(err).To(Equal(saramakit.HandlerError{Retry: false, Err: dao.ErrVideoNotFound}))
+				})
+			})
+
+			When("video has failed", func() {
+				BeforeEach(func() {
+					video := newEncodingVideo(id, objectName, nil)
+					video.Status = dao.VideoStatusFailed
+					videoDAO.EXPECT().GetIncludingDeleted(ctx, id).Return(video, nil)
+				})
+
+				It("returns unretryable error without transcoding", func() {
+					Expect(resp).To(BeNil())
+					Expect(err).To(Equal(saramakit.HandlerError{Retry: false, Err: dao.ErrVideoStatusConflict}))
+				})
+			})
+
+			When("variant is finished by a previous delivery", func() {
+				BeforeEach(func() {
+					video := newEncodingVideo(id, objectName, map[string]*dao.Playlist{profileID: playlist})
+					video.ManifestObjectName = masterObjectName(id)
+
+					videoDAO.EXPECT().GetIncludingDeleted(ctx, id).Return(video, nil).Times(2)
+					storage.EXPECT().PutObject(ctx, masterObjectName(id), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				})
+
+				It("writes the master playlist without transcoding the variant again", func() {
+					Expect(resp).To(Equal(&emptypb.Empty{}))
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err := memStorage.StatObject(ctx, id.Hex()+"-video-720p.mp4")
+					Expect(err).To(MatchError(storagekit.ErrObjectNotFound))
+				})
+			})
+
+			When("source not found", func() {
+				var produced *pb.HandleVideoCreatedRequest
+
+				BeforeEach(func() {
+					produced = nil
+					videoDAO.EXPECT().GetIncludingDeleted(ctx, id).Return(newEncodingVideo(id, objectName, nil), nil)
+					profileDAO.EXPECT().Get(ctx, profileID).Return(profile, nil)
+					producer.EXPECT().SendMessages(gomock.Any()).DoAndReturn(func(msgs []*kafkakit.ProducerMessage) error {
+						produced = unmarshalVideoCreated(msgs)
+						return nil
+					})
+				})
+
+				It("returns unretryable error", func() {
+					Expect(resp).To(BeNil())
+					expectHandlerError(err, false, storagekit.ErrObjectNotFound)
+				})
+
+				It("produces the variant again with the attempt increased", func() {
+					Expect(produced.GetId()).To(Equal(id.Hex()))
+					Expect(produced.GetProfileId()).To(Equal(profileID))
+					Expect(produced.GetObjectName()).To(Equal(objectName))
+					Expect(produced.GetAttempt()).To(Equal(int32(1)))
+				})
+			})
+
+			When("profile is removed", func() {
+				BeforeEach(func() {
+					videoDAO.EXPECT().GetIncludingDeleted(ctx, id).Return(newEncodingVideo(id, objectName, nil), nil)
+					profileDAO.EXPECT().Get(ctx, profileID).Return(nil, dao.ErrProfileNotFound)
+					videoDAO.EXPECT().UpdateStatus(ctx, id, dao.VideoStatusEncoding, dao.VideoStatusFailed).Return(nil)
+				})
+
+				It("marks the video as failed without retrying the variant", func() {
+					Expect(resp).To(BeNil())
+					expectHandlerError(err, false, dao.ErrProfileNotFound)
+				})
+			})
+
+			When("source not found and producer send messages error", func() {
+				BeforeEach(func() {
+					videoDAO.EXPECT().GetIncludingDeleted(ctx, id).Return(newEncodingVideo(id, objectName, nil), nil)
+					profileDAO.EXPECT().Get(ctx, profileID).Return(profile, nil)
+					producer.EXPECT().SendMessages(gomock.Any()).Return(errSendMessagesUnknown)
+				})
+
+				It("returns retryable error", func() {
+					Expect(resp).To(BeNil())
+					Expect(err).To(Equal(saramakit.HandlerError{Retry: true, Err: errSendMessagesUnknown}))
+				})
+			})
+
+			When("source not found and attempts run out", func() {
+				BeforeEach(func() {
+					attempt = 2
+					videoDAO.EXPECT().GetIncludingDeleted(ctx, id).Return(newEncodingVideo(id, objectName, nil), nil)
+					profileDAO.EXPECT().Get(ctx, profileID).Return(profile, nil)
+				})
+
+				When("update status error", func() {
+					BeforeEach(func() {
+						videoDAO.EXPECT().UpdateStatus(ctx, id, dao.VideoStatusEncoding, dao.VideoStatusFailed).Return(errDAOUnknown)
+					})
+
+					It("returns retryable error", func() {
+						Expect(resp).To(BeNil())
+						Expect(err).To(Equal(saramakit.HandlerError{Retry: true, Err: errDAOUnknown}))
+					})
+				})
+
+				When("video is no longer encoding", func() {
+					BeforeEach(func() {
+						videoDAO.EXPECT().UpdateStatus(ctx, id, dao.VideoStatusEncoding, dao.VideoStatusFailed).Return(dao.ErrVideoStatusConflict)
+					})
+
+					It("returns unretryable error", func() {
+						Expect(resp).To(BeNil())
+						expectHandlerError(err, false, storagekit.ErrObjectNotFound)
+					})
+				})
+
+				When("success", func() {
+					BeforeEach(func() {
+						videoDAO.EXPECT().UpdateStatus(ctx, id, dao.VideoStatusEncoding, dao.VideoStatusFailed).Return(nil)
+					})
+
+					It("marks the video as failed and returns unretryable error", func() {
+						Expect(resp).To(BeNil())
+						expectHandlerError(err, false, storagekit.ErrObjectNotFound)
+					})
+				})
+			})
 
 			When("video not found", func() {
 				BeforeEach(func() {
-					videoDAO.EXPECT().UpdateVariant(ctx, id, strconv.Itoa(int(scale)), url).Return(dao.ErrVideoNotFound)
+					putSourceObject(ctx, memStorage, objectName)
+					videoDAO.EXPECT().GetIncludingDeleted(ctx, id).Return(newEncodingVideo(id, objectName, nil), nil)
+					profileDAO.EXPECT().Get(ctx, profileID).Return(profile, nil)
+					videoDAO.EXPECT().UpdateVariant(ctx, id, profileID, id.Hex()+"-video-720p.mp4", playlist).Return(nil, dao.ErrVideoNotFound)
 				})
 
-				It("returns with no error", func() {
+				It("returns unretryable error", func() {
+					Expect(resp).To(BeNil())
+					Expect(err).To(Equal(saramakit.HandlerError{Retry: false, Err: dao.ErrVideoNotFound}))
+				})
+			})
+
+			When("video is no longer encoding", func() {
+				BeforeEach(func() {
+					putSourceObject(ctx, memStorage, objectName)
+					videoDAO.EXPECT().GetIncludingDeleted(ctx, id).Return(newEncodingVideo(id, objectName, nil), nil)
+					profileDAO.EXPECT().Get(ctx, profileID).Return(profile, nil)
+					videoDAO.EXPECT().UpdateVariant(ctx, id, profileID, id.Hex()+"-video-720p.mp4", playlist).Return(nil, dao.ErrVideoStatusConflict)
+				})
+
+				It("returns unretryable error", func() {
 					Expect(resp).To(BeNil())
-					Expect(err).To(Equal(&saramakit.HandlerError{Retry: true, Err: dao.ErrVideoNotFound}))
+					Expect(err).To(Equal(saramakit.HandlerError{Retry: false, Err: dao.ErrVideoStatusConflict}))
+				})
+			})
+
+			When("write master playlist error", func() {
+				BeforeEach(func() {
+					putSourceObject(ctx, memStorage, objectName)
+					videoDAO.EXPECT().GetIncludingDeleted(ctx, id).Return(newEncodingVideo(id, objectName, nil), nil)
+					profileDAO.EXPECT().Get(ctx, profileID).Return(profile, nil)
+					videoDAO.EXPECT().UpdateVariant(ctx, id, profileID, id.Hex()+"-video-720p.mp4", playlist).
+						Return(newEncodingVideo(id, objectName, map[string]*dao.Playlist{profileID: playlist}), nil)
+					storage.EXPECT().PutObject(ctx, masterObjectName(id), gomock.Any(), gomock.Any(), gomock.Any()).Return(errStorageUnknown)
+					producer.EXPECT().SendMessages(gomock.Any()).Return(nil)
+				})
+
+				It("produces the variant again and returns unretryable error", func() {
+					Expect(resp).To(BeNil())
+					expectHandlerError(err, false, errStorageUnknown)
+				})
+			})
+
+			When("other variants finish concurrently", func() {
+				var (
+					other   *dao.Playlist
+					masters []string
+				)
+
+				BeforeEach(func() {
+					masters = nil
+					other = &dao.Playlist{ObjectName: id.Hex() + "-video-hls/1080p/index.m3u8", Width: 1920, Height: 1080, Bandwidth: 4320000}
+
+					putSourceObject(ctx, memStorage, objectName)
+					videoDAO.EXPECT().GetIncludingDeleted(ctx, id).Return(newEncodingVideo(id, objectName, nil), nil)
+					profileDAO.EXPECT().Get(ctx, profileID).Return(profile, nil)
+					videoDAO.EXPECT().UpdateVariant(ctx, id, profileID, id.Hex()+"-video-720p.mp4", playlist).
+						Return(newEncodingVideo(id, objectName, map[string]*dao.Playlist{profileID: playlist}), nil)
+					storage.EXPECT().PutObject(ctx, masterObjectName(id), gomock.Any(), gomock.Any(), gomock.Any()).Times(2).
+						DoAndReturn(func(_ context.Context, _ string, reader io.Reader, _ int64, _ storagekit.PutObjectOptions) error {
+							data, err := io.ReadAll(reader)
+							Expect(err).NotTo(HaveOccurred())
+							masters = append(masters, string(data))
+							return nil
+						})
+					gomock.InOrder(
+						videoDAO.EXPECT().GetIncludingDeleted(ctx, id).Return(newEncodingVideo(id, objectName, map[string]*dao.Playlist{
+							profileID: playlist,
+							"1080p":   other,
+						}), nil),
+						videoDAO.EXPECT().GetIncludingDeleted(ctx, id).Return(newEncodingVideo(id, objectName, map[string]*dao.Playlist{
+							profileID: playlist,
+							"1080p":   other,
+						}), nil),
+					)
+					videoDAO.EXPECT().UpdateManifest(ctx, id, masterObjectName(id)).Return(nil)
+				})
+
+				It("returns with no error", func() {
+					Expect(resp).To(Equal(&emptypb.Empty{}))
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("writes the master playlist again with all finished variants", func() {
+					Expect(masters).To(HaveLen(2))
+					Expect(masters[1]).To(Equal("#EXTM3U\n" +
+						"#EXT-X-VERSION:7\n" +
+						"#EXT-X-INDEPENDENT-SEGMENTS\n" +
+						"#EXT-X-STREAM-INF:BANDWIDTH=4320000,RESOLUTION=1920x1080\n" +
+						"1080p/index.m3u8\n" +
+						"#EXT-X-STREAM-INF:BANDWIDTH=2880000,RESOLUTION=1280x720\n" +
+						"720p/index.m3u8\n",
+					))
+				})
+			})
+
+			When("video is moved to the trash during encoding", func() {
+				BeforeEach(func() {
+					trashed := newEncodingVideo(id, objectName, nil)
+					trashed.DeletedAt = time.Now()
+					video := newEncodingVideo(id, objectName, map[string]*dao.Playlist{profileID: playlist})
+					video.DeletedAt = trashed.DeletedAt
+
+					putSourceObject(ctx, memStorage, objectName)
+					videoDAO.EXPECT().GetIncludingDeleted(ctx, id).Return(trashed, nil)
+					profileDAO.EXPECT().Get(ctx, profileID).Return(profile, nil)
+					videoDAO.EXPECT().UpdateVariant(ctx, id, profileID, id.Hex()+"-video-720p.mp4", playlist).Return(video, nil)
+					storage.EXPECT().PutObject(ctx, masterObjectName(id), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+					videoDAO.EXPECT().GetIncludingDeleted(ctx, id).Return(video, nil)
+					videoDAO.EXPECT().UpdateManifest(ctx, id, masterObjectName(id)).Return(nil)
+				})
+
+				It("finishes the variant so the video is encoded once it is restored", func() {
+					Expect(resp).To(Equal(&emptypb.Empty{}))
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err := memStorage.StatObject(ctx, id.Hex()+"-video-720p.mp4")
+					Expect(err).NotTo(HaveOccurred())
 				})
 			})
 
 			When("success", func() {
 				BeforeEach(func() {
-					videoDAO.EXPECT().UpdateVariant(ctx, id, strconv.Itoa(int(scale)), url).Return(nil)
+					video := newEncodingVideo(id, objectName, map[string]*dao.Playlist{profileID: playlist})
+
+					putSourceObject(ctx, memStorage, objectName)
+					videoDAO.EXPECT().GetIncludingDeleted(ctx, id).Return(newEncodingVideo(id, objectName, nil), nil)
+					profileDAO.EXPECT().Get(ctx, profileID).Return(profile, nil)
+					videoDAO.EXPECT().UpdateVariant(ctx, id, profileID, id.Hex()+"-video-720p.mp4", playlist).Return(video, nil)
+					storage.EXPECT().PutObject(ctx, masterObjectName(id), gomock.Any(), gomock.Any(), storagekit.PutObjectOptions{
+						ContentType: hlsContentType,
+					}).Return(nil)
+					videoDAO.EXPECT().GetIncludingDeleted(ctx, id).Return(video, nil)
+					videoDAO.EXPECT().UpdateManifest(ctx, id, masterObjectName(id)).Return(nil)
 				})
 
 				It("returns with no error", func() {
 					Expect(resp).To(Equal(&emptypb.Empty{}))
 					Expect(err).NotTo(HaveOccurred())
 				})
+
+				It("uploads the rendition under the variant object", func() {
+					info, err := memStorage.StatObject(ctx, id.Hex()+"-video-720p.mp4")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(info.Size).To(Equal(int64(len("fake-720p:source"))))
+				})
+
+				It("packages the rendition into the media playlist", func() {
+					info, err := memStorage.StatObject(ctx, playlist.ObjectName)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(info.ContentType).To(Equal(hlsContentType))
+				})
+			})
+		})
+	})
+
+	Describe("HandleVideoCreated with thumbnail", func() {
+		var (
+			id         primitive.ObjectID
+			objectName string
+			thumbnail  *dao.Thumbnail
+			attempt    int32
+			resp       *emptypb.Empty
+			err        error
+		)
+
+		BeforeEach(func() {
+			id = primitive.NewObjectID()
+			objectName = id.Hex() + "-video.mp4"
+			thumbnail = &dao.Thumbnail{
+				PosterObjectName:  id.Hex() + "-video-poster.jpg",
+				SpriteObjectName:  id.Hex() + "-video-sprite.jpg",
+				PreviewObjectName: id.Hex() + "-video-preview.vtt",
+			}
+			attempt = 0
+		})
+
+		JustBeforeEach(func() {
+			resp, err = stream.HandleVideoCreated(ctx, &pb.HandleVideoCreatedRequest{
+				Id:         id.Hex(),
+				ObjectName: objectName,
+				Attempt:    attempt,
+				Thumbnail:  true,
+			})
+		})
+
+		When("source is not a video", func() {
+			BeforeEach(func() {
+				Expect(memStorage.PutObject(ctx, objectName, strings.NewReader(""), 0, storagekit.PutObjectOptions{})).To(Succeed())
+			})
+
+			It("returns unretryable error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(Equal(saramakit.HandlerError{Retry: false, Err: ErrInvalidSource}))
+			})
+		})
+
+		When("write preview error", func() {
+			var produced *pb.HandleVideoCreatedRequest
+
+			BeforeEach(func() {
+				putSourceObject(ctx, memStorage, objectName)
+				storage.EXPECT().PutObject(ctx, thumbnail.PreviewObjectName, gomock.Any(), gomock.Any(), gomock.Any()).Return(errStorageUnknown)
+				producer.EXPECT().SendMessages(gomock.Any()).DoAndReturn(func(msgs []*kafkakit.ProducerMessage) error {
+					produced = unmarshalVideoCreated(msgs)
+					return nil
+				})
+			})
+
+			It("returns unretryable error", func() {
+				Expect(resp).To(BeNil())
+				expectHandlerError(err, false, errStorageUnknown)
+			})
+
+			It("produces the thumbnail again with the attempt increased", func() {
+				Expect(produced.GetThumbnail()).To(BeTrue())
+				Expect(produced.GetAttempt()).To(Equal(int32(1)))
+			})
+		})
+
+		When("attempts run out", func() {
+			BeforeEach(func() {
+				attempt = 2
+				putSourceObject(ctx, memStorage, objectName)
+				storage.EXPECT().PutObject(ctx, thumbnail.PreviewObjectName, gomock.Any(), gomock.Any(), gomock.Any()).Return(errStorageUnknown)
+			})
+
+			It("returns unretryable error without failing the video", func() {
+				Expect(resp).To(BeNil())
+				expectHandlerError(err, false, errStorageUnknown)
+			})
+		})
+
+		When("video not found", func() {
+			BeforeEach(func() {
+				putSourceObject(ctx, memStorage, objectName)
+				storage.EXPECT().PutObject(ctx, thumbnail.PreviewObjectName, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				videoDAO.EXPECT().UpdateThumbnail(ctx, id, thumbnail).Return(dao.ErrVideoNotFound)
+			})
+
+			It("returns unretryable error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(Equal(saramakit.HandlerError{Retry: false, Err: dao.ErrVideoNotFound}))
+			})
+		})
+
+		When("success", func() {
+			var preview string
+
+			BeforeEach(func() {
+				putSourceObject(ctx, memStorage, objectName)
+				storage.EXPECT().PutObject(ctx, thumbnail.PreviewObjectName, gomock.Any(), gomock.Any(), storagekit.PutObjectOptions{
+					ContentType: previewContentType,
+				}).DoAndReturn(func(_ context.Context, _ string, reader io.Reader, _ int64, _ storagekit.PutObjectOptions) error {
+					data, err := io.ReadAll(reader)
+					Expect(err).NotTo(HaveOccurred())
+					preview = string(data)
+					return nil
+				})
+				videoDAO.EXPECT().UpdateThumbnail(ctx, id, thumbnail).Return(nil)
+			})
+
+			It("returns with no error", func() {
+				Expect(resp).To(Equal(&emptypb.Empty{}))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("uploads the poster and the sprite sheet beside the video", func() {
+				info, err := memStorage.StatObject(ctx, thumbnail.PosterObjectName)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(info.ContentType).To(Equal(thumbnailContentType))
+
+				info, err = memStorage.StatObject(ctx, thumbnail.SpriteObjectName)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(info.ContentType).To(Equal(thumbnailContentType))
+			})
+
+			It("writes the preview referencing the sprite sheet", func() {
+				Expect(preview).To(ContainSubstring(id.Hex() + "-video-sprite.jpg#xywh=0,0,160,90"))
+			})
+		})
+	})
+
+	Describe("HandleVideoDeleted", func() {
+		var (
+			id             primitive.ObjectID
+			objectNames    []string
+			objectPrefixes []string
+			resp           *emptypb.Empty
+			err            error
+		)
+
+		BeforeEach(func() {
+			id = primitive.NewObjectID()
+			objectNames = []string{id.Hex() + "-video.mp4", id.Hex() + "-video-720.mp4"}
+			objectPrefixes = nil
+		})
+
+		JustBeforeEach(func() {
+			resp, err = stream.HandleVideoDeleted(ctx, &pb.HandleVideoDeletedRequest{
+				Id:             id.Hex(),
+				ObjectNames:    objectNames,
+				ObjectPrefixes: objectPrefixes,
+			})
+		})
+
+		When("no object to remove", func() {
+			BeforeEach(func() { objectNames = nil })
+
+			It("returns with no error", func() {
+				Expect(resp).To(Equal(&emptypb.Empty{}))
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		When("storage error", func() {
+			BeforeEach(func() {
+				storage.EXPECT().RemoveObjects(ctx, objectNames).Return(errStorageUnknown)
+			})
+
+			It("returns retryable error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(Equal(saramakit.HandlerError{Retry: true, Err: errStorageUnknown}))
+			})
+		})
+
+		When("list objects error", func() {
+			BeforeEach(func() {
+				objectPrefixes = []string{id.Hex() + "-video-hls/"}
+				storage.EXPECT().ListObjects(ctx, storagekit.ListObjectsOptions{Prefix: objectPrefixes[0], Recursive: true}).
+					Return(nil, errStorageUnknown)
+			})
+
+			It("returns retryable error", func() {
+				Expect(resp).To(BeNil())
+				Expect(err).To(Equal(saramakit.HandlerError{Retry: true, Err: errStorageUnknown}))
+			})
+		})
+
+		When("success", func() {
+			BeforeEach(func() {
+				storage.EXPECT().RemoveObjects(ctx, objectNames).Return(nil)
+			})
+
+			It("returns with no error", func() {
+				Expect(resp).To(Equal(&emptypb.Empty{}))
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		When("success with prefixes", func() {
+			BeforeEach(func() {
+				objectPrefixes = []string{id.Hex() + "-video-hls/"}
+				storage.EXPECT().ListObjects(ctx, storagekit.ListObjectsOptions{Prefix: objectPrefixes[0], Recursive: true}).
+					Return([]*storagekit.ObjectInfo{
+						{Name: id.Hex() + "-video-hls/720p/index.m3u8"},
+						{Name: id.Hex() + "-video-hls/master.m3u8"},
+					}, nil)
+				storage.EXPECT().RemoveObjects(ctx, append(objectNames,
+					id.Hex()+"-video-hls/720p/index.m3u8",
+					id.Hex()+"-video-hls/master.m3u8",
+				)).Return(nil)
+			})
+
+			It("removes the objects under the prefixes", func() {
+				Expect(resp).To(Equal(&emptypb.Empty{}))
+				Expect(err).NotTo(HaveOccurred())
 			})
 		})
 	})
 })
+
+func newUploadedVideo(id primitive.ObjectID, objectName string, height uint32) *dao.Video {
+	return &dao.Video{
+		ID:         id,
+		Status:     dao.VideoStatusUploaded,
+		ObjectName: objectName,
+		Height:     height,
+	}
+}
+
+func newEncodingVideo(id primitive.ObjectID, objectName string, playlists map[string]*dao.Playlist) *dao.Video {
+	return &dao.Video{
+		ID:         id,
+		Status:     dao.VideoStatusEncoding,
+		ObjectName: objectName,
+		Playlists:  playlists,
+	}
+}
+
+func masterObjectName(id primitive.ObjectID) string {
+	return id.Hex() + "-video-hls/master.m3u8"
+}
+
+func putSourceObject(ctx context.Context, storage storagekit.Storage, objectName string) {
+	Expect(storage.PutObject(ctx, objectName, strings.NewReader("source"), int64(len("source")), storagekit.PutObjectOptions{})).
+		To(Succeed())
+}
+
+func unmarshalVideoCreated(msgs []*kafkakit.ProducerMessage) *pb.HandleVideoCreatedRequest {
+	Expect(msgs).To(HaveLen(1))
+
+	var req pb.HandleVideoCreatedRequest
+	Expect(proto.Unmarshal(msgs[0].Value, &req)).To(Succeed())
+
+	return &req
+}
+
+func expectHandlerError(err error, retry bool, cause error) {
+	var handlerErr saramakit.HandlerError
+	Expect(errors.As(err, &handlerErr)).To(BeTrue())
+	Expect(handlerErr.Retry).To(Equal(retry))
+	Expect(handlerErr.Err).To(MatchError(cause))
+}
@@ -0,0 +1,123 @@
+package stream
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/dao"
+)
+
+const (
+	// spriteMaxTiles bounds the preview frames in the sprite sheet, longer videos take the frames at a longer interval
+	spriteMaxTiles   = 100
+	spriteColumns    = 10
+	spriteTileWidth  = 160
+	spriteTileHeight = 90
+
+	thumbnailContentType = "image/jpeg"
+	previewContentType   = "text/vtt"
+)
+
+// SpriteLayout is the grid of the preview frames in the sprite sheet, a frame is taken every interval
+// from the start of the video and placed from left to right, top to bottom.
+type SpriteLayout struct {
+	// Interval is the seconds between the preview frames
+	Interval   float64
+	Tiles      int
+	Columns    int
+	Rows       int
+	TileWidth  uint32
+	TileHeight uint32
+}
+
+// thumbnailObjects returns the objects of the poster, the sprite sheet and the preview
+// beside the source video, e.g. the poster of `id-video.mov` is `id-video-poster.jpg`.
+func thumbnailObjects(sourceObjectName string) *dao.Thumbnail {
+	base := strings.TrimSuffix(sourceObjectName, path.Ext(sourceObjectName))
+
+	return &dao.Thumbnail{
+		PosterObjectName:  base + "-poster.jpg",
+		SpriteObjectName:  base + "-sprite.jpg",
+		PreviewObjectName: base + "-preview.vtt",
+	}
+}
+
+// posterTime returns the position of the poster frame at a tenth of the video, which skips the opening that is often a black frame
+func posterTime(duration float64) float64 {
+	return duration / 10
+}
+
+// newSpriteLayout lays out the preview frames of the source, the tiles keep the aspect ratio
+// of the source with an even height, and a single tile is taken if the duration is unknown.
+func newSpriteLayout(source *Rendition) *SpriteLayout {
+	interval := math.Max(math.Ceil(source.Duration/spriteMaxTiles), 1)
+
+	tiles := int(math.Ceil(source.Duration / interval))
+	if tiles < 1 {
+		tiles = 1
+	}
+	if tiles > spriteMaxTiles {
+		tiles = spriteMaxTiles
+	}
+
+	columns := tiles
+	if columns > spriteColumns {
+		columns = spriteColumns
+	}
+
+	tileHeight := uint32(spriteTileHeight)
+	if source.Width > 0 && source.Height > 0 {
+		tileHeight = uint32(math.Round(float64(spriteTileWidth)*float64(source.Height)/float64(source.Width)/2)) * 2
+	}
+
+	return &SpriteLayout{
+		Interval:   interval,
+		Tiles:      tiles,
+		Columns:    columns,
+		Rows:       (tiles + columns - 1) / columns,
+		TileWidth:  spriteTileWidth,
+		TileHeight: tileHeight,
+	}
+}
+
+// previewVTT renders the WebVTT index of the sprite sheet, each cue references a tile by the media fragment,
+// and the sprite sheet is referenced relative to the preview so they can be moved together.
+func previewVTT(previewObjectName string, spriteObjectName string, layout *SpriteLayout, duration float64) []byte {
+	sprite := strings.TrimPrefix(spriteObjectName, path.Dir(previewObjectName)+"/")
+
+	var buf bytes.Buffer
+
+	buf.WriteString("WEBVTT\n")
+
+	for i := 0; i < layout.Tiles; i++ {
+		start := float64(i) * layout.Interval
+		end := start + layout.Interval
+		if duration > 0 && end > duration {
+			end = duration
+		}
+
+		x := uint32(i%layout.Columns) * layout.TileWidth
+		y := uint32(i/layout.Columns) * layout.TileHeight
+
+		fmt.Fprintf(&buf, "\n%s --> %s\n", vttTimestamp(start), vttTimestamp(end))
+		fmt.Fprintf(&buf, "%s#xywh=%d,%d,%d,%d\n", sprite, x, y, layout.TileWidth, layout.TileHeight)
+	}
+
+	return buf.Bytes()
+}
+
+// vttTimestamp formats the seconds as `hh:mm:ss.ttt`
+func vttTimestamp(seconds float64) string {
+	d := time.Duration(math.Round(seconds*1000)) * time.Millisecond
+
+	return fmt.Sprintf("%02d:%02d:%02d.%03d",
+		int(d/time.Hour),
+		int(d%time.Hour/time.Minute),
+		int(d%time.Minute/time.Second),
+		int(d%time.Second/time.Millisecond),
+	)
+}
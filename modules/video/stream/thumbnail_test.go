@@ -0,0 +1,78 @@
+package stream
+
+import (
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/dao"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Thumbnail", func() {
+	Describe("thumbnailObjects", func() {
+		It("puts the thumbnails beside the source", func() {
+			Expect(thumbnailObjects("id-video.mov")).To(Equal(&dao.Thumbnail{
+				PosterObjectName:  "id-video-poster.jpg",
+				SpriteObjectName:  "id-video-sprite.jpg",
+				PreviewObjectName: "id-video-preview.vtt",
+			}))
+		})
+	})
+
+	Describe("newSpriteLayout", func() {
+		When("duration is unknown", func() {
+			It("takes a single tile", func() {
+				Expect(newSpriteLayout(&Rendition{})).To(Equal(&SpriteLayout{
+					Interval:   1,
+					Tiles:      1,
+					Columns:    1,
+					Rows:       1,
+					TileWidth:  160,
+					TileHeight: 90,
+				}))
+			})
+		})
+
+		When("short video", func() {
+			It("takes a frame every second in the aspect ratio of the source", func() {
+				Expect(newSpriteLayout(&Rendition{Width: 320, Height: 240, Duration: 13.696})).To(Equal(&SpriteLayout{
+					Interval:   1,
+					Tiles:      14,
+					Columns:    10,
+					Rows:       2,
+					TileWidth:  160,
+					TileHeight: 120,
+				}))
+			})
+		})
+
+		When("long video", func() {
+			It("bounds the tiles with a longer interval", func() {
+				Expect(newSpriteLayout(&Rendition{Width: 1920, Height: 1080, Duration: 3600})).To(Equal(&SpriteLayout{
+					Interval:   36,
+					Tiles:      100,
+					Columns:    10,
+					Rows:       10,
+					TileWidth:  160,
+					TileHeight: 90,
+				}))
+			})
+		})
+	})
+
+	Describe("previewVTT", func() {
+		It("maps the time ranges to the tiles relative to the preview", func() {
+			layout := &SpriteLayout{Interval: 5, Tiles: 3, Columns: 2, Rows: 2, TileWidth: 160, TileHeight: 90}
+
+			Expect(string(previewVTT("videos/id-preview.vtt", "videos/id-sprite.jpg", layout, 12.5))).To(Equal("WEBVTT\n" +
+				"\n00:00:00.000 --> 00:00:05.000\nid-sprite.jpg#xywh=0,0,160,90\n" +
+				"\n00:00:05.000 --> 00:00:10.000\nid-sprite.jpg#xywh=160,0,160,90\n" +
+				"\n00:00:10.000 --> 00:00:12.500\nid-sprite.jpg#xywh=0,90,160,90\n",
+			))
+		})
+	})
+
+	Describe("vttTimestamp", func() {
+		It("formats the hours, the minutes, the seconds and the milliseconds", func() {
+			Expect(vttTimestamp(3725.5)).To(Equal("01:02:05.500"))
+		})
+	})
+})
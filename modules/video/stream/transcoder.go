@@ -0,0 +1,100 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"path"
+	"strings"
+
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/dao"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/storagekit"
+)
+
+const (
+	TranscoderFFmpeg = "ffmpeg"
+	TranscoderFake   = "fake"
+)
+
+type TranscoderConfig struct {
+	Type string `long:"type" env:"TYPE" description:"the transcoder implementation, the fake one is for local development" choice:"ffmpeg" choice:"fake" default:"ffmpeg"`
+}
+
+// TranscodeRequest describes a rendition to produce from the source video
+type TranscodeRequest struct {
+	// SourceObjectName is the object of the source video in the storage
+	SourceObjectName string
+	// ObjectName is the object the rendition is uploaded to
+	ObjectName string
+	// Profile describes the resolution and the encoding of the rendition
+	Profile *dao.Profile
+}
+
+// Rendition is the metadata of a transcoded and uploaded video
+type Rendition struct {
+	ObjectName string
+	Width      uint32
+	Height     uint32
+	Size       uint64
+	Duration   float64
+}
+
+// PackageRequest describes the HLS packaging of a rendition
+type PackageRequest struct {
+	// ObjectName is the object of the rendition in the storage
+	ObjectName string
+	// Prefix is the prefix of the media playlist and the segments in the storage
+	Prefix string
+}
+
+// ThumbnailRequest describes the poster frame and the preview sprite sheet to extract from the source video
+type ThumbnailRequest struct {
+	// SourceObjectName is the object of the source video in the storage
+	SourceObjectName string
+	// PosterObjectName is the object the poster frame is uploaded to
+	PosterObjectName string
+	// PosterTime is the position of the poster frame in seconds
+	PosterTime float64
+	// SpriteObjectName is the object the sprite sheet is uploaded to
+	SpriteObjectName string
+	// Sprite is the grid of the preview frames in the sprite sheet
+	Sprite *SpriteLayout
+}
+
+// Transcoder downloads the source video from the storage, produces the scaled rendition
+// and uploads it to the storage.
+type Transcoder interface {
+	// Probe reads the metadata of the video object, ErrInvalidSource is returned if it is not a video
+	Probe(ctx context.Context, objectName string) (*Rendition, error)
+	Transcode(ctx context.Context, req *TranscodeRequest) (*Rendition, error)
+	// Package segments the rendition into fragmented MP4 segments and returns the object of the media playlist
+	Package(ctx context.Context, req *PackageRequest) (string, error)
+	// Thumbnail extracts the poster frame and the preview sprite sheet as JPEG images
+	Thumbnail(ctx context.Context, req *ThumbnailRequest) error
+}
+
+var (
+	ErrInvalidSource = errors.New("invalid source video")
+)
+
+// variantObjectName returns the object name of the variant of the source video in the container,
+// e.g. the 720p variant of `id-video.mov` in MP4 is `id-video-720p.mp4`.
+func variantObjectName(sourceObjectName string, variant string, container string) string {
+	return strings.TrimSuffix(sourceObjectName, path.Ext(sourceObjectName)) + "-" + variant + "." + container
+}
+
+func containerContentType(container string) string {
+	if container == dao.ProfileContainerWebM {
+		return "video/webm"
+	}
+
+	return "video/mp4"
+}
+
+// NewTranscoder creates the transcoder of the configured implementation
+func NewTranscoder(storage storagekit.Storage, conf *TranscoderConfig, ffmpegConf *FFmpegConfig) Transcoder {
+	if conf.Type == TranscoderFake {
+		return NewFakeTranscoder(storage)
+	}
+
+	return NewFFmpegTranscoder(storage, ffmpegConf)
+}
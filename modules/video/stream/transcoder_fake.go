@@ -0,0 +1,133 @@
+package stream
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/storagekit"
+)
+
+// fakeTranscoder is a deterministic transcoder that is useful for testing and local development,
+// every non-empty source is probed as a 1080p video, the rendition is the source content with
+// a header of the height, and the width is scaled to 16:9. The thumbnails are placeholders
+// describing the poster time and the sprite grid.
+type fakeTranscoder struct {
+	storage storagekit.Storage
+}
+
+var _ Transcoder = (*fakeTranscoder)(nil)
+
+func NewFakeTranscoder(storage storagekit.Storage) *fakeTranscoder {
+	return &fakeTranscoder{
+		storage: storage,
+	}
+}
+
+func (t *fakeTranscoder) Probe(ctx context.Context, objectName string) (*Rendition, error) {
+	info, err := t.storage.StatObject(ctx, objectName)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.Size == 0 {
+		return nil, ErrInvalidSource
+	}
+
+	return &Rendition{
+		ObjectName: objectName,
+		Width:      1920,
+		Height:     1080,
+		Size:       uint64(info.Size),
+	}, nil
+}
+
+func (t *fakeTranscoder) Transcode(ctx context.Context, req *TranscodeRequest) (*Rendition, error) {
+	reader, err := t.storage.GetObject(ctx, req.SourceObjectName, storagekit.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	source, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	height := req.Profile.Height
+	data := append([]byte(fmt.Sprintf("fake-%dp:", height)), source...)
+
+	if err := t.storage.PutObject(ctx, req.ObjectName, bytes.NewReader(data), int64(len(data)), storagekit.PutObjectOptions{
+		ContentType: containerContentType(req.Profile.Container),
+	}); err != nil {
+		return nil, err
+	}
+
+	return &Rendition{
+		ObjectName: req.ObjectName,
+		Width:      (height*16/9 + 1) &^ 1,
+		Height:     height,
+		Size:       uint64(len(data)),
+	}, nil
+}
+
+// fakePlaylist is the media playlist of the fake package, which has the whole rendition as the only segment
+const fakePlaylist = `#EXTM3U
+#EXT-X-VERSION:7
+#EXT-X-TARGETDURATION:6
+#EXT-X-PLAYLIST-TYPE:VOD
+#EXTINF:6.000,
+segment_00000.m4s
+#EXT-X-ENDLIST
+`
+
+func (t *fakeTranscoder) Thumbnail(ctx context.Context, req *ThumbnailRequest) error {
+	if _, err := t.storage.StatObject(ctx, req.SourceObjectName); err != nil {
+		return err
+	}
+
+	images := map[string]string{
+		req.PosterObjectName: fmt.Sprintf("fake-poster:%.3f", req.PosterTime),
+		req.SpriteObjectName: fmt.Sprintf("fake-sprite:%dx%d", req.Sprite.Columns, req.Sprite.Rows),
+	}
+
+	for objectName, data := range images {
+		if err := t.storage.PutObject(ctx, objectName, strings.NewReader(data), int64(len(data)), storagekit.PutObjectOptions{
+			ContentType: thumbnailContentType,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t *fakeTranscoder) Package(ctx context.Context, req *PackageRequest) (string, error) {
+	reader, err := t.storage.GetObject(ctx, req.ObjectName, storagekit.GetObjectOptions{})
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+
+	segment, err := io.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+
+	if err := t.storage.PutObject(ctx, req.Prefix+"/segment_00000.m4s", bytes.NewReader(segment), int64(len(segment)), storagekit.PutObjectOptions{
+		ContentType: hlsObjectContentType("segment_00000.m4s"),
+	}); err != nil {
+		return "", err
+	}
+
+	playlistObjectName := req.Prefix + "/" + hlsPlaylistName
+	if err := t.storage.PutObject(ctx, playlistObjectName, strings.NewReader(fakePlaylist), int64(len(fakePlaylist)), storagekit.PutObjectOptions{
+		ContentType: hlsContentType,
+	}); err != nil {
+		return "", err
+	}
+
+	return playlistObjectName, nil
+}
@@ -0,0 +1,405 @@
+package stream
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/dao"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/storagekit"
+)
+
+type FFmpegConfig struct {
+	FFmpegPath  string `long:"path" env:"PATH" description:"the path of the ffmpeg binary" default:"ffmpeg"`
+	FFprobePath string `long:"probe_path" env:"PROBE_PATH" description:"the path of the ffprobe binary" default:"ffprobe"`
+	WorkDir     string `long:"work_dir" env:"WORK_DIR" description:"the directory of the temporary files, the system temporary directory is used if empty"`
+}
+
+// ffmpegTranscoder transcodes the videos by executing ffmpeg, the source and the
+// rendition are kept in a temporary directory which is removed after the upload.
+type ffmpegTranscoder struct {
+	storage     storagekit.Storage
+	ffmpegPath  string
+	ffprobePath string
+	workDir     string
+}
+
+var _ Transcoder = (*ffmpegTranscoder)(nil)
+
+func NewFFmpegTranscoder(storage storagekit.Storage, conf *FFmpegConfig) *ffmpegTranscoder {
+	return &ffmpegTranscoder{
+		storage:     storage,
+		ffmpegPath:  conf.FFmpegPath,
+		ffprobePath: conf.FFprobePath,
+		workDir:     conf.WorkDir,
+	}
+}
+
+func (t *ffmpegTranscoder) Probe(ctx context.Context, objectName string) (*Rendition, error) {
+	source, cleanup, err := t.probeSource(ctx, objectName)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	rendition, err := t.probe(ctx, source)
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) || errors.Is(err, errNoVideoStream) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidSource, err)
+		}
+
+		return nil, err
+	}
+
+	rendition.ObjectName = objectName
+
+	return rendition, nil
+}
+
+func (t *ffmpegTranscoder) Transcode(ctx context.Context, req *TranscodeRequest) (*Rendition, error) {
+	dir, err := os.MkdirTemp(t.workDir, "transcode-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	source := filepath.Join(dir, "source")
+	if err := t.download(ctx, req.SourceObjectName, source); err != nil {
+		return nil, err
+	}
+
+	output := filepath.Join(dir, "output."+req.Profile.Container)
+	if err := t.run(ctx, t.ffmpegPath, ffmpegArgs(source, output, req.Profile)...); err != nil {
+		return nil, err
+	}
+
+	rendition, err := t.probe(ctx, output)
+	if err != nil {
+		return nil, err
+	}
+
+	rendition.ObjectName = req.ObjectName
+
+	if err := t.upload(ctx, output, req.ObjectName, containerContentType(req.Profile.Container)); err != nil {
+		return nil, err
+	}
+
+	return rendition, nil
+}
+
+func (t *ffmpegTranscoder) Package(ctx context.Context, req *PackageRequest) (string, error) {
+	dir, err := os.MkdirTemp(t.workDir, "package-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(dir)
+
+	source := filepath.Join(dir, "source"+path.Ext(req.ObjectName))
+	if err := t.download(ctx, req.ObjectName, source); err != nil {
+		return "", err
+	}
+
+	output := filepath.Join(dir, "hls")
+	if err := os.Mkdir(output, 0o755); err != nil {
+		return "", err
+	}
+
+	if err := t.run(ctx, t.ffmpegPath, ffmpegHLSArgs(source, output)...); err != nil {
+		return "", err
+	}
+
+	entries, err := os.ReadDir(output)
+	if err != nil {
+		return "", err
+	}
+
+	// the playlist is uploaded last, so it never references a segment that is not uploaded yet
+	for _, entry := range entries {
+		if entry.Name() == hlsPlaylistName {
+			continue
+		}
+
+		if err := t.upload(ctx, filepath.Join(output, entry.Name()), req.Prefix+"/"+entry.Name(), hlsObjectContentType(entry.Name())); err != nil {
+			return "", err
+		}
+	}
+
+	playlistObjectName := req.Prefix + "/" + hlsPlaylistName
+	if err := t.upload(ctx, filepath.Join(output, hlsPlaylistName), playlistObjectName, hlsContentType); err != nil {
+		return "", err
+	}
+
+	return playlistObjectName, nil
+}
+
+func (t *ffmpegTranscoder) Thumbnail(ctx context.Context, req *ThumbnailRequest) error {
+	dir, err := os.MkdirTemp(t.workDir, "thumbnail-*")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	source := filepath.Join(dir, "source")
+	if err := t.download(ctx, req.SourceObjectName, source); err != nil {
+		return err
+	}
+
+	poster := filepath.Join(dir, "poster.jpg")
+	if err := t.run(ctx, t.ffmpegPath, ffmpegPosterArgs(source, poster, req.PosterTime)...); err != nil {
+		return err
+	}
+
+	sprite := filepath.Join(dir, "sprite.jpg")
+	if err := t.run(ctx, t.ffmpegPath, ffmpegSpriteArgs(source, sprite, req.Sprite)...); err != nil {
+		return err
+	}
+
+	if err := t.upload(ctx, poster, req.PosterObjectName, thumbnailContentType); err != nil {
+		return err
+	}
+
+	return t.upload(ctx, sprite, req.SpriteObjectName, thumbnailContentType)
+}
+
+// probeSource returns the presigned URL of the object, which ffprobe reads by ranges instead of the whole object,
+// and the object is downloaded only if the storage cannot presign. The cleanup removes the downloaded object.
+func (t *ffmpegTranscoder) probeSource(ctx context.Context, objectName string) (string, func(), error) {
+	// ffprobe cannot tell a missing object from an invalid video, so the object is checked first
+	if _, err := t.storage.StatObject(ctx, objectName); err != nil {
+		return "", nil, err
+	}
+
+	presignedURL, err := t.storage.PresignedGetObject(ctx, objectName)
+	if err == nil {
+		return presignedURL.URL, func() {}, nil
+	}
+
+	if !errors.Is(err, storagekit.ErrPresignNotSupported) {
+		return "", nil, err
+	}
+
+	dir, err := os.MkdirTemp(t.workDir, "probe-*")
+	if err != nil {
+		return "", nil, err
+	}
+
+	source := filepath.Join(dir, "source")
+	if err := t.download(ctx, objectName, source); err != nil {
+		os.RemoveAll(dir)
+		return "", nil, err
+	}
+
+	return source, func() { os.RemoveAll(dir) }, nil
+}
+
+func (t *ffmpegTranscoder) download(ctx context.Context, objectName string, filename string) error {
+	reader, err := t.storage.GetObject(ctx, objectName, storagekit.GetObjectOptions{})
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(file, reader); err != nil {
+		return err
+	}
+
+	return file.Close()
+}
+
+func (t *ffmpegTranscoder) upload(ctx context.Context, filename string, objectName string, contentType string) error {
+	file, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	return t.storage.PutObject(ctx, objectName, file, info.Size(), storagekit.PutObjectOptions{
+		ContentType: contentType,
+	})
+}
+
+// probe reads the metadata of the rendition with ffprobe
+func (t *ffmpegTranscoder) probe(ctx context.Context, filename string) (*Rendition, error) {
+	var stdout bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, t.ffprobePath,
+		"-v", "error",
+		"-select_streams", "v:0",
+		"-show_entries", "stream=width,height:format=duration,size",
+		"-of", "json",
+		filename,
+	)
+	cmd.Stdout = &stdout
+
+	if err := t.runCmd(cmd); err != nil {
+		return nil, err
+	}
+
+	return parseFFprobeOutput(stdout.Bytes())
+}
+
+func (t *ffmpegTranscoder) run(ctx context.Context, name string, args ...string) error {
+	return t.runCmd(exec.CommandContext(ctx, name, args...))
+}
+
+func (t *ffmpegTranscoder) runCmd(cmd *exec.Cmd) error {
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s: %w: %s", filepath.Base(cmd.Path), err, bytes.TrimSpace(stderr.Bytes()))
+	}
+
+	return nil
+}
+
+// ffmpegArgs returns the ffmpeg arguments to scale the source to the height of the profile with its codec and bitrate,
+// the width is rounded to an even number since it is required by the encoders.
+func ffmpegArgs(source string, output string, profile *dao.Profile) []string {
+	args := []string{
+		"-y",
+		"-i", source,
+		"-vf", "scale=-2:" + strconv.Itoa(int(profile.Height)),
+	}
+
+	switch profile.Codec {
+	case dao.ProfileCodecH265:
+		args = append(args, "-c:v", "libx265", "-preset", "veryfast", "-tag:v", "hvc1")
+	case dao.ProfileCodecVP9:
+		args = append(args, "-c:v", "libvpx-vp9", "-deadline", "good", "-row-mt", "1")
+	default:
+		args = append(args, "-c:v", "libx264", "-preset", "veryfast")
+	}
+
+	if profile.Bitrate > 0 {
+		bitrate := strconv.Itoa(int(profile.Bitrate)) + "k"
+		bufsize := strconv.Itoa(int(profile.Bitrate)*2) + "k"
+
+		args = append(args, "-b:v", bitrate, "-maxrate", bitrate, "-bufsize", bufsize)
+	}
+
+	if profile.Container == dao.ProfileContainerWebM {
+		args = append(args, "-c:a", "libopus")
+	} else {
+		args = append(args, "-c:a", "aac", "-movflags", "+faststart")
+	}
+
+	return append(args, output)
+}
+
+// ffmpegHLSArgs returns the ffmpeg arguments to segment the rendition into a VOD media playlist
+// of fragmented MP4 segments in the output directory without encoding again.
+func ffmpegHLSArgs(source string, outputDir string) []string {
+	return []string{
+		"-y",
+		"-i", source,
+		"-c", "copy",
+		"-f", "hls",
+		"-hls_time", "6",
+		"-hls_playlist_type", "vod",
+		"-hls_segment_type", "fmp4",
+		"-hls_fmp4_init_filename", "init.mp4",
+		"-hls_segment_filename", filepath.Join(outputDir, "segment_%05d.m4s"),
+		filepath.Join(outputDir, hlsPlaylistName),
+	}
+}
+
+// ffmpegPosterArgs returns the ffmpeg arguments to extract a single frame at the time,
+// the input is sought before decoding so only the frames around the time are decoded.
+func ffmpegPosterArgs(source string, output string, time float64) []string {
+	return []string{
+		"-y",
+		"-ss", strconv.FormatFloat(time, 'f', 3, 64),
+		"-i", source,
+		"-frames:v", "1",
+		"-q:v", "2",
+		output,
+	}
+}
+
+// ffmpegSpriteArgs returns the ffmpeg arguments to take a frame every interval, scale it to the tile
+// and tile the frames into a single image.
+func ffmpegSpriteArgs(source string, output string, layout *SpriteLayout) []string {
+	filter := fmt.Sprintf("fps=1/%s,scale=%d:%d,tile=%dx%d",
+		strconv.FormatFloat(layout.Interval, 'f', -1, 64),
+		layout.TileWidth, layout.TileHeight,
+		layout.Columns, layout.Rows,
+	)
+
+	return []string{
+		"-y",
+		"-i", source,
+		"-vf", filter,
+		"-frames:v", "1",
+		"-q:v", "4",
+		output,
+	}
+}
+
+var errNoVideoStream = errors.New("no video stream found")
+
+func parseFFprobeOutput(data []byte) (*Rendition, error) {
+	var output struct {
+		Streams []struct {
+			Width  uint32 `json:"width"`
+			Height uint32 `json:"height"`
+		} `json:"streams"`
+		Format struct {
+			Duration string `json:"duration"`
+			Size     string `json:"size"`
+		} `json:"format"`
+	}
+
+	if err := json.Unmarshal(data, &output); err != nil {
+		return nil, err
+	}
+
+	if len(output.Streams) == 0 {
+		return nil, errNoVideoStream
+	}
+
+	rendition := &Rendition{
+		Width:  output.Streams[0].Width,
+		Height: output.Streams[0].Height,
+	}
+
+	if output.Format.Duration != "" {
+		duration, err := strconv.ParseFloat(output.Format.Duration, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		rendition.Duration = duration
+	}
+
+	if output.Format.Size != "" {
+		size, err := strconv.ParseUint(output.Format.Size, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		rendition.Size = size
+	}
+
+	return rendition, nil
+}
@@ -0,0 +1,251 @@
+package stream
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/modules/video/dao"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/logkit"
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/storagekit"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Transcoder", func() {
+	Describe("variantObjectName", func() {
+		It("replaces the extension with the variant and the container", func() {
+			Expect(variantObjectName("id-video.mov", "720p", "mp4")).To(Equal("id-video-720p.mp4"))
+			Expect(variantObjectName("id-video", "1080p", "webm")).To(Equal("id-video-1080p.webm"))
+		})
+	})
+
+	Describe("fakeTranscoder", func() {
+		var (
+			ctx        context.Context
+			storage    *storagekit.MemoryStorage
+			transcoder *fakeTranscoder
+
+			rendition *Rendition
+			err       error
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			storage = storagekit.NewMemoryStorage(logkit.WithContext(ctx, logkit.NewNopLogger()), &storagekit.MemoryConfig{Bucket: "videos"})
+			transcoder = NewFakeTranscoder(storage)
+
+			putSourceObject(ctx, storage, "id-video.mp4")
+		})
+
+		Describe("Probe", func() {
+			It("probes the source as a 1080p video", func() {
+				rendition, err := transcoder.Probe(ctx, "id-video.mp4")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(rendition).To(Equal(&Rendition{
+					ObjectName: "id-video.mp4",
+					Width:      1920,
+					Height:     1080,
+					Size:       uint64(len("source")),
+				}))
+			})
+
+			It("rejects an empty source", func() {
+				Expect(storage.PutObject(ctx, "empty.mp4", strings.NewReader(""), 0, storagekit.PutObjectOptions{})).To(Succeed())
+
+				_, err := transcoder.Probe(ctx, "empty.mp4")
+				Expect(err).To(MatchError(ErrInvalidSource))
+			})
+		})
+
+		Describe("Transcode", func() {
+			JustBeforeEach(func() {
+				rendition, err = transcoder.Transcode(ctx, &TranscodeRequest{
+					SourceObjectName: "id-video.mp4",
+					ObjectName:       "id-video-480p.webm",
+					Profile: &dao.Profile{
+						ID:        "480p",
+						Height:    480,
+						Codec:     dao.ProfileCodecVP9,
+						Container: dao.ProfileContainerWebM,
+					},
+				})
+			})
+
+			It("uploads the deterministic rendition", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(rendition).To(Equal(&Rendition{
+					ObjectName: "id-video-480p.webm",
+					Width:      854,
+					Height:     480,
+					Size:       uint64(len("fake-480p:source")),
+				}))
+
+				info, err := storage.StatObject(ctx, "id-video-480p.webm")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(info.ContentType).To(Equal("video/webm"))
+
+				reader, err := storage.GetObject(ctx, "id-video-480p.webm", storagekit.GetObjectOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				defer reader.Close()
+
+				Expect(io.ReadAll(reader)).To(Equal([]byte("fake-480p:source")))
+			})
+		})
+
+		Describe("Thumbnail", func() {
+			It("uploads the placeholder poster and sprite sheet", func() {
+				Expect(transcoder.Thumbnail(ctx, &ThumbnailRequest{
+					SourceObjectName: "id-video.mp4",
+					PosterObjectName: "id-video-poster.jpg",
+					PosterTime:       1.5,
+					SpriteObjectName: "id-video-sprite.jpg",
+					Sprite:           &SpriteLayout{Interval: 1, Tiles: 14, Columns: 10, Rows: 2, TileWidth: 160, TileHeight: 90},
+				})).To(Succeed())
+
+				for _, objectName := range []string{"id-video-poster.jpg", "id-video-sprite.jpg"} {
+					info, err := storage.StatObject(ctx, objectName)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(info.ContentType).To(Equal(thumbnailContentType))
+				}
+			})
+
+			It("returns the error if the source is not found", func() {
+				Expect(transcoder.Thumbnail(ctx, &ThumbnailRequest{
+					SourceObjectName: "not-found.mp4",
+					Sprite:           &SpriteLayout{},
+				})).To(MatchError(storagekit.ErrObjectNotFound))
+			})
+		})
+
+		Describe("Package", func() {
+			It("uploads the rendition as the only segment of the media playlist", func() {
+				playlistObjectName, err := transcoder.Package(ctx, &PackageRequest{
+					ObjectName: "id-video.mp4",
+					Prefix:     "id-video-hls/720p",
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(playlistObjectName).To(Equal("id-video-hls/720p/index.m3u8"))
+
+				info, err := storage.StatObject(ctx, playlistObjectName)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(info.ContentType).To(Equal(hlsContentType))
+
+				info, err = storage.StatObject(ctx, "id-video-hls/720p/segment_00000.m4s")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(info.Size).To(Equal(int64(len("source"))))
+			})
+		})
+	})
+
+	Describe("ffmpegArgs", func() {
+		It("scales to the height with an even width in H.264 and MP4", func() {
+			Expect(ffmpegArgs("in", "out.mp4", dao.NewFakeProfile("720p", 720))).To(Equal([]string{
+				"-y",
+				"-i", "in",
+				"-vf", "scale=-2:720",
+				"-c:v", "libx264",
+				"-preset", "veryfast",
+				"-b:v", "2880k",
+				"-maxrate", "2880k",
+				"-bufsize", "5760k",
+				"-c:a", "aac",
+				"-movflags", "+faststart",
+				"out.mp4",
+			}))
+		})
+
+		It("encodes VP9 into WebM with the encoder deciding the bitrate", func() {
+			Expect(ffmpegArgs("in", "out.webm", &dao.Profile{
+				ID:        "480p",
+				Height:    480,
+				Codec:     dao.ProfileCodecVP9,
+				Container: dao.ProfileContainerWebM,
+			})).To(Equal([]string{
+				"-y",
+				"-i", "in",
+				"-vf", "scale=-2:480",
+				"-c:v", "libvpx-vp9",
+				"-deadline", "good",
+				"-row-mt", "1",
+				"-c:a", "libopus",
+				"out.webm",
+			}))
+		})
+	})
+
+	Describe("ffmpegHLSArgs", func() {
+		It("segments the rendition into fragmented MP4 without encoding again", func() {
+			Expect(ffmpegHLSArgs("in.mp4", "out")).To(Equal([]string{
+				"-y",
+				"-i", "in.mp4",
+				"-c", "copy",
+				"-f", "hls",
+				"-hls_time", "6",
+				"-hls_playlist_type", "vod",
+				"-hls_segment_type", "fmp4",
+				"-hls_fmp4_init_filename", "init.mp4",
+				"-hls_segment_filename", "out/segment_%05d.m4s",
+				"out/index.m3u8",
+			}))
+		})
+	})
+
+	Describe("ffmpegPosterArgs", func() {
+		It("seeks the input before extracting a frame", func() {
+			Expect(ffmpegPosterArgs("in", "poster.jpg", 1.3696)).To(Equal([]string{
+				"-y",
+				"-ss", "1.370",
+				"-i", "in",
+				"-frames:v", "1",
+				"-q:v", "2",
+				"poster.jpg",
+			}))
+		})
+	})
+
+	Describe("ffmpegSpriteArgs", func() {
+		It("tiles a frame every interval into a single image", func() {
+			Expect(ffmpegSpriteArgs("in", "sprite.jpg", &SpriteLayout{
+				Interval:   36,
+				Tiles:      100,
+				Columns:    10,
+				Rows:       10,
+				TileWidth:  160,
+				TileHeight: 90,
+			})).To(Equal([]string{
+				"-y",
+				"-i", "in",
+				"-vf", "fps=1/36,scale=160:90,tile=10x10",
+				"-frames:v", "1",
+				"-q:v", "4",
+				"sprite.jpg",
+			}))
+		})
+	})
+
+	Describe("parseFFprobeOutput", func() {
+		When("no video stream", func() {
+			It("returns an error", func() {
+				_, err := parseFFprobeOutput([]byte(`{"streams": [], "format": {}}`))
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		When("success", func() {
+			It("returns the metadata", func() {
+				rendition, err := parseFFprobeOutput([]byte(`{
+					"streams": [{"width": 1280, "height": 720}],
+					"format": {"duration": "10.500000", "size": "1048576"}
+				}`))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(rendition).To(Equal(&Rendition{
+					Width:    1280,
+					Height:   720,
+					Size:     1048576,
+					Duration: 10.5,
+				}))
+			})
+		})
+	})
+})
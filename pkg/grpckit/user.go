@@ -0,0 +1,114 @@
+package grpckit
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/textproto"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// UserIDHeader is the HTTP header of the ID of the signed-in user, which is set by the authenticating
+	// proxy in front of the gateways, the header of the requests from any other address is dropped.
+	UserIDHeader = "X-User-Id"
+
+	userIDMetadataKey = "x-user-id"
+)
+
+// metadataUserIDHeader is the header forwarded as the user ID metadata by the default header matcher of the gateway
+var metadataUserIDHeader = textproto.CanonicalMIMEHeaderKey(runtime.MetadataHeaderPrefix + userIDMetadataKey)
+
+type TrustedProxyConfig struct {
+	TrustedProxies []string `long:"trusted_proxies" env:"TRUSTED_PROXIES" env-delim:"," description:"the CIDRs of the authenticating proxies allowed to set the user ID header, requests from any other address are anonymous"`
+}
+
+// NewTrustedProxyHandler returns the handler that drops the user ID header of the requests not from the trusted proxies,
+// so a client connecting to the gateway directly cannot claim to be any user.
+func NewTrustedProxyHandler(handler http.Handler, conf *TrustedProxyConfig) (http.Handler, error) {
+	networks := make([]*net.IPNet, 0, len(conf.TrustedProxies))
+	for _, proxy := range conf.TrustedProxies {
+		_, network, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", proxy, err)
+		}
+
+		networks = append(networks, network)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		// the user ID is never taken from the metadata header, which the proxies do not know to strip
+		req.Header.Del(metadataUserIDHeader)
+
+		if !isTrustedProxy(networks, req.RemoteAddr) {
+			req.Header.Del(UserIDHeader)
+		}
+
+		handler.ServeHTTP(w, req)
+	}), nil
+}
+
+func isTrustedProxy(networks []*net.IPNet, remoteAddr string) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return false
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// UserIDFromContext returns the ID of the user in the incoming metadata, which is empty for anonymous requests
+func UserIDFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	if values := md.Get(userIDMetadataKey); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+// WithUserID returns the context with the ID of the user in the outgoing metadata
+func WithUserID(ctx context.Context, userID string) context.Context {
+	if userID == "" {
+		return ctx
+	}
+
+	return metadata.AppendToOutgoingContext(ctx, userIDMetadataKey, userID)
+}
+
+// ForwardUserID forwards the ID of the user of the incoming request to the outgoing requests,
+// so the downstream servers authorize the same user
+func ForwardUserID(ctx context.Context) context.Context {
+	return WithUserID(ctx, UserIDFromContext(ctx))
+}
+
+// GatewayHeaderMatcher forwards the user ID header to the metadata besides the headers forwarded by default,
+// the user ID header must have been checked by the handler returned by NewTrustedProxyHandler
+func GatewayHeaderMatcher(key string) (string, bool) {
+	switch textproto.CanonicalMIMEHeaderKey(key) {
+	case UserIDHeader:
+		return userIDMetadataKey, true
+	case metadataUserIDHeader:
+		return "", false
+	}
+
+	return runtime.DefaultHeaderMatcher(key)
+}
@@ -0,0 +1,84 @@
+package grpckit
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TrustedProxyHandler", func() {
+	var (
+		conf    *TrustedProxyConfig
+		req     *http.Request
+		header  http.Header
+		handler http.Handler
+		err     error
+	)
+
+	BeforeEach(func() {
+		conf = &TrustedProxyConfig{TrustedProxies: []string{"10.0.0.0/8"}}
+
+		req = httptest.NewRequest(http.MethodGet, "/v1/videos", nil)
+		req.Header.Set(UserIDHeader, "user")
+		req.Header.Set("Grpc-Metadata-X-User-Id", "another user")
+	})
+
+	JustBeforeEach(func() {
+		handler, err = NewTrustedProxyHandler(http.HandlerFunc(func(_ http.ResponseWriter, req *http.Request) {
+			header = req.Header
+		}), conf)
+		if err == nil {
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+		}
+	})
+
+	When("request is from a trusted proxy", func() {
+		BeforeEach(func() {
+			req.RemoteAddr = "10.1.2.3:40000"
+		})
+
+		It("keeps the user ID header only", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(header.Get(UserIDHeader)).To(Equal("user"))
+			Expect(header.Get("Grpc-Metadata-X-User-Id")).To(BeEmpty())
+		})
+	})
+
+	When("request is not from a trusted proxy", func() {
+		BeforeEach(func() {
+			req.RemoteAddr = "192.168.1.2:40000"
+		})
+
+		It("drops the user ID headers", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(header.Get(UserIDHeader)).To(BeEmpty())
+			Expect(header.Get("Grpc-Metadata-X-User-Id")).To(BeEmpty())
+		})
+	})
+
+	When("trusted proxy is not a CIDR", func() {
+		BeforeEach(func() {
+			conf.TrustedProxies = []string{"10.0.0.1"}
+		})
+
+		It("returns error", func() {
+			Expect(handler).To(BeNil())
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
+
+var _ = Describe("GatewayHeaderMatcher", func() {
+	It("forwards the user ID header", func() {
+		key, ok := GatewayHeaderMatcher("x-user-id")
+		Expect(ok).To(BeTrue())
+		Expect(key).To(Equal("x-user-id"))
+	})
+
+	It("does not forward the user ID metadata header", func() {
+		_, ok := GatewayHeaderMatcher("grpc-metadata-x-user-id")
+		Expect(ok).To(BeFalse())
+	})
+})
@@ -7,6 +7,7 @@ import (
 
 	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/logkit"
 	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/mongodb"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"go.uber.org/zap"
@@ -21,11 +22,14 @@ type MigrationConfig struct {
 	// File System: https://github.com/golang-migrate/migrate/tree/master/source/file
 	Source string `long:"source" env:"SOURCE" description:"the migration files source directory" required:"true"`
 	// URL is the migration database URL,
-	// currently we only accept Postgres as database.
+	// currently we accept Postgres and MongoDB as database.
+	// The database type is chosen by the URL scheme, and the migrations of MongoDB
+	// are JSON arrays of the database commands.
 	//
 	// Register more database types by importing other database packages.
 	//
 	// Postgres: https://github.com/golang-migrate/migrate/tree/master/database/postgres
+	// MongoDB: https://github.com/golang-migrate/migrate/tree/master/database/mongodb
 	URL string `long:"url" env:"URL" description:"the database url" required:"true"`
 }
 
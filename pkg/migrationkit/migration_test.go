@@ -3,6 +3,7 @@ package migrationkit
 import (
 	"context"
 	"os"
+	"strings"
 
 	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/logkit"
 	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/pgkit"
@@ -49,5 +50,18 @@ var _ = Describe("Migration", func() {
 				Expect(migration).NotTo(BeNil())
 			})
 		})
+
+		When("database is MongoDB", func() {
+			BeforeEach(func() {
+				migrationConf.URL = "mongodb://mongo:27017/nthu_distributed_system"
+				if url := os.Getenv("MONGO_URL"); url != "" {
+					migrationConf.URL = strings.TrimSuffix(url, "/") + "/nthu_distributed_system"
+				}
+			})
+
+			It("returns new Migration without error", func() {
+				Expect(migration).NotTo(BeNil())
+			})
+		})
 	})
 })
@@ -0,0 +1,80 @@
+package otelkit
+
+import (
+	"context"
+
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/logkit"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+	"go.opentelemetry.io/otel/metric/nonrecording"
+	"go.uber.org/zap"
+)
+
+// CacheMeter provides 3 meters to measure the reads of a cache:
+// 1. Count number of reads served by the cache
+// 2. Count number of reads missing the cache
+// 3. Count number of reads falling back to the underlying storage since the cache is unavailable
+type CacheMeter struct {
+	attributes      []attribute.KeyValue
+	hitCounter      syncint64.Counter
+	missCounter     syncint64.Counter
+	fallbackCounter syncint64.Counter
+}
+
+func (m *CacheMeter) Hit(ctx context.Context) {
+	m.hitCounter.Add(ctx, 1, m.attributes...)
+}
+
+func (m *CacheMeter) Miss(ctx context.Context) {
+	m.missCounter.Add(ctx, 1, m.attributes...)
+}
+
+func (m *CacheMeter) Fallback(ctx context.Context) {
+	m.fallbackCounter.Add(ctx, 1, m.attributes...)
+}
+
+// NewCacheMeter returns the meter of the cache named by name, which is recorded as the Cache attribute
+func NewCacheMeter(ctx context.Context, meter metric.Meter, name string) *CacheMeter {
+	logger := logkit.FromContext(ctx).With(zap.String("cache", name))
+
+	cacheMeter, err := newCacheMeter(meter, name)
+	if err != nil {
+		logger.Fatal("failed to create cache meter", zap.Error(err))
+	}
+
+	return cacheMeter
+}
+
+// NewNopCacheMeter returns a meter which records nothing, for the caches that are not exported
+func NewNopCacheMeter() *CacheMeter {
+	// the non-recording instruments never fail to be created
+	cacheMeter, _ := newCacheMeter(nonrecording.NewNoopMeterProvider().Meter(""), "")
+
+	return cacheMeter
+}
+
+func newCacheMeter(meter metric.Meter, name string) (*CacheMeter, error) {
+	hitCounter, err := meter.SyncInt64().Counter("cache_hit", instrument.WithDescription("count number of reads served by the cache"))
+	if err != nil {
+		return nil, err
+	}
+
+	missCounter, err := meter.SyncInt64().Counter("cache_miss", instrument.WithDescription("count number of reads missing the cache"))
+	if err != nil {
+		return nil, err
+	}
+
+	fallbackCounter, err := meter.SyncInt64().Counter("cache_fallback", instrument.WithDescription("count number of reads falling back since the cache is unavailable"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &CacheMeter{
+		attributes:      []attribute.KeyValue{attribute.String("Cache", name)},
+		hitCounter:      hitCounter,
+		missCounter:     missCounter,
+		fallbackCounter: fallbackCounter,
+	}, nil
+}
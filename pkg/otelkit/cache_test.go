@@ -0,0 +1,62 @@
+package otelkit
+
+import (
+	"context"
+	"time"
+
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/logkit"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CacheMeter", func() {
+	var (
+		ctx        context.Context
+		conf       *PrometheusServiceMeterConfig
+		meter      *PrometheusServiceMeter
+		cacheMeter *CacheMeter
+	)
+
+	BeforeEach(func() {
+		ctx = logkit.NewNopLogger().WithContext(context.Background())
+
+		conf = &PrometheusServiceMeterConfig{
+			Addr:                ":52223",
+			Path:                "/metrics",
+			Name:                "test_cache_meter",
+			HistogramBoundaries: []float64{10, 100},
+		}
+
+		meter = NewPrometheusServiceMeter(ctx, conf)
+		time.Sleep(50 * time.Millisecond) // wait prometheus exporter server to start
+
+		cacheMeter = NewCacheMeter(ctx, meter, "test")
+	})
+
+	AfterEach(func() {
+		Expect(meter.Close()).NotTo(HaveOccurred())
+	})
+
+	It("records the hits, the misses and the fallbacks", func() {
+		cacheMeter.Hit(ctx)
+		cacheMeter.Hit(ctx)
+		cacheMeter.Miss(ctx)
+		cacheMeter.Fallback(ctx)
+
+		validateCounter(ctx, conf, "cache_hit", 2)
+		validateCounter(ctx, conf, "cache_miss", 1)
+		validateCounter(ctx, conf, "cache_fallback", 1)
+	})
+
+	Describe("NewNopCacheMeter", func() {
+		It("records nothing", func() {
+			nopMeter := NewNopCacheMeter()
+
+			Expect(func() {
+				nopMeter.Hit(ctx)
+				nopMeter.Miss(ctx)
+				nopMeter.Fallback(ctx)
+			}).NotTo(Panic())
+		})
+	})
+})
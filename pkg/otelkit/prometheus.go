@@ -99,6 +99,7 @@ func NewPrometheusServiceMeter(ctx context.Context, conf *PrometheusServiceMeter
 	}
 
 	return &PrometheusServiceMeter{
+		Meter:                 meter,
 		server:                server,
 		requestCounter:        requestCounter,
 		requestErrorCounter:   requestErrorCounter,
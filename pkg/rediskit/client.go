@@ -2,6 +2,7 @@ package rediskit
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/logkit"
 	"github.com/go-redis/redis/v8"
@@ -16,9 +17,15 @@ type RedisConfig struct {
 
 type RedisClient struct {
 	*redis.Client
+	bus       *InvalidationBus
 	closeFunc func()
 }
 
+// InvalidationBus returns the bus which broadcasts the cache invalidations to the clients of the same database
+func (c *RedisClient) InvalidationBus() *InvalidationBus {
+	return c.bus
+}
+
 func (c *RedisClient) Close() error {
 	if c.closeFunc != nil {
 		c.closeFunc()
@@ -43,7 +50,11 @@ func NewRedisClient(ctx context.Context, conf *RedisConfig) *RedisClient {
 		logger.Fatal("failed to ping to Redis", zap.Error(err))
 	}
 
+	bus := newInvalidationBus(client, fmt.Sprintf("cache:invalidation:%d", conf.Database), logger)
+
 	return &RedisClient{
-		Client: client,
+		Client:    client,
+		bus:       bus,
+		closeFunc: bus.close,
 	}
 }
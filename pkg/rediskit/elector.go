@@ -0,0 +1,128 @@
+package rediskit
+
+import (
+	"context"
+	"time"
+
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/logkit"
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type LeaderElectionConfig struct {
+	LeaseDuration time.Duration `long:"lease_duration" env:"LEASE_DURATION" description:"the duration the leader holds the lease without renewing it" default:"15s"`
+	RetryPeriod   time.Duration `long:"retry_period" env:"RETRY_PERIOD" description:"the period to renew the lease or to campaign for the leadership, which must be shorter than the lease duration" default:"5s"`
+}
+
+var (
+	// renewScript extends the lease only if it is still held by the candidate
+	renewScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+	// releaseScript deletes the lease only if it is still held by the candidate
+	releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+)
+
+// LeaderElector elects a leader among the replicas by a lease in Redis, the leader renews the lease every
+// retry period and the other replicas take over once the lease expires.
+type LeaderElector struct {
+	client        *RedisClient
+	key           string
+	id            string
+	leaseDuration time.Duration
+	retryPeriod   time.Duration
+}
+
+func NewLeaderElector(client *RedisClient, key string, conf *LeaderElectionConfig) *LeaderElector {
+	return &LeaderElector{
+		client:        client,
+		key:           key,
+		id:            uuid.NewString(),
+		leaseDuration: conf.LeaseDuration,
+		retryPeriod:   conf.RetryPeriod,
+	}
+}
+
+// Run campaigns for the leadership until the context is done, and runs fn whenever the leadership is acquired.
+// The context of fn is cancelled once the leadership is lost, fn must return soon after so that no two replicas
+// lead at the same time.
+func (e *LeaderElector) Run(ctx context.Context, fn func(ctx context.Context)) error {
+	logger := logkit.FromContext(ctx).With(zap.String("key", e.key), zap.String("id", e.id))
+
+	for {
+		acquired, err := e.client.SetNX(ctx, e.key, e.id, e.leaseDuration).Result()
+		if err != nil && ctx.Err() == nil {
+			logger.Error("failed to acquire the lease", zap.Error(err))
+		}
+
+		if acquired {
+			logger.Info("acquired the leadership")
+			e.lead(ctx, fn)
+			logger.Info("lost the leadership")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(e.retryPeriod):
+		}
+	}
+}
+
+// lead runs fn and renews the lease until fn returns, the context is done or the lease is not renewed
+func (e *LeaderElector) lead(ctx context.Context, fn func(ctx context.Context)) {
+	logger := logkit.FromContext(ctx).With(zap.String("key", e.key), zap.String("id", e.id))
+
+	leaderCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn(leaderCtx)
+	}()
+
+	defer func() {
+		cancel()
+		<-done
+
+		// release the lease so another replica takes over without waiting for the lease to expire,
+		// the context may be done already
+		if err := releaseScript.Run(context.Background(), e.client, []string{e.key}, e.id).Err(); err != nil {
+			logger.Error("failed to release the lease", zap.Error(err))
+		}
+	}()
+
+	ticker := time.NewTicker(e.retryPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// the leader steps down on any error since it cannot tell whether the lease is still held
+			renewed, err := renewScript.Run(ctx, e.client, []string{e.key}, e.id, e.leaseDuration.Milliseconds()).Int()
+			if err != nil {
+				logger.Error("failed to renew the lease", zap.Error(err))
+				return
+			}
+
+			if renewed == 0 {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package rediskit
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/logkit"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LeaderElector", func() {
+	var (
+		ctx         context.Context
+		redisClient *RedisClient
+		conf        LeaderElectionConfig
+		key         string
+	)
+
+	BeforeEach(func() {
+		ctx = logkit.WithContext(context.Background(), logkit.NewNopLogger())
+
+		redisConf := RedisConfig{Addr: "localhost:6379"}
+		if addr := os.Getenv("REDIS_ADDR"); addr != "" {
+			redisConf.Addr = addr
+		}
+
+		redisClient = NewRedisClient(ctx, &redisConf)
+		conf = LeaderElectionConfig{LeaseDuration: time.Second, RetryPeriod: 100 * time.Millisecond}
+		key = "leader:" + uuid.NewString()
+	})
+
+	AfterEach(func() {
+		Expect(redisClient.Del(ctx, key).Err()).NotTo(HaveOccurred())
+		Expect(redisClient.Close()).NotTo(HaveOccurred())
+	})
+
+	Describe("Run", func() {
+		// run runs an elector and reports whether it is leading
+		run := func(ctx context.Context, elector *LeaderElector) (<-chan bool, <-chan error) {
+			leading := make(chan bool, 10)
+			done := make(chan error, 1)
+
+			go func() {
+				done <- elector.Run(ctx, func(ctx context.Context) {
+					leading <- true
+					<-ctx.Done()
+					leading <- false
+				})
+			}()
+
+			return leading, done
+		}
+
+		It("elects a single leader and fails over once the leader stops", func() {
+			ctx1, cancel1 := context.WithCancel(ctx)
+			defer cancel1()
+			ctx2, cancel2 := context.WithCancel(ctx)
+			defer cancel2()
+
+			leading1, done1 := run(ctx1, NewLeaderElector(redisClient, key, &conf))
+			Eventually(leading1).Should(Receive(BeTrue()))
+
+			leading2, done2 := run(ctx2, NewLeaderElector(redisClient, key, &conf))
+			Consistently(leading2, 3*conf.RetryPeriod).ShouldNot(Receive())
+
+			cancel1()
+			Eventually(leading1).Should(Receive(BeFalse()))
+			Eventually(done1).Should(Receive(BeNil()))
+
+			// the lease is released so the other elector takes over without waiting for the lease to expire
+			Eventually(leading2, conf.LeaseDuration/2).Should(Receive(BeTrue()))
+
+			cancel2()
+			Eventually(leading2).Should(Receive(BeFalse()))
+			Eventually(done2).Should(Receive(BeNil()))
+		})
+
+		It("steps down once the lease is taken", func() {
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+
+			leading, done := run(ctx, NewLeaderElector(redisClient, key, &conf))
+			Eventually(leading).Should(Receive(BeTrue()))
+
+			Expect(redisClient.Set(ctx, key, "another leader", 0).Err()).NotTo(HaveOccurred())
+			Eventually(leading).Should(Receive(BeFalse()))
+			Consistently(done, 3*conf.RetryPeriod).ShouldNot(Receive())
+		})
+	})
+})
@@ -0,0 +1,104 @@
+package rediskit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/logkit"
+	"github.com/go-redis/cache/v8"
+	"github.com/go-redis/redis/v8"
+	"go.uber.org/zap"
+)
+
+// LocalCache is the in-process cache which the invalidation bus evicts the keys from
+type LocalCache interface {
+	Del(key string)
+}
+
+// InvalidationBus broadcasts the keys evicted from the cache over Redis pub/sub, so that every replica drops the keys
+// from its local cache, which is not evicted by deleting the keys in Redis. The invalidations published while a replica
+// is reconnecting are lost, and its local cache serves the stale keys until their TTL expires.
+type InvalidationBus struct {
+	client  *redis.Client
+	channel string
+	logger  *logkit.Logger
+
+	once   sync.Once
+	pubsub *redis.PubSub
+
+	mu     sync.RWMutex
+	caches []LocalCache
+}
+
+func newInvalidationBus(client *redis.Client, channel string, logger *logkit.Logger) *InvalidationBus {
+	return &InvalidationBus{
+		client:  client,
+		channel: channel,
+		logger:  logger.With(zap.String("channel", channel)),
+	}
+}
+
+// NewLocalCache returns a TinyLFU local cache subscribed to the bus for the lifetime of the client
+func (b *InvalidationBus) NewLocalCache(size int, ttl time.Duration) cache.LocalCache {
+	local := cache.NewTinyLFU(size, ttl)
+	b.Subscribe(local)
+
+	return local
+}
+
+// Subscribe drops the keys from the local cache once they are published, the bus subscribes to Redis on the first call
+func (b *InvalidationBus) Subscribe(local LocalCache) {
+	b.mu.Lock()
+	b.caches = append(b.caches, local)
+	b.mu.Unlock()
+
+	b.once.Do(b.subscribe)
+}
+
+// Publish broadcasts the keys to every replica, including the current one
+func (b *InvalidationBus) Publish(ctx context.Context, keys ...string) error {
+	_, err := b.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, key := range keys {
+			pipe.Publish(ctx, b.channel, key)
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (b *InvalidationBus) subscribe() {
+	ctx := context.Background()
+
+	b.pubsub = b.client.Subscribe(ctx, b.channel)
+
+	// wait for the subscription so the keys published after the local cache is created are not missed,
+	// on failure the subscription is retried by the pub/sub in the background
+	if _, err := b.pubsub.Receive(ctx); err != nil {
+		b.logger.Error("failed to subscribe to the cache invalidations", zap.Error(err))
+	}
+
+	go b.run(b.pubsub.Channel())
+}
+
+func (b *InvalidationBus) run(messages <-chan *redis.Message) {
+	for message := range messages {
+		b.mu.RLock()
+		for _, local := range b.caches {
+			local.Del(message.Payload)
+		}
+		b.mu.RUnlock()
+	}
+}
+
+func (b *InvalidationBus) close() {
+	// prevent a subscription after the close
+	b.once.Do(func() {})
+
+	if b.pubsub != nil {
+		if err := b.pubsub.Close(); err != nil {
+			b.logger.Error("failed to close the cache invalidation subscription", zap.Error(err))
+		}
+	}
+}
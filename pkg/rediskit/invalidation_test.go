@@ -0,0 +1,65 @@
+package rediskit
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/logkit"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("InvalidationBus", func() {
+	var (
+		ctx                  context.Context
+		client1, client2     *RedisClient
+		key, otherKey, value string
+	)
+
+	BeforeEach(func() {
+		ctx = logkit.WithContext(context.Background(), logkit.NewNopLogger())
+
+		redisConf := RedisConfig{Addr: "localhost:6379"}
+		if addr := os.Getenv("REDIS_ADDR"); addr != "" {
+			redisConf.Addr = addr
+		}
+
+		// the clients stand for two replicas
+		client1 = NewRedisClient(ctx, &redisConf)
+		client2 = NewRedisClient(ctx, &redisConf)
+
+		key, otherKey, value = "key:"+uuid.NewString(), "key:"+uuid.NewString(), "value"
+	})
+
+	AfterEach(func() {
+		Expect(client1.Close()).NotTo(HaveOccurred())
+		Expect(client2.Close()).NotTo(HaveOccurred())
+	})
+
+	Describe("Publish", func() {
+		It("drops the keys from the local caches of every replica", func() {
+			local1 := client1.InvalidationBus().NewLocalCache(16, time.Minute)
+			local2 := client2.InvalidationBus().NewLocalCache(16, time.Minute)
+
+			local1.Set(key, []byte(value))
+			local2.Set(key, []byte(value))
+			local2.Set(otherKey, []byte(value))
+
+			Expect(client1.InvalidationBus().Publish(ctx, key)).To(Succeed())
+
+			Eventually(func() bool {
+				_, ok := local2.Get(key)
+				return ok
+			}).Should(BeFalse())
+			Eventually(func() bool {
+				_, ok := local1.Get(key)
+				return ok
+			}).Should(BeFalse())
+
+			_, ok := local2.Get(otherKey)
+			Expect(ok).To(BeTrue())
+		})
+	})
+})
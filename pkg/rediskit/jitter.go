@@ -0,0 +1,27 @@
+package rediskit
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+var (
+	jitterMutex sync.Mutex
+	// jitterRand is seeded so the replicas jitter the TTLs differently
+	jitterRand = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+// JitterTTL extends the TTL by a random duration up to the ratio of the TTL, so that the keys cached together
+// do not expire together and hit the underlying storage at once
+func JitterTTL(ttl time.Duration, ratio float64) time.Duration {
+	max := int64(float64(ttl) * ratio)
+	if max <= 0 {
+		return ttl
+	}
+
+	jitterMutex.Lock()
+	defer jitterMutex.Unlock()
+
+	return ttl + time.Duration(jitterRand.Int63n(max+1))
+}
@@ -0,0 +1,32 @@
+package rediskit
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("JitterTTL", func() {
+	When("the ratio is zero", func() {
+		It("returns the TTL", func() {
+			Expect(JitterTTL(time.Minute, 0)).To(Equal(time.Minute))
+		})
+	})
+
+	When("success", func() {
+		It("extends the TTL by up to the ratio of the TTL", func() {
+			ttls := map[time.Duration]struct{}{}
+
+			for i := 0; i < 100; i++ {
+				ttl := JitterTTL(time.Minute, 0.1)
+				Expect(ttl).To(BeNumerically(">=", time.Minute))
+				Expect(ttl).To(BeNumerically("<=", time.Minute+6*time.Second))
+
+				ttls[ttl] = struct{}{}
+			}
+
+			Expect(len(ttls)).To(BeNumerically(">", 1))
+		})
+	})
+})
@@ -0,0 +1,453 @@
+package storagekit
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/logkit"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type FileSystemConfig struct {
+	Root   string `long:"root" env:"ROOT" description:"the root directory of the stored objects" default:"./data"`
+	Bucket string `long:"bucket" env:"BUCKET" description:"the bucket name, which is a sub directory of the root directory" default:"videos"`
+}
+
+// FileSystemStorage stores the objects as files under the bucket directory of the root directory.
+// Files are written into a temporary directory first and renamed into place, so a reader never
+// sees a partially written object. The content type of an object is derived from the extension of its name.
+type FileSystemStorage struct {
+	root       string
+	bucketName string
+}
+
+var _ Storage = (*FileSystemStorage)(nil)
+
+const (
+	// fileSystemTempDir keeps the temporary files, it is on the same file system as the bucket so renaming is atomic
+	fileSystemTempDir = ".tmp"
+	// fileSystemUploadsDir keeps the parts of the multipart uploads, each upload is a sub directory named by the upload ID
+	fileSystemUploadsDir = ".uploads"
+)
+
+// Endpoint returns no endpoint, the objects are files which are not reachable by any host
+func (s *FileSystemStorage) Endpoint() string {
+	return ""
+}
+
+func (s *FileSystemStorage) Bucket() string {
+	return s.bucketName
+}
+
+func (s *FileSystemStorage) PutObject(ctx context.Context, objectName string, reader io.Reader, objectSize int64, opts PutObjectOptions) error {
+	filename, err := s.objectPath(objectName)
+	if err != nil {
+		return err
+	}
+
+	return s.writeFile(filename, func(w io.Writer) error {
+		n, err := io.Copy(w, reader)
+		if err != nil {
+			return err
+		}
+
+		if objectSize >= 0 && n != objectSize {
+			return fmt.Errorf("object size mismatch: expected %d bytes but got %d bytes", objectSize, n)
+		}
+
+		return nil
+	})
+}
+
+func (s *FileSystemStorage) GetObject(ctx context.Context, objectName string, opts GetObjectOptions) (io.ReadCloser, error) {
+	filename, err := s.objectPath(objectName)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, toFileSystemError(err)
+	}
+
+	if opts.Offset != 0 || opts.Length != 0 {
+		info, err := file.Stat()
+		if err != nil {
+			_ = file.Close()
+			return nil, err
+		}
+
+		if opts.Offset >= info.Size() {
+			_ = file.Close()
+			return nil, ErrInvalidRange
+		}
+	}
+
+	if _, err := file.Seek(opts.Offset, io.SeekStart); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	if opts.Length == 0 {
+		return file, nil
+	}
+
+	return &limitedReadCloser{
+		Reader: io.LimitReader(file, opts.Length),
+		Closer: file,
+	}, nil
+}
+
+func (s *FileSystemStorage) StatObject(ctx context.Context, objectName string) (*ObjectInfo, error) {
+	filename, err := s.objectPath(objectName)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.statFile(objectName, filename)
+}
+
+func (s *FileSystemStorage) RemoveObject(ctx context.Context, objectName string) error {
+	filename, err := s.objectPath(objectName)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(filename); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	s.removeEmptyDirs(filepath.Dir(filename))
+
+	return nil
+}
+
+func (s *FileSystemStorage) RemoveObjects(ctx context.Context, objectNames []string) error {
+	for _, objectName := range objectNames {
+		if err := s.RemoveObject(ctx, objectName); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *FileSystemStorage) ListObjects(ctx context.Context, opts ListObjectsOptions) ([]*ObjectInfo, error) {
+	bucketDir := s.bucketDir()
+
+	objects := make([]*ObjectInfo, 0)
+	prefixes := make(map[string]struct{})
+
+	if err := filepath.WalkDir(bucketDir, func(filename string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(bucketDir, filename)
+		if err != nil {
+			return err
+		}
+
+		objectName := filepath.ToSlash(rel)
+		if !strings.HasPrefix(objectName, opts.Prefix) {
+			return nil
+		}
+
+		// the objects in nested "directories" are grouped into a common prefix like S3 does
+		if !opts.Recursive {
+			if i := strings.Index(objectName[len(opts.Prefix):], "/"); i >= 0 {
+				prefixes[objectName[:len(opts.Prefix)+i+1]] = struct{}{}
+				return nil
+			}
+		}
+
+		info, err := s.statFile(objectName, filename)
+		if err != nil {
+			return err
+		}
+
+		objects = append(objects, info)
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	for prefix := range prefixes {
+		objects = append(objects, &ObjectInfo{Name: prefix})
+	}
+
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Name < objects[j].Name })
+
+	return objects, nil
+}
+
+func (s *FileSystemStorage) PresignedGetObject(ctx context.Context, objectName string) (*PresignedURL, error) {
+	return nil, ErrPresignNotSupported
+}
+
+func (s *FileSystemStorage) PresignedPutObject(ctx context.Context, objectName string) (*PresignedURL, error) {
+	return nil, ErrPresignNotSupported
+}
+
+func (s *FileSystemStorage) NewMultipartUpload(ctx context.Context, objectName string, opts PutObjectOptions) (string, error) {
+	if _, err := s.objectPath(objectName); err != nil {
+		return "", err
+	}
+
+	uploadID := uuid.NewString()
+
+	if err := os.MkdirAll(s.uploadDir(uploadID), 0o755); err != nil {
+		return "", err
+	}
+
+	return uploadID, nil
+}
+
+func (s *FileSystemStorage) PutObjectPart(ctx context.Context, objectName string, uploadID string, partNumber int, reader io.Reader, partSize int64) (*ObjectPart, error) {
+	uploadDir, err := s.existingUploadDir(uploadID)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := md5.New()
+
+	if err := s.writeFile(filepath.Join(uploadDir, partFilename(partNumber)), func(w io.Writer) error {
+		n, err := io.Copy(io.MultiWriter(w, hash), reader)
+		if err != nil {
+			return err
+		}
+
+		if n != partSize {
+			return fmt.Errorf("part size mismatch: expected %d bytes but got %d bytes", partSize, n)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return &ObjectPart{
+		PartNumber: partNumber,
+		ETag:       hex.EncodeToString(hash.Sum(nil)),
+		Size:       partSize,
+	}, nil
+}
+
+func (s *FileSystemStorage) CompleteMultipartUpload(ctx context.Context, objectName string, uploadID string, parts []*ObjectPart) error {
+	filename, err := s.objectPath(objectName)
+	if err != nil {
+		return err
+	}
+
+	uploadDir, err := s.existingUploadDir(uploadID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.writeFile(filename, func(w io.Writer) error {
+		for _, part := range parts {
+			if err := copyFile(w, filepath.Join(uploadDir, partFilename(part.PartNumber))); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	return os.RemoveAll(uploadDir)
+}
+
+func (s *FileSystemStorage) AbortMultipartUpload(ctx context.Context, objectName string, uploadID string) error {
+	uploadDir, err := s.existingUploadDir(uploadID)
+	if err != nil {
+		return err
+	}
+
+	return os.RemoveAll(uploadDir)
+}
+
+// writeFile writes the file through a temporary file, which is renamed to the filename only if write succeeds
+func (s *FileSystemStorage) writeFile(filename string, write func(w io.Writer) error) (err error) {
+	tempDir := filepath.Join(s.root, fileSystemTempDir)
+	if err := os.MkdirAll(tempDir, 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(tempDir, "object-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = file.Close()
+			_ = os.Remove(file.Name())
+		}
+	}()
+
+	if err := write(file); err != nil {
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), filename)
+}
+
+func (s *FileSystemStorage) statFile(objectName, filename string) (*ObjectInfo, error) {
+	info, err := os.Stat(filename)
+	if err != nil {
+		return nil, toFileSystemError(err)
+	}
+
+	if info.IsDir() {
+		return nil, ErrObjectNotFound
+	}
+
+	return &ObjectInfo{
+		Name:         objectName,
+		Size:         info.Size(),
+		ETag:         fmt.Sprintf("%x-%x", info.ModTime().UnixNano(), info.Size()),
+		ContentType:  contentTypeByName(objectName),
+		LastModified: info.ModTime().UTC(),
+	}, nil
+}
+
+// removeEmptyDirs removes the empty directories from dir up to the bucket directory
+func (s *FileSystemStorage) removeEmptyDirs(dir string) {
+	bucketDir := s.bucketDir()
+
+	for dir != bucketDir && strings.HasPrefix(dir, bucketDir) {
+		if err := os.Remove(dir); err != nil {
+			return
+		}
+
+		dir = filepath.Dir(dir)
+	}
+}
+
+func (s *FileSystemStorage) bucketDir() string {
+	return filepath.Join(s.root, s.bucketName)
+}
+
+// objectPath returns the file path of the object, object names escaping the bucket directory are rejected
+func (s *FileSystemStorage) objectPath(objectName string) (string, error) {
+	cleaned := path.Clean("/" + objectName)
+	if objectName == "" || strings.HasSuffix(objectName, "/") || cleaned[1:] != objectName {
+		return "", ErrInvalidObjectName
+	}
+
+	return filepath.Join(s.bucketDir(), filepath.FromSlash(objectName)), nil
+}
+
+func (s *FileSystemStorage) uploadDir(uploadID string) string {
+	return filepath.Join(s.root, fileSystemUploadsDir, uploadID)
+}
+
+func (s *FileSystemStorage) existingUploadDir(uploadID string) (string, error) {
+	if _, err := uuid.Parse(uploadID); err != nil {
+		return "", ErrUploadNotFound
+	}
+
+	uploadDir := s.uploadDir(uploadID)
+	if _, err := os.Stat(uploadDir); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return "", ErrUploadNotFound
+		}
+		return "", err
+	}
+
+	return uploadDir, nil
+}
+
+func NewFileSystemStorage(ctx context.Context, conf *FileSystemConfig) *FileSystemStorage {
+	logger := logkit.FromContext(ctx).
+		With(zap.String("root", conf.Root)).
+		With(zap.String("bucket", conf.Bucket))
+
+	root, err := filepath.Abs(conf.Root)
+	if err != nil {
+		logger.Fatal("failed to resolve root directory", zap.Error(err))
+	}
+
+	if conf.Bucket == "" || strings.HasPrefix(conf.Bucket, ".") || strings.ContainsAny(conf.Bucket, `/\`) {
+		logger.Fatal("invalid bucket name")
+	}
+
+	if err := os.MkdirAll(filepath.Join(root, conf.Bucket), 0o755); err != nil {
+		logger.Fatal("failed to create bucket directory", zap.Error(err))
+	}
+
+	logger.Info("create file system storage successfully")
+
+	return &FileSystemStorage{
+		root:       root,
+		bucketName: conf.Bucket,
+	}
+}
+
+type limitedReadCloser struct {
+	io.Reader
+	io.Closer
+}
+
+func partFilename(partNumber int) string {
+	return fmt.Sprintf("%05d", partNumber)
+}
+
+func copyFile(w io.Writer, filename string) error {
+	file, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(w, file); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func contentTypeByName(objectName string) string {
+	if contentType := mime.TypeByExtension(path.Ext(objectName)); contentType != "" {
+		return contentType
+	}
+
+	return "application/octet-stream"
+}
+
+func toFileSystemError(err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrObjectNotFound
+	}
+
+	return err
+}
@@ -0,0 +1,64 @@
+package storagekit
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/logkit"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FileSystemStorage", func() {
+	describeStorage(func(ctx context.Context) Storage {
+		ctx = logkit.WithContext(ctx, logkit.NewNopLogger())
+
+		return NewFileSystemStorage(ctx, &FileSystemConfig{Root: GinkgoT().TempDir(), Bucket: "videos"})
+	})
+
+	Describe("objects on disk", func() {
+		var (
+			ctx     context.Context
+			root    string
+			storage *FileSystemStorage
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			root = GinkgoT().TempDir()
+			storage = NewFileSystemStorage(logkit.WithContext(ctx, logkit.NewNopLogger()), &FileSystemConfig{Root: root, Bucket: "videos"})
+		})
+
+		When("object name escapes the bucket", func() {
+			It("returns invalid object name error", func() {
+				Expect(storage.PutObject(ctx, "../secret", strings.NewReader("x"), 1, PutObjectOptions{})).
+					To(MatchError(ErrInvalidObjectName))
+			})
+		})
+
+		When("object is written", func() {
+			It("stores the file under the bucket directory and leaves no temporary file", func() {
+				putObject(ctx, storage, "a/video.mp4", "video content")
+
+				data, err := os.ReadFile(filepath.Join(root, "videos", "a", "video.mp4"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(data)).To(Equal("video content"))
+
+				Expect(os.ReadDir(filepath.Join(root, fileSystemTempDir))).To(BeEmpty())
+			})
+		})
+
+		When("object is removed", func() {
+			It("removes the empty parent directories", func() {
+				putObject(ctx, storage, "a/b/video.mp4", "video content")
+
+				Expect(storage.RemoveObject(ctx, "a/b/video.mp4")).To(Succeed())
+
+				Expect(filepath.Join(root, "videos", "a")).NotTo(BeADirectory())
+				Expect(filepath.Join(root, "videos")).To(BeADirectory())
+			})
+		})
+	})
+})
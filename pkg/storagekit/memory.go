@@ -0,0 +1,298 @@
+package storagekit
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/logkit"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type MemoryConfig struct {
+	Bucket string `long:"bucket" env:"BUCKET" description:"the bucket name" default:"videos"`
+}
+
+// MemoryStorage keeps the objects in memory, which is useful for local development and testing
+type MemoryStorage struct {
+	bucketName string
+
+	mu      sync.RWMutex
+	objects map[string]*memoryObject
+	uploads map[string]*memoryUpload
+}
+
+type memoryObject struct {
+	data         []byte
+	etag         string
+	contentType  string
+	lastModified time.Time
+}
+
+type memoryUpload struct {
+	objectName  string
+	contentType string
+	parts       map[int][]byte
+}
+
+var _ Storage = (*MemoryStorage)(nil)
+
+// memoryEndpoint is the endpoint of the in-memory storage, the objects are not reachable from outside the process
+const memoryEndpoint = "memory"
+
+func (s *MemoryStorage) Endpoint() string {
+	return memoryEndpoint
+}
+
+func (s *MemoryStorage) Bucket() string {
+	return s.bucketName
+}
+
+func (s *MemoryStorage) PutObject(ctx context.Context, objectName string, reader io.Reader, objectSize int64, opts PutObjectOptions) error {
+	if objectName == "" {
+		return ErrInvalidObjectName
+	}
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+
+	if objectSize >= 0 && int64(len(data)) != objectSize {
+		return fmt.Errorf("object size mismatch: expected %d bytes but got %d bytes", objectSize, len(data))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.objects[objectName] = newMemoryObject(objectName, data, opts.ContentType)
+
+	return nil
+}
+
+func (s *MemoryStorage) GetObject(ctx context.Context, objectName string, opts GetObjectOptions) (io.ReadCloser, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	object, ok := s.objects[objectName]
+	if !ok {
+		return nil, ErrObjectNotFound
+	}
+
+	data := object.data
+	if (opts.Offset != 0 || opts.Length != 0) && opts.Offset >= int64(len(data)) {
+		return nil, ErrInvalidRange
+	}
+
+	data = data[opts.Offset:]
+	if opts.Length > 0 && opts.Length < int64(len(data)) {
+		data = data[:opts.Length]
+	}
+
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *MemoryStorage) StatObject(ctx context.Context, objectName string) (*ObjectInfo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	object, ok := s.objects[objectName]
+	if !ok {
+		return nil, ErrObjectNotFound
+	}
+
+	return object.info(objectName), nil
+}
+
+func (s *MemoryStorage) RemoveObject(ctx context.Context, objectName string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.objects, objectName)
+
+	return nil
+}
+
+func (s *MemoryStorage) RemoveObjects(ctx context.Context, objectNames []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, objectName := range objectNames {
+		delete(s.objects, objectName)
+	}
+
+	return nil
+}
+
+func (s *MemoryStorage) ListObjects(ctx context.Context, opts ListObjectsOptions) ([]*ObjectInfo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	objects := make([]*ObjectInfo, 0)
+	prefixes := make(map[string]struct{})
+
+	for objectName, object := range s.objects {
+		if !strings.HasPrefix(objectName, opts.Prefix) {
+			continue
+		}
+
+		// the objects in nested "directories" are grouped into a common prefix like S3 does
+		if !opts.Recursive {
+			if i := strings.Index(objectName[len(opts.Prefix):], "/"); i >= 0 {
+				prefixes[objectName[:len(opts.Prefix)+i+1]] = struct{}{}
+				continue
+			}
+		}
+
+		objects = append(objects, object.info(objectName))
+	}
+
+	for prefix := range prefixes {
+		objects = append(objects, &ObjectInfo{Name: prefix})
+	}
+
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Name < objects[j].Name })
+
+	return objects, nil
+}
+
+func (s *MemoryStorage) PresignedGetObject(ctx context.Context, objectName string) (*PresignedURL, error) {
+	return nil, ErrPresignNotSupported
+}
+
+func (s *MemoryStorage) PresignedPutObject(ctx context.Context, objectName string) (*PresignedURL, error) {
+	return nil, ErrPresignNotSupported
+}
+
+func (s *MemoryStorage) NewMultipartUpload(ctx context.Context, objectName string, opts PutObjectOptions) (string, error) {
+	if objectName == "" {
+		return "", ErrInvalidObjectName
+	}
+
+	uploadID := uuid.NewString()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.uploads[uploadID] = &memoryUpload{
+		objectName:  objectName,
+		contentType: opts.ContentType,
+		parts:       make(map[int][]byte),
+	}
+
+	return uploadID, nil
+}
+
+func (s *MemoryStorage) PutObjectPart(ctx context.Context, objectName string, uploadID string, partNumber int, reader io.Reader, partSize int64) (*ObjectPart, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(data)) != partSize {
+		return nil, fmt.Errorf("part size mismatch: expected %d bytes but got %d bytes", partSize, len(data))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	upload, ok := s.uploads[uploadID]
+	if !ok || upload.objectName != objectName {
+		return nil, ErrUploadNotFound
+	}
+
+	upload.parts[partNumber] = data
+
+	return &ObjectPart{
+		PartNumber: partNumber,
+		ETag:       md5Hex(data),
+		Size:       partSize,
+	}, nil
+}
+
+func (s *MemoryStorage) CompleteMultipartUpload(ctx context.Context, objectName string, uploadID string, parts []*ObjectPart) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	upload, ok := s.uploads[uploadID]
+	if !ok || upload.objectName != objectName {
+		return ErrUploadNotFound
+	}
+
+	var buf bytes.Buffer
+	for _, part := range parts {
+		data, ok := upload.parts[part.PartNumber]
+		if !ok {
+			return fmt.Errorf("part %d is not uploaded", part.PartNumber)
+		}
+
+		buf.Write(data)
+	}
+
+	s.objects[objectName] = newMemoryObject(objectName, buf.Bytes(), upload.contentType)
+	delete(s.uploads, uploadID)
+
+	return nil
+}
+
+func (s *MemoryStorage) AbortMultipartUpload(ctx context.Context, objectName string, uploadID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	upload, ok := s.uploads[uploadID]
+	if !ok || upload.objectName != objectName {
+		return ErrUploadNotFound
+	}
+
+	delete(s.uploads, uploadID)
+
+	return nil
+}
+
+func NewMemoryStorage(ctx context.Context, conf *MemoryConfig) *MemoryStorage {
+	logger := logkit.FromContext(ctx).With(zap.String("bucket", conf.Bucket))
+
+	logger.Info("create in-memory storage successfully")
+
+	return &MemoryStorage{
+		bucketName: conf.Bucket,
+		objects:    make(map[string]*memoryObject),
+		uploads:    make(map[string]*memoryUpload),
+	}
+}
+
+func newMemoryObject(objectName string, data []byte, contentType string) *memoryObject {
+	if contentType == "" {
+		contentType = contentTypeByName(objectName)
+	}
+
+	return &memoryObject{
+		data:         data,
+		etag:         md5Hex(data),
+		contentType:  contentType,
+		lastModified: time.Now().UTC(),
+	}
+}
+
+func (o *memoryObject) info(objectName string) *ObjectInfo {
+	return &ObjectInfo{
+		Name:         objectName,
+		Size:         int64(len(o.data)),
+		ETag:         o.etag,
+		ContentType:  o.contentType,
+		LastModified: o.lastModified,
+	}
+}
+
+func md5Hex(data []byte) string {
+	hash := md5.Sum(data)
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,16 @@
+package storagekit
+
+import (
+	"context"
+
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/logkit"
+	. "github.com/onsi/ginkgo/v2"
+)
+
+var _ = Describe("MemoryStorage", func() {
+	describeStorage(func(ctx context.Context) Storage {
+		ctx = logkit.WithContext(ctx, logkit.NewNopLogger())
+
+		return NewMemoryStorage(ctx, &MemoryConfig{Bucket: "videos"})
+	})
+})
@@ -2,8 +2,11 @@ package storagekit
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"net/url"
+	"time"
 
 	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/logkit"
 	"github.com/minio/minio-go/v7"
@@ -12,32 +15,60 @@ import (
 )
 
 type MinIOConfig struct {
-	Endpoint string `long:"endpoint" env:"ENDPOINT" description:"the endpoint of MinIO server" required:"true"`
-	Bucket   string `long:"bucket" env:"BUCKET" description:"the bucket name" required:"true"`
-	Username string `long:"username" env:"USERNAME" description:"the access key id (username) to the MinIO server" required:"true"`
-	Password string `long:"password" env:"PASSWORD" description:"the secret access key (password) to the MinIO server" required:"true"`
-	Insecure bool   `long:"insecure" env:"INSECURE" description:"disable HTTPS or not"`
-	Policy   string `long:"policy" env:"POLICY" description:"the bucket policy" default:"public"`
+	Endpoint      string        `long:"endpoint" env:"ENDPOINT" description:"the endpoint of MinIO server"`
+	Bucket        string        `long:"bucket" env:"BUCKET" description:"the bucket name"`
+	Username      string        `long:"username" env:"USERNAME" description:"the access key id (username) to the MinIO server"`
+	Password      string        `long:"password" env:"PASSWORD" description:"the secret access key (password) to the MinIO server"`
+	Insecure      bool          `long:"insecure" env:"INSECURE" description:"disable HTTPS or not"`
+	Policy        string        `long:"policy" env:"POLICY" description:"the bucket policy" choice:"public" choice:"private" default:"private"`
+	PresignExpiry time.Duration `long:"presign_expiry" env:"PRESIGN_EXPIRY" description:"the lifetime of the presigned URLs" default:"15m"`
+	PartSize      uint64        `long:"part_size" env:"PART_SIZE" description:"the part size of multipart uploads, which bounds the memory used by each upload" default:"16777216"`
+}
+
+// validate checks the fields required once MinIO is the selected backend, every object is stored in the bucket
+func (conf *MinIOConfig) validate() error {
+	if conf.Endpoint == "" {
+		return errors.New("MinIO endpoint is required")
+	}
+	if conf.Bucket == "" {
+		return errors.New("MinIO bucket is required")
+	}
+	if conf.Username == "" || conf.Password == "" {
+		return errors.New("MinIO username and password are required")
+	}
+
+	return nil
 }
 
 type MinIOClient struct {
 	*minio.Client
-	bucketName string
+	bucketName    string
+	partSize      uint64
+	presignExpiry time.Duration
 }
 
 var _ Storage = (*MinIOClient)(nil)
 
 func (c *MinIOClient) Endpoint() string {
-	return c.Client.EndpointURL().Path
+	return c.Client.EndpointURL().Host
 }
 
 func (c *MinIOClient) Bucket() string {
 	return c.bucketName
 }
 
+// PutObject streams the reader into the bucket. Objects larger than the part size, or with unknown
+// size, are uploaded part by part using multipart upload, so at most one part is buffered in memory.
+// The multipart upload is aborted if the reader returns an error, so no partial object is left.
 func (c *MinIOClient) PutObject(ctx context.Context, objectName string, reader io.Reader, objectSize int64, opts PutObjectOptions) error {
+	partSize := opts.PartSize
+	if partSize == 0 {
+		partSize = c.partSize
+	}
+
 	if _, err := c.Client.PutObject(ctx, c.bucketName, objectName, reader, objectSize, minio.PutObjectOptions{
 		ContentType: opts.ContentType,
+		PartSize:    partSize,
 	}); err != nil {
 		return err
 	}
@@ -45,11 +76,166 @@ func (c *MinIOClient) PutObject(ctx context.Context, objectName string, reader i
 	return nil
 }
 
+func (c *MinIOClient) GetObject(ctx context.Context, objectName string, opts GetObjectOptions) (io.ReadCloser, error) {
+	var o minio.GetObjectOptions
+	if opts.Offset != 0 || opts.Length != 0 {
+		end := int64(0)
+		if opts.Length > 0 {
+			end = opts.Offset + opts.Length - 1
+		}
+
+		if err := o.SetRange(opts.Offset, end); err != nil {
+			return nil, err
+		}
+	}
+
+	object, err := c.Client.GetObject(ctx, c.bucketName, objectName, o)
+	if err != nil {
+		return nil, toStorageError(err)
+	}
+
+	// the object is fetched lazily, stat it to surface the error before the first read
+	if _, err := object.Stat(); err != nil {
+		_ = object.Close()
+		return nil, toStorageError(err)
+	}
+
+	return object, nil
+}
+
+func (c *MinIOClient) StatObject(ctx context.Context, objectName string) (*ObjectInfo, error) {
+	info, err := c.Client.StatObject(ctx, c.bucketName, objectName, minio.StatObjectOptions{})
+	if err != nil {
+		return nil, toStorageError(err)
+	}
+
+	return toObjectInfo(&info), nil
+}
+
+func (c *MinIOClient) RemoveObject(ctx context.Context, objectName string) error {
+	return c.Client.RemoveObject(ctx, c.bucketName, objectName, minio.RemoveObjectOptions{})
+}
+
+func (c *MinIOClient) RemoveObjects(ctx context.Context, objectNames []string) error {
+	objectsCh := make(chan minio.ObjectInfo, len(objectNames))
+	for _, objectName := range objectNames {
+		objectsCh <- minio.ObjectInfo{Key: objectName}
+	}
+	close(objectsCh)
+
+	// drain the error channel so that the removing goroutine can finish
+	var err error
+	for rerr := range c.Client.RemoveObjects(ctx, c.bucketName, objectsCh, minio.RemoveObjectsOptions{}) {
+		if err == nil && rerr.Err != nil {
+			err = rerr.Err
+		}
+	}
+
+	return err
+}
+
+func (c *MinIOClient) ListObjects(ctx context.Context, opts ListObjectsOptions) ([]*ObjectInfo, error) {
+	// cancel the listing goroutine if returning early
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	objects := make([]*ObjectInfo, 0)
+
+	for object := range c.Client.ListObjects(ctx, c.bucketName, minio.ListObjectsOptions{
+		Prefix:    opts.Prefix,
+		Recursive: opts.Recursive,
+	}) {
+		if object.Err != nil {
+			return nil, object.Err
+		}
+
+		object := object
+		objects = append(objects, toObjectInfo(&object))
+	}
+
+	return objects, nil
+}
+
+func (c *MinIOClient) PresignedGetObject(ctx context.Context, objectName string) (*PresignedURL, error) {
+	expiresAt := time.Now().Add(c.presignExpiry)
+
+	u, err := c.Client.PresignedGetObject(ctx, c.bucketName, objectName, c.presignExpiry, url.Values{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &PresignedURL{URL: u.String(), ExpiresAt: expiresAt}, nil
+}
+
+func (c *MinIOClient) PresignedPutObject(ctx context.Context, objectName string) (*PresignedURL, error) {
+	expiresAt := time.Now().Add(c.presignExpiry)
+
+	u, err := c.Client.PresignedPutObject(ctx, c.bucketName, objectName, c.presignExpiry)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PresignedURL{URL: u.String(), ExpiresAt: expiresAt}, nil
+}
+
+func (c *MinIOClient) NewMultipartUpload(ctx context.Context, objectName string, opts PutObjectOptions) (string, error) {
+	return c.core().NewMultipartUpload(ctx, c.bucketName, objectName, minio.PutObjectOptions{
+		ContentType: opts.ContentType,
+	})
+}
+
+func (c *MinIOClient) PutObjectPart(ctx context.Context, objectName string, uploadID string, partNumber int, reader io.Reader, partSize int64) (*ObjectPart, error) {
+	part, err := c.core().PutObjectPart(ctx, c.bucketName, objectName, uploadID, partNumber, reader, partSize, "", "", nil)
+	if err != nil {
+		return nil, toStorageError(err)
+	}
+
+	return &ObjectPart{
+		PartNumber: part.PartNumber,
+		ETag:       part.ETag,
+		Size:       part.Size,
+	}, nil
+}
+
+func (c *MinIOClient) CompleteMultipartUpload(ctx context.Context, objectName string, uploadID string, parts []*ObjectPart) error {
+	completeParts := make([]minio.CompletePart, 0, len(parts))
+	for _, part := range parts {
+		completeParts = append(completeParts, minio.CompletePart{
+			PartNumber: part.PartNumber,
+			ETag:       part.ETag,
+		})
+	}
+
+	if _, err := c.core().CompleteMultipartUpload(ctx, c.bucketName, objectName, uploadID, completeParts, minio.PutObjectOptions{}); err != nil {
+		return toStorageError(err)
+	}
+
+	return nil
+}
+
+func (c *MinIOClient) AbortMultipartUpload(ctx context.Context, objectName string, uploadID string) error {
+	if err := c.core().AbortMultipartUpload(ctx, c.bucketName, objectName, uploadID); err != nil {
+		return toStorageError(err)
+	}
+
+	return nil
+}
+
+// core exposes the low-level S3 APIs of the MinIO client
+func (c *MinIOClient) core() *minio.Core {
+	return &minio.Core{Client: c.Client}
+}
+
 func NewMinIOClient(ctx context.Context, conf *MinIOConfig) *MinIOClient {
 	logger := logkit.FromContext(ctx).
 		With(zap.String("endpoint", conf.Endpoint)).
 		With(zap.String("bucket", conf.Bucket))
 
+	// the flags are not required since MinIO is not the only storage backend
+	if err := conf.validate(); err != nil {
+		logger.Fatal("invalid MinIO config", zap.Error(err))
+	}
+
 	client, err := minio.New(conf.Endpoint, &minio.Options{
 		Creds:  credentials.NewStaticV4(conf.Username, conf.Password, ""),
 		Secure: !conf.Insecure,
@@ -58,36 +244,60 @@ func NewMinIOClient(ctx context.Context, conf *MinIOConfig) *MinIOClient {
 		logger.Fatal("failed to create MinIO client", zap.Error(err))
 	}
 
-	if conf.Bucket != "" {
-		ok, err := client.BucketExists(ctx, conf.Bucket)
-		if err != nil {
-			logger.Fatal("failed to check bucket existence", zap.Error(err))
-		}
-
-		if !ok {
-			if err := client.MakeBucket(ctx, conf.Bucket, minio.MakeBucketOptions{}); err != nil {
-				logger.Fatal("failed to create bucket", zap.Error(err))
-			}
+	ok, err := client.BucketExists(ctx, conf.Bucket)
+	if err != nil {
+		logger.Fatal("failed to check bucket existence", zap.Error(err))
+	}
 
-			if policy := generatePolicy(conf.Bucket, conf.Policy); policy != "" {
-				if err := client.SetBucketPolicy(ctx, conf.Bucket, policy); err != nil {
-					logger.Fatal("failed to set bucket policy")
-				}
-			}
+	if !ok {
+		if err := client.MakeBucket(ctx, conf.Bucket, minio.MakeBucketOptions{}); err != nil {
+			logger.Fatal("failed to create bucket", zap.Error(err))
 		}
 	}
 
+	// the policy is set on every start, so an existing bucket is reconciled with the configured policy,
+	// e.g. a bucket created public before the videos were served by presigned URLs is made private
+	if err := client.SetBucketPolicy(ctx, conf.Bucket, generatePolicy(conf.Bucket, conf.Policy)); err != nil {
+		logger.Fatal("failed to set bucket policy", zap.Error(err))
+	}
+
 	logger.Info("create MinIO client successfully")
 
 	return &MinIOClient{
-		Client:     client,
-		bucketName: conf.Bucket,
+		Client:        client,
+		bucketName:    conf.Bucket,
+		partSize:      conf.PartSize,
+		presignExpiry: conf.PresignExpiry,
 	}
 }
 
+func toObjectInfo(info *minio.ObjectInfo) *ObjectInfo {
+	return &ObjectInfo{
+		Name:         info.Key,
+		Size:         info.Size,
+		ETag:         info.ETag,
+		ContentType:  info.ContentType,
+		LastModified: info.LastModified,
+	}
+}
+
+func toStorageError(err error) error {
+	switch minio.ToErrorResponse(err).Code {
+	case "NoSuchKey":
+		return ErrObjectNotFound
+	case "NoSuchUpload":
+		return ErrUploadNotFound
+	case "InvalidRange":
+		return ErrInvalidRange
+	}
+
+	return err
+}
+
 func generatePolicy(bucketName string, policy string) string {
 	switch policy {
 	case "public":
+		// anonymous users may only read the objects, listing and writing the bucket need the credentials
 		return fmt.Sprintf(`
 			{
 				"Version":"2012-10-17",
@@ -95,26 +305,14 @@ func generatePolicy(bucketName string, policy string) string {
 					{
 						"Effect": "Allow",
 						"Principal": {"AWS": ["*"]},
-						"Action": ["s3:GetBucketLocation", "s3:ListBucket", "s3:ListBucketMultipartUploads"],
-						"Resource": ["arn:aws:s3:::%s"]
-					},
-					{
-						"Effect": "Allow",
-						"Principal": {"AWS": ["*"]},
-						"Action": ["s3:AbortMultipartUpload", "s3:DeleteObject", "s3:GetObject", "s3:ListMultipartUploadParts", "s3:PutObject"],
+						"Action": ["s3:GetObject"],
 						"Resource":["arn:aws:s3:::%s/*"]
 					}
 				]
 			}
-		`, bucketName, bucketName)
-	case "private":
-		return `
-			{
-				"Version": "2012-10-17",
-				"Statement": []
-			}
-		`
+		`, bucketName)
 	}
 
+	// an empty policy removes the bucket policy, which leaves the bucket private
 	return ""
 }
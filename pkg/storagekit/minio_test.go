@@ -0,0 +1,214 @@
+package storagekit
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/logkit"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MinIOConfig", func() {
+	Describe("validate", func() {
+		var conf *MinIOConfig
+
+		BeforeEach(func() {
+			conf = &MinIOConfig{
+				Endpoint: "play.min.io",
+				Bucket:   "videos",
+				Username: "username",
+				Password: "password",
+			}
+		})
+
+		When("endpoint is missing", func() {
+			BeforeEach(func() { conf.Endpoint = "" })
+
+			It("returns an error", func() {
+				Expect(conf.validate()).To(MatchError("MinIO endpoint is required"))
+			})
+		})
+
+		When("bucket is missing", func() {
+			BeforeEach(func() { conf.Bucket = "" })
+
+			It("returns an error", func() {
+				Expect(conf.validate()).To(MatchError("MinIO bucket is required"))
+			})
+		})
+
+		When("credentials are missing", func() {
+			BeforeEach(func() { conf.Password = "" })
+
+			It("returns an error", func() {
+				Expect(conf.validate()).To(MatchError("MinIO username and password are required"))
+			})
+		})
+
+		When("all fields are present", func() {
+			It("returns no error", func() {
+				Expect(conf.validate()).To(Succeed())
+			})
+		})
+	})
+})
+
+var _ = Describe("generatePolicy", func() {
+	When("policy is public", func() {
+		It("only allows anonymous users to get the objects", func() {
+			var policy struct {
+				Statement []struct {
+					Action   []string
+					Resource []string
+				}
+			}
+			Expect(json.Unmarshal([]byte(generatePolicy("videos", "public")), &policy)).To(Succeed())
+
+			Expect(policy.Statement).To(HaveLen(1))
+			Expect(policy.Statement[0].Action).To(Equal([]string{"s3:GetObject"}))
+			Expect(policy.Statement[0].Resource).To(Equal([]string{"arn:aws:s3:::videos/*"}))
+		})
+	})
+
+	When("policy is private", func() {
+		It("returns empty policy", func() {
+			Expect(generatePolicy("videos", "private")).To(BeEmpty())
+		})
+	})
+})
+
+var _ = Describe("MinIOClient", func() {
+	var (
+		ctx    context.Context
+		client *MinIOClient
+		prefix string
+	)
+
+	BeforeEach(func() {
+		// the specs run against the MinIO server of the environment as the DAO specs run against Mongo
+		conf := &MinIOConfig{
+			Endpoint: os.Getenv("MINIO_ENDPOINT"),
+			Bucket:   os.Getenv("MINIO_BUCKET"),
+			Username: os.Getenv("MINIO_USERNAME"),
+			Password: os.Getenv("MINIO_PASSWORD"),
+			Policy:   "private",
+			PartSize: 16 << 20,
+		}
+		if conf.Endpoint == "" {
+			Skip("MINIO_ENDPOINT is not set")
+		}
+
+		ctx = logkit.NewNopLogger().WithContext(context.Background())
+		client = NewMinIOClient(ctx, conf)
+
+		// the bucket may be shared, so every spec works under its own prefix
+		prefix = "storagekit-test/" + uuid.NewString() + "/"
+
+		putObject(ctx, client, prefix+"video.mp4", "0123456789")
+		putObject(ctx, client, prefix+"hls/index.m3u8", "#EXTM3U")
+	})
+
+	AfterEach(func() {
+		if client == nil {
+			return
+		}
+
+		objects, err := client.ListObjects(ctx, ListObjectsOptions{Prefix: prefix, Recursive: true})
+		Expect(err).NotTo(HaveOccurred())
+
+		objectNames := make([]string, 0, len(objects))
+		for _, object := range objects {
+			objectNames = append(objectNames, object.Name)
+		}
+		Expect(client.RemoveObjects(ctx, objectNames)).To(Succeed())
+	})
+
+	Describe("GetObject", func() {
+		When("object not found", func() {
+			It("returns object not found error", func() {
+				_, err := client.GetObject(ctx, prefix+"not-found.mp4", GetObjectOptions{})
+				Expect(err).To(MatchError(ErrObjectNotFound))
+			})
+		})
+
+		When("range is given", func() {
+			It("returns the range of the object", func() {
+				Expect(readObject(ctx, client, prefix+"video.mp4", GetObjectOptions{Offset: 2, Length: 3})).To(Equal("234"))
+			})
+		})
+
+		When("range starts at the end of the object", func() {
+			It("returns invalid range error", func() {
+				_, err := client.GetObject(ctx, prefix+"video.mp4", GetObjectOptions{Offset: 10, Length: 1})
+				Expect(err).To(MatchError(ErrInvalidRange))
+			})
+		})
+
+		When("success", func() {
+			It("returns the whole object", func() {
+				Expect(readObject(ctx, client, prefix+"video.mp4", GetObjectOptions{})).To(Equal("0123456789"))
+			})
+		})
+	})
+
+	Describe("StatObject", func() {
+		When("object not found", func() {
+			It("returns object not found error", func() {
+				info, err := client.StatObject(ctx, prefix+"not-found.mp4")
+				Expect(info).To(BeNil())
+				Expect(err).To(MatchError(ErrObjectNotFound))
+			})
+		})
+
+		When("success", func() {
+			It("returns the metadata of the object", func() {
+				info, err := client.StatObject(ctx, prefix+"video.mp4")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(info.Name).To(Equal(prefix + "video.mp4"))
+				Expect(info.Size).To(Equal(int64(10)))
+				Expect(info.ETag).NotTo(BeEmpty())
+				Expect(info.LastModified).NotTo(BeZero())
+			})
+		})
+	})
+
+	Describe("RemoveObject", func() {
+		When("success", func() {
+			It("removes the object", func() {
+				Expect(client.RemoveObject(ctx, prefix+"video.mp4")).To(Succeed())
+
+				_, err := client.StatObject(ctx, prefix+"video.mp4")
+				Expect(err).To(MatchError(ErrObjectNotFound))
+			})
+		})
+	})
+
+	Describe("RemoveObjects", func() {
+		When("success", func() {
+			It("removes only the objects and ignores nonexistent ones", func() {
+				Expect(client.RemoveObjects(ctx, []string{prefix + "video.mp4", prefix + "not-found.mp4"})).To(Succeed())
+				Expect(listObjectNames(ctx, client, ListObjectsOptions{Prefix: prefix, Recursive: true})).
+					To(Equal([]string{prefix + "hls/index.m3u8"}))
+			})
+		})
+	})
+
+	Describe("ListObjects", func() {
+		When("not recursive", func() {
+			It("groups the nested objects into prefixes", func() {
+				Expect(listObjectNames(ctx, client, ListObjectsOptions{Prefix: prefix})).
+					To(Equal([]string{prefix + "hls/", prefix + "video.mp4"}))
+			})
+		})
+
+		When("recursive", func() {
+			It("lists all the nested objects", func() {
+				Expect(listObjectNames(ctx, client, ListObjectsOptions{Prefix: prefix, Recursive: true})).
+					To(Equal([]string{prefix + "hls/index.m3u8", prefix + "video.mp4"}))
+			})
+		})
+	})
+})
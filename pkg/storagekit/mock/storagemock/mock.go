@@ -36,6 +36,20 @@ func (m *MockStorage) EXPECT() *MockStorageMockRecorder {
 	return m.recorder
 }
 
+// AbortMultipartUpload mocks base method.
+func (m *MockStorage) AbortMultipartUpload(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AbortMultipartUpload", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AbortMultipartUpload indicates an expected call of AbortMultipartUpload.
+func (mr *MockStorageMockRecorder) AbortMultipartUpload(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AbortMultipartUpload", reflect.TypeOf((*MockStorage)(nil).AbortMultipartUpload), arg0, arg1, arg2)
+}
+
 // Bucket mocks base method.
 func (m *MockStorage) Bucket() string {
 	m.ctrl.T.Helper()
@@ -50,6 +64,20 @@ func (mr *MockStorageMockRecorder) Bucket() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bucket", reflect.TypeOf((*MockStorage)(nil).Bucket))
 }
 
+// CompleteMultipartUpload mocks base method.
+func (m *MockStorage) CompleteMultipartUpload(arg0 context.Context, arg1, arg2 string, arg3 []*storagekit.ObjectPart) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteMultipartUpload", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteMultipartUpload indicates an expected call of CompleteMultipartUpload.
+func (mr *MockStorageMockRecorder) CompleteMultipartUpload(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteMultipartUpload", reflect.TypeOf((*MockStorage)(nil).CompleteMultipartUpload), arg0, arg1, arg2, arg3)
+}
+
 // Endpoint mocks base method.
 func (m *MockStorage) Endpoint() string {
 	m.ctrl.T.Helper()
@@ -64,6 +92,81 @@ func (mr *MockStorageMockRecorder) Endpoint() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Endpoint", reflect.TypeOf((*MockStorage)(nil).Endpoint))
 }
 
+// GetObject mocks base method.
+func (m *MockStorage) GetObject(arg0 context.Context, arg1 string, arg2 storagekit.GetObjectOptions) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObject", arg0, arg1, arg2)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetObject indicates an expected call of GetObject.
+func (mr *MockStorageMockRecorder) GetObject(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObject", reflect.TypeOf((*MockStorage)(nil).GetObject), arg0, arg1, arg2)
+}
+
+// ListObjects mocks base method.
+func (m *MockStorage) ListObjects(arg0 context.Context, arg1 storagekit.ListObjectsOptions) ([]*storagekit.ObjectInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListObjects", arg0, arg1)
+	ret0, _ := ret[0].([]*storagekit.ObjectInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListObjects indicates an expected call of ListObjects.
+func (mr *MockStorageMockRecorder) ListObjects(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListObjects", reflect.TypeOf((*MockStorage)(nil).ListObjects), arg0, arg1)
+}
+
+// NewMultipartUpload mocks base method.
+func (m *MockStorage) NewMultipartUpload(arg0 context.Context, arg1 string, arg2 storagekit.PutObjectOptions) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewMultipartUpload", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewMultipartUpload indicates an expected call of NewMultipartUpload.
+func (mr *MockStorageMockRecorder) NewMultipartUpload(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewMultipartUpload", reflect.TypeOf((*MockStorage)(nil).NewMultipartUpload), arg0, arg1, arg2)
+}
+
+// PresignedGetObject mocks base method.
+func (m *MockStorage) PresignedGetObject(arg0 context.Context, arg1 string) (*storagekit.PresignedURL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PresignedGetObject", arg0, arg1)
+	ret0, _ := ret[0].(*storagekit.PresignedURL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PresignedGetObject indicates an expected call of PresignedGetObject.
+func (mr *MockStorageMockRecorder) PresignedGetObject(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PresignedGetObject", reflect.TypeOf((*MockStorage)(nil).PresignedGetObject), arg0, arg1)
+}
+
+// PresignedPutObject mocks base method.
+func (m *MockStorage) PresignedPutObject(arg0 context.Context, arg1 string) (*storagekit.PresignedURL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PresignedPutObject", arg0, arg1)
+	ret0, _ := ret[0].(*storagekit.PresignedURL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PresignedPutObject indicates an expected call of PresignedPutObject.
+func (mr *MockStorageMockRecorder) PresignedPutObject(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PresignedPutObject", reflect.TypeOf((*MockStorage)(nil).PresignedPutObject), arg0, arg1)
+}
+
 // PutObject mocks base method.
 func (m *MockStorage) PutObject(arg0 context.Context, arg1 string, arg2 io.Reader, arg3 int64, arg4 storagekit.PutObjectOptions) error {
 	m.ctrl.T.Helper()
@@ -77,3 +180,61 @@ func (mr *MockStorageMockRecorder) PutObject(arg0, arg1, arg2, arg3, arg4 interf
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutObject", reflect.TypeOf((*MockStorage)(nil).PutObject), arg0, arg1, arg2, arg3, arg4)
 }
+
+// PutObjectPart mocks base method.
+func (m *MockStorage) PutObjectPart(arg0 context.Context, arg1, arg2 string, arg3 int, arg4 io.Reader, arg5 int64) (*storagekit.ObjectPart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutObjectPart", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(*storagekit.ObjectPart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutObjectPart indicates an expected call of PutObjectPart.
+func (mr *MockStorageMockRecorder) PutObjectPart(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutObjectPart", reflect.TypeOf((*MockStorage)(nil).PutObjectPart), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// RemoveObject mocks base method.
+func (m *MockStorage) RemoveObject(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveObject", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveObject indicates an expected call of RemoveObject.
+func (mr *MockStorageMockRecorder) RemoveObject(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveObject", reflect.TypeOf((*MockStorage)(nil).RemoveObject), arg0, arg1)
+}
+
+// RemoveObjects mocks base method.
+func (m *MockStorage) RemoveObjects(arg0 context.Context, arg1 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveObjects", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveObjects indicates an expected call of RemoveObjects.
+func (mr *MockStorageMockRecorder) RemoveObjects(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveObjects", reflect.TypeOf((*MockStorage)(nil).RemoveObjects), arg0, arg1)
+}
+
+// StatObject mocks base method.
+func (m *MockStorage) StatObject(arg0 context.Context, arg1 string) (*storagekit.ObjectInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StatObject", arg0, arg1)
+	ret0, _ := ret[0].(*storagekit.ObjectInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StatObject indicates an expected call of StatObject.
+func (mr *MockStorageMockRecorder) StatObject(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StatObject", reflect.TypeOf((*MockStorage)(nil).StatObject), arg0, arg1)
+}
@@ -2,20 +2,120 @@ package storagekit
 
 import (
 	"context"
+	"errors"
 	"io"
+	"time"
 )
 
 type PutObjectOptions struct {
 	ContentType string
+	// PartSize is the size of each part when the object is uploaded by multipart upload,
+	// the storage implementation decides the default value if it is zero.
+	PartSize uint64
+}
+
+// GetObjectOptions selects the byte range [Offset, Offset+Length) of the object,
+// the range ends at the end of the object if Length is zero or the range exceeds the object.
+// As MinIO responds to a range starting at or beyond the end of the object, ErrInvalidRange is returned for it.
+type GetObjectOptions struct {
+	Offset int64
+	Length int64
+}
+
+type ListObjectsOptions struct {
+	// Prefix limits the listing to the objects whose name begins with the prefix
+	Prefix string
+	// Recursive lists the objects under all nested "directories" of the prefix
+	Recursive bool
+}
+
+// ObjectInfo is the metadata of a stored object
+type ObjectInfo struct {
+	Name         string
+	Size         int64
+	ETag         string
+	ContentType  string
+	LastModified time.Time
+}
+
+// PresignedURL is a URL that grants access to an object without credentials until it expires
+type PresignedURL struct {
+	URL       string
+	ExpiresAt time.Time
+}
+
+var (
+	ErrObjectNotFound      = errors.New("object not found")
+	ErrUploadNotFound      = errors.New("multipart upload not found")
+	ErrInvalidRange        = errors.New("invalid range, the range starts at or beyond the end of the object")
+	ErrInvalidObjectName   = errors.New("invalid object name")
+	ErrPresignNotSupported = errors.New("presigned URL is not supported by the storage")
+	ErrBaseURLRequired     = errors.New("base URL is required to serve the objects of the storage without an endpoint")
+)
+
+// ObjectPart is an uploaded part of a multipart upload
+type ObjectPart struct {
+	PartNumber int
+	ETag       string
+	Size       int64
 }
 
 // Provide a simplifier interface to upload file
 type Storage interface {
-	// Endpoint returns the endpoint of the object storage
+	// Endpoint returns the endpoint (host) of the object storage, which is empty if the objects are not served by a host
 	Endpoint() string
 	// Bucket returns the bucket name in the object storage
 	Bucket() string
 
-	// PutObject add an object into the storage bucket
+	// PutObject add an object into the storage bucket,
+	// objectSize can be -1 if the size of the reader is unknown.
 	PutObject(ctx context.Context, objectName string, reader io.Reader, objectSize int64, opts PutObjectOptions) error
+	// GetObject returns a reader of the object content, the caller must close the reader
+	GetObject(ctx context.Context, objectName string, opts GetObjectOptions) (io.ReadCloser, error)
+	// StatObject returns the metadata of the object
+	StatObject(ctx context.Context, objectName string) (*ObjectInfo, error)
+	// RemoveObject removes the object, removing a nonexistent object is not an error
+	RemoveObject(ctx context.Context, objectName string) error
+	// RemoveObjects removes the objects in batch
+	RemoveObjects(ctx context.Context, objectNames []string) error
+	// ListObjects returns the metadata of the objects in the bucket
+	ListObjects(ctx context.Context, opts ListObjectsOptions) ([]*ObjectInfo, error)
+
+	// PresignedGetObject returns a time-limited URL to download the object,
+	// it returns ErrPresignNotSupported if the storage cannot be accessed by URL.
+	PresignedGetObject(ctx context.Context, objectName string) (*PresignedURL, error)
+	// PresignedPutObject returns a time-limited URL to upload the object with a HTTP PUT request,
+	// it returns ErrPresignNotSupported if the storage cannot be accessed by URL.
+	PresignedPutObject(ctx context.Context, objectName string) (*PresignedURL, error)
+
+	// NewMultipartUpload initiates a multipart upload of the object and returns the upload ID
+	NewMultipartUpload(ctx context.Context, objectName string, opts PutObjectOptions) (string, error)
+	// PutObjectPart uploads a part of the multipart upload, partSize must be the exact size of the reader
+	PutObjectPart(ctx context.Context, objectName string, uploadID string, partNumber int, reader io.Reader, partSize int64) (*ObjectPart, error)
+	// CompleteMultipartUpload concatenates the uploaded parts into the object
+	CompleteMultipartUpload(ctx context.Context, objectName string, uploadID string, parts []*ObjectPart) error
+	// AbortMultipartUpload aborts the multipart upload and removes the uploaded parts
+	AbortMultipartUpload(ctx context.Context, objectName string, uploadID string) error
+}
+
+const (
+	BackendMinIO      = "minio"
+	BackendFileSystem = "filesystem"
+	BackendMemory     = "memory"
+)
+
+type StorageConfig struct {
+	Backend string `long:"backend" env:"BACKEND" description:"the storage backend" choice:"minio" choice:"filesystem" choice:"memory" default:"minio"`
+}
+
+// NewStorage creates the storage of the configured backend with the config of that backend
+func NewStorage(ctx context.Context, conf *StorageConfig, minioConf *MinIOConfig, fileSystemConf *FileSystemConfig, memoryConf *MemoryConfig) Storage {
+	switch conf.Backend {
+	case BackendFileSystem:
+		return NewFileSystemStorage(ctx, fileSystemConf)
+	case BackendMemory:
+		return NewMemoryStorage(ctx, memoryConf)
+	}
+
+	return NewMinIOClient(ctx, minioConf)
 }
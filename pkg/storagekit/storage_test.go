@@ -0,0 +1,365 @@
+package storagekit
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// describeStorage describes the behaviors shared by the in-process storage backends
+func describeStorage(newStorage func(ctx context.Context) Storage) {
+	var (
+		ctx     context.Context
+		storage Storage
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		storage = newStorage(ctx)
+	})
+
+	Describe("PutObject", func() {
+		var (
+			objectName string
+			content    string
+			size       int64
+
+			err error
+		)
+
+		BeforeEach(func() {
+			objectName = "video.mp4"
+			content = "video content"
+			size = int64(len(content))
+		})
+
+		JustBeforeEach(func() {
+			err = storage.PutObject(ctx, objectName, strings.NewReader(content), size, PutObjectOptions{ContentType: "video/mp4"})
+		})
+
+		When("object name is invalid", func() {
+			BeforeEach(func() { objectName = "" })
+
+			It("returns invalid object name error", func() {
+				Expect(err).To(MatchError(ErrInvalidObjectName))
+			})
+		})
+
+		When("size mismatches", func() {
+			BeforeEach(func() { size = 1 })
+
+			It("returns an error and stores nothing", func() {
+				Expect(err).To(HaveOccurred())
+
+				_, err := storage.StatObject(ctx, objectName)
+				Expect(err).To(MatchError(ErrObjectNotFound))
+			})
+		})
+
+		When("size is unknown", func() {
+			BeforeEach(func() { size = -1 })
+
+			It("stores the object", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(readObject(ctx, storage, objectName, GetObjectOptions{})).To(Equal(content))
+			})
+		})
+
+		When("success", func() {
+			It("stores the object", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(readObject(ctx, storage, objectName, GetObjectOptions{})).To(Equal(content))
+			})
+		})
+	})
+
+	Describe("GetObject", func() {
+		var (
+			objectName string
+			opts       GetObjectOptions
+
+			content string
+			err     error
+		)
+
+		BeforeEach(func() {
+			objectName = "video.mp4"
+			opts = GetObjectOptions{}
+
+			putObject(ctx, storage, "video.mp4", "0123456789")
+		})
+
+		JustBeforeEach(func() {
+			var reader io.ReadCloser
+			if reader, err = storage.GetObject(ctx, objectName, opts); err == nil {
+				data, rerr := io.ReadAll(reader)
+				Expect(rerr).NotTo(HaveOccurred())
+				Expect(reader.Close()).To(Succeed())
+
+				content = string(data)
+			}
+		})
+
+		When("object not found", func() {
+			BeforeEach(func() { objectName = "not-found.mp4" })
+
+			It("returns object not found error", func() {
+				Expect(err).To(MatchError(ErrObjectNotFound))
+			})
+		})
+
+		When("range is given", func() {
+			BeforeEach(func() { opts = GetObjectOptions{Offset: 2, Length: 3} })
+
+			It("returns the range of the object", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(content).To(Equal("234"))
+			})
+		})
+
+		When("range exceeds the object", func() {
+			BeforeEach(func() { opts = GetObjectOptions{Offset: 7, Length: 10} })
+
+			It("returns the object from the offset to the end", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(content).To(Equal("789"))
+			})
+		})
+
+		When("range starts at the end of the object", func() {
+			BeforeEach(func() { opts = GetObjectOptions{Offset: 10, Length: 1} })
+
+			It("returns invalid range error", func() {
+				Expect(err).To(MatchError(ErrInvalidRange))
+			})
+		})
+
+		When("range starts beyond the end of the object", func() {
+			BeforeEach(func() { opts = GetObjectOptions{Offset: 20} })
+
+			It("returns invalid range error", func() {
+				Expect(err).To(MatchError(ErrInvalidRange))
+			})
+		})
+
+		When("only offset is given", func() {
+			BeforeEach(func() { opts = GetObjectOptions{Offset: 7} })
+
+			It("returns the object from the offset to the end", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(content).To(Equal("789"))
+			})
+		})
+
+		When("success", func() {
+			It("returns the whole object", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(content).To(Equal("0123456789"))
+			})
+		})
+	})
+
+	Describe("StatObject", func() {
+		var (
+			objectName string
+
+			info *ObjectInfo
+			err  error
+		)
+
+		BeforeEach(func() {
+			objectName = "videos/video.mp4"
+
+			putObject(ctx, storage, "videos/video.mp4", "video content")
+		})
+
+		JustBeforeEach(func() {
+			info, err = storage.StatObject(ctx, objectName)
+		})
+
+		When("object not found", func() {
+			BeforeEach(func() { objectName = "videos/not-found.mp4" })
+
+			It("returns object not found error", func() {
+				Expect(info).To(BeNil())
+				Expect(err).To(MatchError(ErrObjectNotFound))
+			})
+		})
+
+		When("success", func() {
+			It("returns the metadata of the object", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(info.Name).To(Equal("videos/video.mp4"))
+				Expect(info.Size).To(Equal(int64(len("video content"))))
+				Expect(info.ContentType).To(Equal("video/mp4"))
+				Expect(info.ETag).NotTo(BeEmpty())
+				Expect(info.LastModified).NotTo(BeZero())
+			})
+		})
+	})
+
+	Describe("RemoveObjects", func() {
+		var (
+			objectNames []string
+
+			err error
+		)
+
+		BeforeEach(func() {
+			objectNames = []string{"a/video.mp4", "b.mp4", "not-found.mp4"}
+
+			putObject(ctx, storage, "a/video.mp4", "a")
+			putObject(ctx, storage, "b.mp4", "b")
+			putObject(ctx, storage, "c.mp4", "c")
+		})
+
+		JustBeforeEach(func() {
+			err = storage.RemoveObjects(ctx, objectNames)
+		})
+
+		When("success", func() {
+			It("removes only the objects and ignores nonexistent ones", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(listObjectNames(ctx, storage, ListObjectsOptions{Recursive: true})).To(Equal([]string{"c.mp4"}))
+			})
+		})
+	})
+
+	Describe("ListObjects", func() {
+		var (
+			opts ListObjectsOptions
+
+			objectNames []string
+		)
+
+		BeforeEach(func() {
+			opts = ListObjectsOptions{}
+
+			putObject(ctx, storage, "a.mp4", "a")
+			putObject(ctx, storage, "b/1080p.mp4", "b")
+			putObject(ctx, storage, "b/720p.mp4", "b")
+			putObject(ctx, storage, "b/hls/index.m3u8", "b")
+		})
+
+		JustBeforeEach(func() {
+			objectNames = listObjectNames(ctx, storage, opts)
+		})
+
+		When("not recursive", func() {
+			It("groups the nested objects into prefixes", func() {
+				Expect(objectNames).To(Equal([]string{"a.mp4", "b/"}))
+			})
+		})
+
+		When("prefix is given", func() {
+			BeforeEach(func() { opts = ListObjectsOptions{Prefix: "b/"} })
+
+			It("lists the objects under the prefix", func() {
+				Expect(objectNames).To(Equal([]string{"b/1080p.mp4", "b/720p.mp4", "b/hls/"}))
+			})
+		})
+
+		When("recursive", func() {
+			BeforeEach(func() { opts = ListObjectsOptions{Prefix: "b/", Recursive: true} })
+
+			It("lists all the nested objects", func() {
+				Expect(objectNames).To(Equal([]string{"b/1080p.mp4", "b/720p.mp4", "b/hls/index.m3u8"}))
+			})
+		})
+	})
+
+	Describe("PresignedGetObject", func() {
+		It("returns presign not supported error", func() {
+			_, err := storage.PresignedGetObject(ctx, "video.mp4")
+			Expect(err).To(MatchError(ErrPresignNotSupported))
+		})
+	})
+
+	Describe("PresignedPutObject", func() {
+		It("returns presign not supported error", func() {
+			_, err := storage.PresignedPutObject(ctx, "video.mp4")
+			Expect(err).To(MatchError(ErrPresignNotSupported))
+		})
+	})
+
+	Describe("MultipartUpload", func() {
+		var (
+			objectName string
+			uploadID   string
+		)
+
+		BeforeEach(func() {
+			objectName = "video.mp4"
+
+			var err error
+			uploadID, err = storage.NewMultipartUpload(ctx, objectName, PutObjectOptions{ContentType: "video/mp4"})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		When("upload is completed", func() {
+			It("concatenates the parts in order", func() {
+				part2, err := storage.PutObjectPart(ctx, objectName, uploadID, 2, strings.NewReader("world"), 5)
+				Expect(err).NotTo(HaveOccurred())
+				part1, err := storage.PutObjectPart(ctx, objectName, uploadID, 1, strings.NewReader("hello "), 6)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(storage.CompleteMultipartUpload(ctx, objectName, uploadID, []*ObjectPart{part1, part2})).To(Succeed())
+				Expect(readObject(ctx, storage, objectName, GetObjectOptions{})).To(Equal("hello world"))
+			})
+		})
+
+		When("upload is aborted", func() {
+			It("stores nothing and forgets the upload", func() {
+				_, err := storage.PutObjectPart(ctx, objectName, uploadID, 1, strings.NewReader("hello"), 5)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(storage.AbortMultipartUpload(ctx, objectName, uploadID)).To(Succeed())
+
+				_, err = storage.StatObject(ctx, objectName)
+				Expect(err).To(MatchError(ErrObjectNotFound))
+
+				_, err = storage.PutObjectPart(ctx, objectName, uploadID, 2, strings.NewReader("world"), 5)
+				Expect(err).To(MatchError(ErrUploadNotFound))
+			})
+		})
+
+		When("upload ID is unknown", func() {
+			It("returns upload not found error", func() {
+				Expect(storage.CompleteMultipartUpload(ctx, objectName, "unknown", nil)).To(MatchError(ErrUploadNotFound))
+			})
+		})
+	})
+}
+
+func putObject(ctx context.Context, storage Storage, objectName string, content string) {
+	Expect(storage.PutObject(ctx, objectName, strings.NewReader(content), int64(len(content)), PutObjectOptions{})).
+		To(Succeed())
+}
+
+func readObject(ctx context.Context, storage Storage, objectName string, opts GetObjectOptions) string {
+	reader, err := storage.GetObject(ctx, objectName, opts)
+	Expect(err).NotTo(HaveOccurred())
+	defer reader.Close()
+
+	var buf bytes.Buffer
+	_, err = io.Copy(&buf, reader)
+	Expect(err).NotTo(HaveOccurred())
+
+	return buf.String()
+}
+
+func listObjectNames(ctx context.Context, storage Storage, opts ListObjectsOptions) []string {
+	objects, err := storage.ListObjects(ctx, opts)
+	Expect(err).NotTo(HaveOccurred())
+
+	objectNames := make([]string, 0, len(objects))
+	for _, object := range objects {
+		objectNames = append(objectNames, object.Name)
+	}
+
+	return objectNames
+}
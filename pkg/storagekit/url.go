@@ -0,0 +1,119 @@
+package storagekit
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/logkit"
+	"go.uber.org/zap"
+)
+
+const (
+	URLStylePath        = "path"
+	URLStyleVirtualHost = "virtual-host"
+)
+
+type URLConfig struct {
+	BaseURL string `long:"base_url" env:"BASE_URL" description:"the public base URL of the objects such as a CDN, the objects are served under it without the bucket name"`
+	Scheme  string `long:"scheme" env:"SCHEME" description:"the scheme of the public URLs built from the storage endpoint" choice:"http" choice:"https" default:"https"`
+	Style   string `long:"style" env:"STYLE" description:"the style of the public URLs built from the storage endpoint" choice:"path" choice:"virtual-host" default:"path"`
+	Public  bool   `long:"public" env:"PUBLIC" description:"serve the objects by public URLs instead of presigned URLs"`
+}
+
+// URLBuilder builds the URLs of the stored objects from the object names,
+// so the stored documents only keep the object names and the URLs can be
+// changed, for example moving to another CDN, without rewriting the documents.
+type URLBuilder struct {
+	storage Storage
+	baseURL *url.URL
+	scheme  string
+	style   string
+	public  bool
+}
+
+// PublicURL returns the public URL of the object, which is under the base URL if it is configured,
+// otherwise it is built from the storage endpoint and the bucket with the configured scheme and style.
+// A storage without an endpoint, such as the file system storage, requires the base URL.
+func (b *URLBuilder) PublicURL(objectName string) (string, error) {
+	if objectName == "" {
+		return "", nil
+	}
+
+	if b.baseURL != nil {
+		u := *b.baseURL
+		u.Path = path.Join("/", b.baseURL.Path, objectName)
+
+		return u.String(), nil
+	}
+
+	if b.storage.Endpoint() == "" {
+		return "", ErrBaseURLRequired
+	}
+
+	u := url.URL{
+		Scheme: b.scheme,
+		Host:   b.storage.Endpoint(),
+		Path:   path.Join("/", b.storage.Bucket(), objectName),
+	}
+
+	if b.style == URLStyleVirtualHost {
+		u.Host = b.storage.Bucket() + "." + u.Host
+		u.Path = path.Join("/", objectName)
+	}
+
+	return u.String(), nil
+}
+
+// ObjectURL returns the URL for clients to download the object, which is a presigned URL
+// unless the objects are public or the storage does not support presigned URLs.
+func (b *URLBuilder) ObjectURL(ctx context.Context, objectName string) (string, error) {
+	if objectName == "" {
+		return "", nil
+	}
+
+	if b.public {
+		return b.PublicURL(objectName)
+	}
+
+	presignedURL, err := b.storage.PresignedGetObject(ctx, objectName)
+	if err != nil {
+		if errors.Is(err, ErrPresignNotSupported) {
+			return b.PublicURL(objectName)
+		}
+
+		return "", err
+	}
+
+	return presignedURL.URL, nil
+}
+
+func NewURLBuilder(ctx context.Context, conf *URLConfig, storage Storage) *URLBuilder {
+	logger := logkit.FromContext(ctx).
+		With(zap.String("base_url", conf.BaseURL)).
+		With(zap.String("style", conf.Style))
+
+	b := &URLBuilder{
+		storage: storage,
+		scheme:  conf.Scheme,
+		style:   conf.Style,
+		public:  conf.Public,
+	}
+
+	if b.scheme == "" {
+		b.scheme = "https"
+	}
+
+	if conf.BaseURL != "" {
+		baseURL, err := url.Parse(strings.TrimSuffix(conf.BaseURL, "/"))
+		if err != nil || baseURL.Scheme == "" || baseURL.Host == "" {
+			logger.Fatal("invalid base URL", zap.Error(err))
+		}
+
+		b.baseURL = baseURL
+	}
+
+	return b
+}
@@ -0,0 +1,139 @@
+package storagekit
+
+import (
+	"context"
+
+	"github.com/NTHU-LSALAB/NTHU-Distributed-System/pkg/logkit"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// presignedMemoryStorage is an in-memory storage that presigns the object URLs
+type presignedMemoryStorage struct {
+	*MemoryStorage
+}
+
+func (s *presignedMemoryStorage) PresignedGetObject(ctx context.Context, objectName string) (*PresignedURL, error) {
+	return &PresignedURL{URL: "https://presigned.example.com/" + objectName + "?signature=signature"}, nil
+}
+
+var _ = Describe("URLBuilder", func() {
+	var (
+		ctx     context.Context
+		conf    *URLConfig
+		storage Storage
+		builder *URLBuilder
+	)
+
+	BeforeEach(func() {
+		ctx = logkit.WithContext(context.Background(), logkit.NewNopLogger())
+		conf = &URLConfig{Scheme: "https", Style: URLStylePath}
+		storage = NewMemoryStorage(ctx, &MemoryConfig{Bucket: "videos"})
+	})
+
+	JustBeforeEach(func() {
+		builder = NewURLBuilder(ctx, conf, storage)
+	})
+
+	Describe("PublicURL", func() {
+		When("object name is empty", func() {
+			It("returns empty URL", func() {
+				url, err := builder.PublicURL("")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(url).To(BeEmpty())
+			})
+		})
+
+		When("base URL is configured", func() {
+			BeforeEach(func() { conf.BaseURL = "https://cdn.example.com/media/" })
+
+			It("returns the URL under the base URL without the bucket", func() {
+				url, err := builder.PublicURL("a/video 1.mp4")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(url).To(Equal("https://cdn.example.com/media/a/video%201.mp4"))
+			})
+		})
+
+		When("path style", func() {
+			BeforeEach(func() { conf.Scheme = "http" })
+
+			It("returns the URL with the bucket in the path", func() {
+				url, err := builder.PublicURL("video.mp4")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(url).To(Equal("http://memory/videos/video.mp4"))
+			})
+		})
+
+		When("virtual-host style", func() {
+			BeforeEach(func() { conf.Style = URLStyleVirtualHost })
+
+			It("returns the URL with the bucket in the host", func() {
+				url, err := builder.PublicURL("video.mp4")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(url).To(Equal("https://videos.memory/video.mp4"))
+			})
+		})
+
+		When("storage has no endpoint", func() {
+			BeforeEach(func() {
+				storage = NewFileSystemStorage(ctx, &FileSystemConfig{Root: GinkgoT().TempDir(), Bucket: "videos"})
+			})
+
+			It("returns base URL required error", func() {
+				url, err := builder.PublicURL("video.mp4")
+				Expect(err).To(MatchError(ErrBaseURLRequired))
+				Expect(url).To(BeEmpty())
+			})
+
+			It("returns the URL under the base URL if it is configured", func() {
+				conf.BaseURL = "https://cdn.example.com"
+				builder = NewURLBuilder(ctx, conf, storage)
+
+				url, err := builder.PublicURL("video.mp4")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(url).To(Equal("https://cdn.example.com/video.mp4"))
+			})
+		})
+	})
+
+	Describe("ObjectURL", func() {
+		var (
+			url string
+			err error
+		)
+
+		JustBeforeEach(func() {
+			url, err = builder.ObjectURL(ctx, "video.mp4")
+		})
+
+		When("storage does not support presigned URL", func() {
+			It("returns the public URL", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(url).To(Equal("https://memory/videos/video.mp4"))
+			})
+		})
+
+		When("objects are public", func() {
+			BeforeEach(func() {
+				conf.Public = true
+				storage = &presignedMemoryStorage{MemoryStorage: NewMemoryStorage(ctx, &MemoryConfig{Bucket: "videos"})}
+			})
+
+			It("returns the public URL", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(url).To(Equal("https://memory/videos/video.mp4"))
+			})
+		})
+
+		When("success", func() {
+			BeforeEach(func() {
+				storage = &presignedMemoryStorage{MemoryStorage: NewMemoryStorage(ctx, &MemoryConfig{Bucket: "videos"})}
+			})
+
+			It("returns the presigned URL", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(url).To(Equal("https://presigned.example.com/video.mp4?signature=signature"))
+			})
+		})
+	})
+})